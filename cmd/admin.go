@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/wiring"
+)
+
+const adminUsage = `usage: main admin [--config file] [--override KEY=VALUE] <command> [flags]
+
+commands:
+  adjust-balance  credit or debit a wallet, recording the reason
+  expire-tx       expire a verified top-up immediately
+  reconcile       list wallets whose balance does not match completed top-ups plus adjustments
+  reconcile-settlement
+                  match a payment provider settlement file against top-ups and store the report`
+
+// runAdmin runs an operator command against the same use cases the API uses
+func runAdmin(args []string) error {
+	flags := flag.NewFlagSet("admin", flag.ContinueOnError)
+	opts := configFlags(flags)
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, adminUsage) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if args = flags.Args(); len(args) == 0 {
+		return fmt.Errorf("missing admin command\n%s", adminUsage)
+	}
+	if args[0] == "help" {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	var run func(ctx context.Context, uc wiring.Usecases, args []string) error
+	switch args[0] {
+	case "adjust-balance":
+		run = adminAdjustBalance
+	case "expire-tx":
+		run = adminExpireTransaction
+	case "reconcile":
+		run = adminReconcile
+	case "reconcile-settlement":
+		run = adminReconcileSettlement
+	default:
+		return fmt.Errorf("unknown admin command %q\n%s", args[0], adminUsage)
+	}
+
+	b, err := newBase(*opts)
+	if err != nil {
+		return err
+	}
+	c, err := wiring.New(b)
+	if err == nil {
+		ctx, stop := commandContext()
+		err = run(ctx, c.Usecases, args[1:])
+		stop()
+	}
+	return errors.Join(err, b.Close(context.Background()))
+}
+
+func adminAdjustBalance(ctx context.Context, uc wiring.Usecases, args []string) error {
+	flags := flag.NewFlagSet("admin adjust-balance", flag.ContinueOnError)
+	walletID := flags.Uint("wallet", 0, "wallet ID (required)")
+	amount := flags.Float64("amount", 0, "amount to add; negative to debit (required)")
+	reason := flags.String("reason", "", "why the balance is being adjusted (required)")
+	actor := flags.String("actor", currentUser(), "operator recorded with the adjustment")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *walletID == 0 {
+		return fmt.Errorf("--wallet is required")
+	}
+
+	w, err := uc.Admin.AdjustBalance(ctx, *walletID, *amount, *reason, *actor)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("wallet %d adjusted by %.2f, new balance %s\n", w.ID, *amount, w.Balance)
+	return nil
+}
+
+func adminExpireTransaction(ctx context.Context, uc wiring.Usecases, args []string) error {
+	flags := flag.NewFlagSet("admin expire-tx", flag.ContinueOnError)
+	transactionID := flags.Uint("id", 0, "transaction ID (required)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *transactionID == 0 {
+		return fmt.Errorf("--id is required")
+	}
+
+	tx, err := uc.Admin.ExpireTransaction(ctx, *transactionID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("transaction %d expired\n", tx.ID)
+	return nil
+}
+
+// adminReconcile exits non-zero when any wallet is out of balance, so it can run from cron
+func adminReconcile(ctx context.Context, uc wiring.Usecases, args []string) error {
+	flags := flag.NewFlagSet("admin reconcile", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	discrepancies, err := uc.Admin.Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+	if len(discrepancies) == 0 {
+		fmt.Println("all wallets reconcile")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WALLET\tBALANCE\tEXPECTED\tDIFFERENCE")
+	for _, d := range discrepancies {
+		fmt.Fprintf(w, "%d\t%.2f\t%.2f\t%+.2f\n", d.WalletID, d.Balance, d.Expected, d.Difference())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%d wallets do not reconcile", len(discrepancies))
+}
+
+// adminReconcileSettlement exits non-zero when the settlement does not reconcile, so a daily
+// cron job can alert on it
+func adminReconcileSettlement(ctx context.Context, uc wiring.Usecases, args []string) error {
+	flags := flag.NewFlagSet("admin reconcile-settlement", flag.ContinueOnError)
+	path := flags.String("file", "", "settlement file to reconcile (required)")
+	date := flags.String("date", time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly), "settlement date, YYYY-MM-DD")
+	format := flags.String("format", "", "csv or json; taken from the file extension when empty")
+	provider := flags.String("provider", "", "payment provider; the configured provider when empty")
+	actor := flags.String("actor", currentUser(), "operator recorded with the report")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("--file is required")
+	}
+	settlementDate, err := time.Parse(time.DateOnly, *date)
+	if err != nil {
+		return fmt.Errorf("--date must be YYYY-MM-DD: %w", err)
+	}
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := uc.Reconciliation.Reconcile(ctx, reconciliation.Spec{
+		Provider:       *provider,
+		SettlementDate: settlementDate,
+		FileName:       filepath.Base(*path),
+		Format:         *format,
+		Actor:          *actor,
+	}, file)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("report %d: %s settlement for %s, %d records\n",
+		report.ID, report.Provider, report.SettlementDate.Format(time.DateOnly), report.Records)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "matched\t%d\nmissing in ours\t%d\nmissing in theirs\t%d\namount mismatch\t%d\n",
+		report.Matched, report.MissingInOurs, report.MissingInTheirs, report.AmountMismatch)
+	if report.Discrepancies() > 0 {
+		fmt.Fprintln(w, "\nSTATUS\tREFERENCE\tTRANSACTION\tPROVIDER\tOURS")
+		for _, item := range report.Items {
+			if item.Status == reconciliation.StatusMatched {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", item.Status, item.ProviderReference, item.TransactionID, item.ProviderAmount, item.TransactionAmount)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if report.Discrepancies() > 0 {
+		return fmt.Errorf("%d settlement records do not reconcile", report.Discrepancies())
+	}
+	return nil
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "cli"
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/wiring"
+)
+
+// configFlags registers the --config and --override flags shared by every subcommand
+func configFlags(flags *flag.FlagSet) *config.Options {
+	opts := &config.Options{}
+	flags.StringVar(&opts.File, "config", "", "YAML configuration file (default $CONFIG_FILE)")
+	flags.Func("override", "KEY=VALUE setting applied over the file and environment; repeatable", func(value string) error {
+		opts.Overrides = append(opts.Overrides, value)
+		return nil
+	})
+	return opts
+}
+
+// newBase loads configuration and connects the logger and database
+func newBase(opts config.Options) (*wiring.Base, error) {
+	cfg, err := config.Load(opts)
+	if err != nil {
+		return nil, err
+	}
+	return wiring.NewBase(cfg, wiring.Overrides{})
+}
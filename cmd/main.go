@@ -1,84 +1,71 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
-	"time"
-
-	"github.com/gofiber/fiber/v2"
-	"github.com/hydr0g3nz/wallet_topup_system/config"
-	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/controller"
-	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/repository"
-	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
-	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 )
 
-func main() {
-	// Load configuration
-	config := config.LoadFromEnv()
-	// Setup logger
-	logger, err := infrastructure.NewLogger(config.IsProduction())
-	if err != nil {
-		log.Fatalf("Failed to initialize logger: %v", err)
-	}
-	defer logger.Close()
+// command is a subcommand of the wallet binary
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
 
-	// // Connect to database
-	db, err := infrastructure.ConnectDB(&config.Database)
-	if err != nil {
-		logger.Fatal("Failed to connect to database", map[string]interface{}{
-			"error": err.Error()})
-	}
+var commands = []command{
+	{name: "serve", summary: "run the HTTP API (and background workers unless --workers is empty)", run: runServe},
+	{name: "worker", summary: "run background workers without the HTTP API", run: runWorker},
+	{name: "migrate", summary: "apply or roll back database migrations", run: runMigrate},
+	{name: "seed", summary: "load fixture data into the database", run: runSeed},
+	{name: "admin", summary: "operator commands: adjust-balance, expire-tx, reconcile, reconcile-settlement", run: runAdmin},
+}
 
-	// Run migrations
-	if err := infrastructure.MigrateDB(db); err != nil {
-		logger.Fatal("Failed to run database migrations", map[string]interface{}{
-			"error": err.Error()})
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
 	}
-
-	// Seed database with initial data
-	if err := infrastructure.SeedDB(db); err != nil {
-		logger.Fatal("Failed to seed database", map[string]interface{}{
-			"error": err.Error()})
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
 	}
-
-	cache := infrastructure.NewRedisClient(config.Cache)
-
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
-	transactionRepo := repository.NewTransactionRepository(db)
-	walletRepo := repository.NewWalletRepository(db)
-	// txRepo := repository.NewDBTransactionRepository(db)
-	txManager := repository.NewTxManagerGorm(db)
-
-	// Initialize use cases
-	walletUsecase := usecase.NewWalletUsecase(userRepo, transactionRepo, walletRepo, cache, txManager, logger, *config)
-
-	// Setup server
-	server := infrastructure.NewFiber(infrastructure.ServerConfig{
-		Address:      config.Server.Port,
-		ReadTimeout:  time.Duration(config.Server.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(config.Server.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(config.Server.ReadTimeout) * time.Second,
-	})
-	registerRoutes(server, walletUsecase)
-	// Start server
-	logger.Info("Starting server", map[string]interface{}{"port": config.Server.Port})
-
-	if err := server.Listen(fmt.Sprintf(":%s", config.Server.Port)); err != nil {
-		logger.Fatal("Failed to start server", map[string]interface{}{
-			"error": err.Error()})
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		if err := cmd.run(os.Args[2:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+			// The logger may already be closed, so report on stderr
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
 	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
 }
 
-// registerRoutes registers all API routes
-func registerRoutes(
-	app *fiber.App,
-	walletUseCase usecase.WalletUsecase,
+func usage() {
+	var b strings.Builder
+	b.WriteString("usage: main <command> [flags]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	b.WriteString("\nRun 'main <command> -h' for command flags.\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
 
-) {
-	// Setup API routes
-	api := app.Group("/api/v1")
-	walletController := controller.NewWalletController(walletUseCase)
-	walletController.RegisterRoutes(api)
+// commandContext returns a context cancelled on SIGINT or SIGTERM, for commands that run to completion
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 }
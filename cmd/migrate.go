@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+)
+
+const migrateUsage = `usage: main migrate [--config file] [--override KEY=VALUE] <command>
+
+commands:
+  up              apply all pending migrations
+  down [steps]    roll back the last applied migrations (default 1)
+  status          list migrations and when they were applied
+  to <version>    migrate up or down to the given version (0 rolls back everything)`
+
+// runMigrate runs the migrate subcommand. Replicas wait on a Postgres advisory lock,
+// so running it from several places at once is safe.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	opts := configFlags(flags)
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, migrateUsage) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if args = flags.Args(); len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+	if args[0] == "help" {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	b, err := newBase(*opts)
+	if err != nil {
+		return err
+	}
+	migrator, err := infrastructure.NewMigrator(b.DB, b.Logger)
+	if err == nil {
+		ctx, stop := commandContext()
+		err = migrate(ctx, migrator, args)
+		stop()
+	}
+	return errors.Join(err, b.Close(context.Background()))
+}
+
+func migrate(ctx context.Context, migrator *infrastructure.Migrator, args []string) error {
+	var err error
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("missing target version\n%s", migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		return migrator.To(ctx, version)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Missing {
+				appliedAt += " (no migration file)"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strings"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+)
+
+// runSeed loads one or more fixture sets. Run `migrate up` first.
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	opts := configFlags(flags)
+	sets := flags.String("set", "demo",
+		"comma-separated fixture sets to load ("+strings.Join(infrastructure.FixtureSets(), ", ")+")")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	b, err := newBase(*opts)
+	if err != nil {
+		return err
+	}
+	for _, set := range strings.Split(*sets, ",") {
+		if err = infrastructure.SeedFixtures(b.DB, strings.TrimSpace(set)); err != nil {
+			break
+		}
+	}
+	return errors.Join(err, b.Close(context.Background()))
+}
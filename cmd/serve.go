@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/wiring"
+)
+
+// runServe runs the HTTP API until SIGINT or SIGTERM
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	opts := configFlags(flags)
+	workerList := flags.String("workers", strings.Join(wiring.WorkerNames, ","),
+		"comma-separated background workers to run in-process ("+strings.Join(wiring.WorkerNames, ", ")+"); empty runs none")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	workers, err := wiring.ParseWorkers(*workerList)
+	if err != nil {
+		return err
+	}
+
+	b, err := newBase(*opts)
+	if err != nil {
+		return err
+	}
+	c, err := wiring.New(b)
+	if err != nil {
+		return errors.Join(err, b.Close(context.Background()))
+	}
+	config := c.Config
+	lifecycle := b.NewLifecycle(time.Duration(config.Server.DrainDelay) * time.Second)
+	c.StartWorkers(lifecycle, workers)
+
+	// Setup server
+	server := infrastructure.NewFiber(infrastructure.ServerConfig{
+		Address:      config.Server.Port,
+		ReadTimeout:  time.Duration(config.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(config.Server.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(config.Server.ReadTimeout) * time.Second,
+	}, c.Logger)
+	server.Use(infrastructure.TracingMiddleware())
+	server.Use(c.Metrics.Middleware())
+	server.Get("/metrics", c.Metrics.Handler())
+	c.RegisterHealthRoutes(server)
+	c.RegisterAPIRoutes(server)
+	// On SIGINT/SIGTERM readiness fails first, then in-flight requests drain, workers stop and connections close
+	lifecycle.OnShutdown(c.Usecases.Health.MarkShuttingDown)
+
+	// Start server
+	c.Logger.Info("Starting server", map[string]interface{}{"port": config.Server.Port, "workers": workers})
+	return lifecycle.Run(
+		func() error { return server.Listen(fmt.Sprintf(":%s", config.Server.Port)) },
+		server.ShutdownWithContext,
+	)
+}
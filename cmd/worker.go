@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/wiring"
+)
+
+// runWorker runs background workers without the public API, so they can be scaled separately
+func runWorker(args []string) error {
+	flags := flag.NewFlagSet("worker", flag.ContinueOnError)
+	opts := configFlags(flags)
+	workerList := flags.String("workers", strings.Join(wiring.WorkerNames, ","),
+		"comma-separated workers to run ("+strings.Join(wiring.WorkerNames, ", ")+")")
+	listen := flags.String("listen", "", "optional address such as :9090 serving /metrics, /healthz and /readyz")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	workers, err := wiring.ParseWorkers(*workerList)
+	if err != nil {
+		return err
+	}
+	if len(workers) == 0 {
+		return fmt.Errorf("no workers selected")
+	}
+
+	b, err := newBase(*opts)
+	if err != nil {
+		return err
+	}
+	c, err := wiring.New(b)
+	if err != nil {
+		return errors.Join(err, b.Close(context.Background()))
+	}
+	// Nothing routes traffic to a worker, so there is no drain delay
+	lifecycle := b.NewLifecycle(0)
+	c.StartWorkers(lifecycle, workers)
+	c.Logger.Info("Starting workers", map[string]interface{}{"workers": workers})
+
+	if *listen == "" {
+		stop := make(chan struct{})
+		return lifecycle.Run(
+			func() error { <-stop; return nil },
+			func(context.Context) error { close(stop); return nil },
+		)
+	}
+
+	server := infrastructure.NewFiber(infrastructure.ServerConfig{
+		Address:      *listen,
+		ReadTimeout:  time.Duration(c.Config.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(c.Config.Server.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(c.Config.Server.ReadTimeout) * time.Second,
+	}, c.Logger)
+	server.Get("/metrics", c.Metrics.Handler())
+	c.RegisterHealthRoutes(server)
+	lifecycle.OnShutdown(c.Usecases.Health.MarkShuttingDown)
+	return lifecycle.Run(func() error { return server.Listen(*listen) }, server.ShutdownWithContext)
+}
@@ -1,24 +1,189 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"time"
 
 	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
 	"github.com/joho/godotenv"
 )
 
+// Deployment environments accepted in Server.Environment
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "production"
+)
+
 // Config holds application configuration
 type Config struct {
-	Server   ServerConfig
-	Database infrastructure.DBConfig
-	Cache    infrastructure.CacheConfig
-	LogLevel string
-	App      AppConfig
+	Server       ServerConfig
+	Database     infrastructure.DBConfig
+	Cache        infrastructure.CacheConfig
+	Storage      infrastructure.StorageConfig
+	Log          infrastructure.LogConfig
+	App          AppConfig
+	KYC          KYCConfig
+	Risk         RiskConfig
+	Outbox       OutboxConfig
+	Events       EventsConfig
+	CacheBackend CacheBackendConfig
+	Lock         LockConfig
+	Tracing      infrastructure.TracingConfig
+	Webhook      WebhookConfig
+	Health       HealthConfig
+	Expiry       ExpiryConfig
+	Settings     SettingsConfig
+	Pricing      PricingConfig
+	Payment      PaymentConfig
+	AutoTopup    AutoTopupConfig
+	Admin        AdminConfig
 }
+
+// AppConfig holds top-up defaults. They can be changed at runtime through the settings API.
 type AppConfig struct {
 	MaxAcceptedAmount float64
+	VerificationTTL   int      // in seconds
+	PaymentMethods    []string // enabled for every KYC tier that allows them
+}
+
+// SettingsConfig holds settings for the runtime settings store
+type SettingsConfig struct {
+	CacheTTL int // in seconds
+}
+
+// PricingConfig holds the default fee and bonus rules as JSON arrays. They can be changed at
+// runtime through the settings API.
+type PricingConfig struct {
+	Fees    string
+	Bonuses string
+}
+
+// KYCConfig holds the top-up limit and allowed payment methods for each KYC tier
+type KYCConfig struct {
+	Tier0MaxAmount      float64
+	Tier1MaxAmount      float64
+	Tier2MaxAmount      float64
+	Tier0PaymentMethods []string
+	Tier1PaymentMethods []string
+	Tier2PaymentMethods []string
+}
+
+// RiskConfig holds the fraud rule parameters and decision thresholds
+type RiskConfig struct {
+	ChallengeScore        int
+	RejectScore           int
+	VelocityWindow        int // in minutes
+	VelocityMaxCount      int
+	VelocityScore         int
+	NewAccountAge         int // in hours
+	NewAccountLargeAmount float64
+	NewAccountScore       int
+	FailedWindow          int // in hours
+	FailedMaxCount        int
+	FailedScore           int
+	NearLimitRatio        float64
+	NearLimitScore        int
+}
+
+// OutboxConfig holds outbox relay settings
+type OutboxConfig struct {
+	PollInterval int // in milliseconds
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  int // in seconds
+	MaxBackoff   int // in seconds
+}
+
+// ExpiryConfig holds settings for the sweeper that expires unconfirmed top-ups
+type ExpiryConfig struct {
+	Interval  int // in seconds
+	BatchSize int
+}
+
+// DevelopmentInstrumentKey is the default PAYMENT_INSTRUMENT_KEY. It is public, so production
+// must set its own key.
+const DevelopmentInstrumentKey = "ZGV2LW9ubHktaW5zdHJ1bWVudC1rZXktMzJieXRlcyE="
+
+// PaymentConfig selects the payment provider and protects saved payment instruments
+type PaymentConfig struct {
+	Provider string // "sandbox"
+	// InstrumentKey is the base64-encoded 32-byte AES-256-GCM key that encrypts saved instruments
+	InstrumentKey string
+}
+
+// DevelopmentAdminAPIKey is the default ADMIN_API_KEY. It is public, so production must set its
+// own key.
+const DevelopmentAdminAPIKey = "dev-only-admin-api-key-change-me"
+
+// AdminConfig protects the /api/v1/admin routes
+type AdminConfig struct {
+	// APIKey must be sent as a bearer token on every admin request
+	APIKey string
+}
+
+// AutoTopupConfig holds settings for the scheduler that runs auto top-up rules
+type AutoTopupConfig struct {
+	Interval          int // in seconds
+	BatchSize         int
+	MaxAttempts       int // charge attempts per run before the rule reports a failure
+	BaseBackoff       int // in seconds
+	MaxBackoff        int // in seconds
+	Lease             int // in seconds, how long a claimed rule is hidden from other schedulers
+	ThresholdCooldown int // in seconds, minimum time between threshold runs of one rule
+}
+
+// CacheBackendConfig selects the cache.CacheService implementation
+type CacheBackendConfig struct {
+	Backend             string // "redis", "memory" or "tiered"
+	MaxEntries          int    // in-memory entries kept before LRU eviction
+	JanitorInterval     int    // in seconds
+	LocalTTL            int    // in seconds, how long the tiered cache keeps a local copy
+	InvalidationChannel string // Redis pub/sub channel for tiered cache invalidation
+}
+
+// LockConfig holds distributed lock settings
+type LockConfig struct {
+	Prefix    string
+	TTL       int // in seconds, how long a lock is held if its owner dies
+	Wait      int // in milliseconds, how long to wait for a held lock
+	RetryBase int // in milliseconds
+	RetryMax  int // in milliseconds
+}
+
+// HealthConfig holds readiness check settings
+type HealthConfig struct {
+	CheckTimeout      int     // in milliseconds, per dependency
+	MaxPoolSaturation float64 // share of DB connections in use at which readiness reports degraded
+}
+
+// EventsConfig selects and configures the event publisher used by the outbox relay
+type EventsConfig struct {
+	Publisher string // "log" or "redis"
+	Stream    infrastructure.StreamConfig
+}
+
+// WebhookConfig holds outgoing merchant webhook settings
+type WebhookConfig struct {
+	PollInterval int // in milliseconds
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  int // in seconds
+	MaxBackoff   int // in seconds
+	Timeout      int // in seconds
+	DisableAfter int // consecutive failed attempts before a subscription is disabled
+}
+
+// MaxWebhookLease bounds how long a claimed batch of deliveries is held. Deliveries of a worker
+// that dies are only sent again once its lease ends.
+const MaxWebhookLease = 15 * time.Minute
+
+// Lease is how long a dispatcher holds a claimed batch. Deliveries are sent one after another, so
+// the lease covers a full timeout for each of them, plus one more for recording the outcomes.
+func (c WebhookConfig) Lease() time.Duration {
+	return time.Duration(c.BatchSize+1) * time.Duration(c.Timeout) * time.Second
 }
 
 // ServerConfig holds server configuration
@@ -28,6 +193,10 @@ type ServerConfig struct {
 	ReadTimeout  int
 	WriteTimeout int
 	Host         string
+	// ShutdownTimeout bounds the whole graceful shutdown, in seconds
+	ShutdownTimeout int
+	// DrainDelay is how long readiness reports not-ready before the listener closes, in seconds
+	DrainDelay int
 }
 
 // JWTConfig holds JWT configuration
@@ -37,68 +206,189 @@ type JWTConfig struct {
 	RefreshExpiration int // in hours
 }
 
-// LoadFromEnv loads configuration from environment variables
-func LoadFromEnv() *Config {
-	if err := godotenv.Load(); err != nil {
-		fmt.Println("No .env file found")
+// Options selects the optional configuration layers
+type Options struct {
+	// File is a YAML file applied over the defaults; CONFIG_FILE is used when empty
+	File string
+	// Overrides are KEY=VALUE pairs, using environment variable names, applied last
+	Overrides []string
+}
+
+// Load builds the configuration from defaults, then the YAML file, then environment variables
+// (including a .env file), then overrides. Malformed values and failed validation are reported together.
+func Load(opts Options) (*Config, error) {
+	// A missing .env file is normal outside local development
+	_ = godotenv.Load()
+
+	cfg := Default()
+	settings := cfg.settings()
+	var errs []error
+
+	file := opts.File
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	if file != "" {
+		errs = append(errs, loadYAML(file, settings)...)
+	}
+	errs = append(errs, loadEnv(settings)...)
+	errs = append(errs, loadOverrides(opts.Overrides, settings)...)
+	// Settings that failed to parse keep their defaults, so validation still reports everything else
+	errs = append(errs, cfg.Validate())
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
 	}
+	return &cfg, nil
+}
 
-	return &Config{
+// Default returns the built-in configuration, suitable for local development
+func Default() Config {
+	return Config{
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "localhost"),
-			Port: getEnv("PORT", "8080"),
-			// Environment:  getEnv("GIN_MODE", "debug"),
-			ReadTimeout:  getEnvAsInt("SERVER_READ_TIMEOUT", 10),  // 10 seconds
-			WriteTimeout: getEnvAsInt("SERVER_WRITE_TIMEOUT", 10), // 10 seconds
+			Host:            "localhost",
+			Port:            "8080",
+			Environment:     "development",
+			ReadTimeout:     10,
+			WriteTimeout:    10,
+			ShutdownTimeout: 30,
+			DrainDelay:      5,
 		},
 		Database: infrastructure.DBConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "hospital_middleware"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:     "localhost",
+			Port:     "5432",
+			User:     "postgres",
+			Password: "postgres",
+			DBName:   "topup_wallet",
+			SSLMode:  "disable",
 		},
 		Cache: infrastructure.CacheConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnvAsInt("REDIS_PORT", 6379),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			Db:       getEnvAsInt("REDIS_DB", 0),
+			Host:             "localhost",
+			Port:             6379,
+			Timeout:          200,
+			BreakerThreshold: 5,
+			BreakerCooldown:  10,
+		},
+		Storage: infrastructure.StorageConfig{
+			BaseDir: "storage",
 		},
 		App: AppConfig{
-			MaxAcceptedAmount: getEnvAsFloat("MAX_ACCEPTED_AMOUNT", 100000.0),
+			MaxAcceptedAmount: 100000.0,
+			VerificationTTL:   900,
+			PaymentMethods:    []string{"credit_card"},
+		},
+		Settings: SettingsConfig{
+			CacheTTL: 30,
+		},
+		Pricing: PricingConfig{
+			Fees:    "[]",
+			Bonuses: "[]",
+		},
+		KYC: KYCConfig{
+			Tier0MaxAmount:      5000.0,
+			Tier1MaxAmount:      50000.0,
+			Tier2MaxAmount:      100000.0,
+			Tier0PaymentMethods: []string{"credit_card"},
+			Tier1PaymentMethods: []string{"credit_card"},
+			Tier2PaymentMethods: []string{"credit_card"},
+		},
+		Risk: RiskConfig{
+			ChallengeScore:        50,
+			RejectScore:           100,
+			VelocityWindow:        10,
+			VelocityMaxCount:      5,
+			VelocityScore:         40,
+			NewAccountAge:         24,
+			NewAccountLargeAmount: 10000.0,
+			NewAccountScore:       40,
+			FailedWindow:          24,
+			FailedMaxCount:        3,
+			FailedScore:           30,
+			NearLimitRatio:        0.95,
+			NearLimitScore:        20,
+		},
+		Outbox: OutboxConfig{
+			PollInterval: 1000,
+			BatchSize:    100,
+			MaxAttempts:  10,
+			BaseBackoff:  1,
+			MaxBackoff:   300,
+		},
+		Expiry: ExpiryConfig{
+			Interval:  60,
+			BatchSize: 100,
+		},
+		Payment: PaymentConfig{
+			Provider:      "sandbox",
+			InstrumentKey: DevelopmentInstrumentKey,
+		},
+		Admin: AdminConfig{
+			APIKey: DevelopmentAdminAPIKey,
+		},
+		AutoTopup: AutoTopupConfig{
+			Interval:          30,
+			BatchSize:         50,
+			MaxAttempts:       3,
+			BaseBackoff:       60,
+			MaxBackoff:        3600,
+			Lease:             300,
+			ThresholdCooldown: 3600,
+		},
+		CacheBackend: CacheBackendConfig{
+			Backend:             "redis",
+			MaxEntries:          10000,
+			JanitorInterval:     60,
+			LocalTTL:            30,
+			InvalidationChannel: "cache:invalidate",
+		},
+		Lock: LockConfig{
+			Prefix:    "lock:",
+			TTL:       30,
+			Wait:      5000,
+			RetryBase: 20,
+			RetryMax:  500,
+		},
+		Tracing: infrastructure.TracingConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "localhost:4318",
+			OTLPInsecure: true,
+			ServiceName:  "wallet-topup",
+			SampleRatio:  1.0,
+		},
+		Health: HealthConfig{
+			CheckTimeout:      2000,
+			MaxPoolSaturation: 0.9,
+		},
+		Events: EventsConfig{
+			Publisher: "log",
+			Stream: infrastructure.StreamConfig{
+				Key:        "wallet:events",
+				MaxLen:     100000,
+				ApproxTrim: true,
+			},
+		},
+		Webhook: WebhookConfig{
+			PollInterval: 1000,
+			BatchSize:    20,
+			MaxAttempts:  8,
+			BaseBackoff:  5,
+			MaxBackoff:   3600,
+			Timeout:      10,
+			DisableAfter: 20,
+		},
+		Log: infrastructure.LogConfig{
+			Level:       "info",
+			Dir:         "logs",
+			FileName:    "app.log",
+			MaxSizeMB:   100,
+			MaxBackups:  14,
+			MaxAgeDays:  30,
+			Compress:    true,
+			RotateDaily: true,
 		},
-		LogLevel: getEnv("LOG_LEVEL", "info"),
 	}
 }
 
 // IsProduction returns true if the environment is production
 func (c *Config) IsProduction() bool {
-	return c.Server.Environment == "release"
-}
-
-// getEnvAsInt gets an environment variable as an integer
-func getEnvAsInt(key string, defaultValue int) int {
-	if value, exists := os.LookupEnv(key); exists {
-		intValue, err := strconv.Atoi(value)
-		if err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
-func getEnvAsFloat(key string, defaultValue float64) float64 {
-	if value, exists := os.LookupEnv(key); exists {
-		floatValue, err := strconv.ParseFloat(value, 64)
-		if err == nil {
-			return floatValue
-		}
-	}
-	return defaultValue
-}
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	return defaultValue
+	return c.Server.Environment == EnvironmentProduction
 }
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes content to a file in a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadAppliesSourcesInOrder(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+server:
+  port: "9000"
+  read_timeout: 20
+  write_timeout: 30
+database:
+  host: db.internal
+app:
+  payment_methods: [credit_card, bank_transfer]
+pricing:
+  fees:
+    - payment_method: credit_card
+      percent: 2.5
+`)
+	t.Setenv("SERVER_READ_TIMEOUT", "25")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "35")
+
+	cfg, err := Load(Options{File: file, Overrides: []string{"SERVER_WRITE_TIMEOUT=40"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, Default().Server.ShutdownTimeout, cfg.Server.ShutdownTimeout, "defaults apply when nothing overrides them")
+	assert.Equal(t, "9000", cfg.Server.Port, "YAML overrides defaults")
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, []string{"credit_card", "bank_transfer"}, cfg.App.PaymentMethods, "YAML lists are read")
+	assert.JSONEq(t, `[{"payment_method":"credit_card","percent":2.5}]`, cfg.Pricing.Fees, "structured YAML is stored as JSON")
+	assert.Equal(t, 25, cfg.Server.ReadTimeout, "the environment overrides YAML")
+	assert.Equal(t, 40, cfg.Server.WriteTimeout, "overrides win over the environment")
+}
+
+func TestLoadReadsSecretsFromFiles(t *testing.T) {
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "s3cret\n"))
+
+	cfg, err := Load(Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", cfg.Database.Password, "the trailing newline is dropped")
+	assert.Equal(t, "******", cfg.Redacted()["DB_PASSWORD"])
+}
+
+func TestLoadRejectsAValueAndAFileForTheSameSetting(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "s3cret")
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "s3cret"))
+
+	_, err := Load(Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_PASSWORD: set either DB_PASSWORD or DB_PASSWORD_FILE, not both")
+}
+
+func TestLoadReportsAMissingSecretFile(t *testing.T) {
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	_, err := Load(Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_PASSWORD_FILE:")
+}
+
+func TestLoadRejectsUnknownAndMalformedKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		env       map[string]string
+		overrides []string
+		want      string
+	}{
+		{name: "unknown YAML key", yaml: "server:\n  prot: 8080\n", want: `unknown key "server.prot"`},
+		{name: "unknown YAML section", yaml: "databse:\n  host: db\n", want: `unknown key "databse.host"`},
+		{name: "YAML section that is not a mapping", yaml: "server: 8080\n", want: `unknown key "server"`},
+		{name: "YAML that does not parse", yaml: "server: [\n", want: "parse config file"},
+		{name: "malformed YAML integer", yaml: "server:\n  read_timeout: ten\n", want: `SERVER_READ_TIMEOUT: invalid integer "ten"`},
+		{name: "malformed environment boolean", env: map[string]string{"LOG_COMPRESS": "maybe"}, want: `LOG_COMPRESS: invalid boolean "maybe"`},
+		{name: "malformed environment number", env: map[string]string{"MAX_ACCEPTED_AMOUNT": "lots"}, want: `MAX_ACCEPTED_AMOUNT: invalid number "lots"`},
+		{name: "unknown override", overrides: []string{"SERVER_PROT=8080"}, want: `unknown key "SERVER_PROT"`},
+		{name: "override without a value", overrides: []string{"PORT"}, want: "expected KEY=VALUE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var file string
+			if tt.yaml != "" {
+				file = writeFile(t, "config.yaml", tt.yaml)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			_, err := Load(Options{File: file, Overrides: tt.overrides})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoadReportsEveryProblemTogether(t *testing.T) {
+	file := writeFile(t, "config.yaml", "server:\n  prot: 8080\n")
+	t.Setenv("SERVER_READ_TIMEOUT", "ten")
+
+	_, err := Load(Options{File: file, Overrides: []string{"PORT=0", "LOG_LEVEL=verbose"}})
+	require.Error(t, err)
+	for _, want := range []string{
+		`unknown key "server.prot"`,
+		`SERVER_READ_TIMEOUT: invalid integer "ten"`,
+		`PORT: must be a port number, got "0"`,
+		`LOG_LEVEL: must be one of`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestValidateCollectsEveryError(t *testing.T) {
+	cfg := Default()
+	assert.NoError(t, cfg.Validate(), "the defaults are valid")
+
+	cfg.Server.Environment = EnvironmentProduction
+	cfg.Server.ReadTimeout = 0
+	cfg.Database.Host = ""
+	cfg.Risk.NearLimitRatio = 1.5
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	lines := strings.Split(err.Error(), "\n")
+	assert.ElementsMatch(t, []string{
+		"SERVER_READ_TIMEOUT: must be positive, got 0",
+		"DB_HOST: is required",
+		"RISK_NEAR_LIMIT_RATIO: must be greater than 0 and at most 1, got 1.5",
+		"PAYMENT_INSTRUMENT_KEY: must not be the development key in production",
+		"ADMIN_API_KEY: must not be the development key in production",
+	}, lines)
+}
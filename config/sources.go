@@ -0,0 +1,345 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setting binds one configuration value to its environment variable and YAML path
+type setting struct {
+	env    string
+	yaml   string
+	target interface{} // *string, *int, *int64, *float64, *bool or *[]string
+	secret bool        // masked in Redacted
+	json   bool        // a *string that YAML may give as a list or mapping, stored as JSON
+}
+
+// settings lists every configurable value. Any variable can also be read from a file
+// named by <env>_FILE, which is how secrets are usually mounted.
+func (c *Config) settings() []setting {
+	return []setting{
+		{env: "APP_ENV", yaml: "server.environment", target: &c.Server.Environment},
+		{env: "SERVER_HOST", yaml: "server.host", target: &c.Server.Host},
+		{env: "PORT", yaml: "server.port", target: &c.Server.Port},
+		{env: "SERVER_READ_TIMEOUT", yaml: "server.read_timeout", target: &c.Server.ReadTimeout},
+		{env: "SERVER_WRITE_TIMEOUT", yaml: "server.write_timeout", target: &c.Server.WriteTimeout},
+		{env: "SERVER_SHUTDOWN_TIMEOUT", yaml: "server.shutdown_timeout", target: &c.Server.ShutdownTimeout},
+		{env: "SERVER_DRAIN_DELAY", yaml: "server.drain_delay", target: &c.Server.DrainDelay},
+
+		{env: "DB_HOST", yaml: "database.host", target: &c.Database.Host},
+		{env: "DB_PORT", yaml: "database.port", target: &c.Database.Port},
+		{env: "DB_USER", yaml: "database.user", target: &c.Database.User},
+		{env: "DB_PASSWORD", yaml: "database.password", target: &c.Database.Password, secret: true},
+		{env: "DB_NAME", yaml: "database.name", target: &c.Database.DBName},
+		{env: "DB_SSLMODE", yaml: "database.sslmode", target: &c.Database.SSLMode},
+
+		{env: "REDIS_HOST", yaml: "redis.host", target: &c.Cache.Host},
+		{env: "REDIS_PORT", yaml: "redis.port", target: &c.Cache.Port},
+		{env: "REDIS_PASSWORD", yaml: "redis.password", target: &c.Cache.Password, secret: true},
+		{env: "REDIS_DB", yaml: "redis.db", target: &c.Cache.Db},
+		{env: "REDIS_TIMEOUT", yaml: "redis.timeout", target: &c.Cache.Timeout},
+		{env: "REDIS_BREAKER_THRESHOLD", yaml: "redis.breaker_threshold", target: &c.Cache.BreakerThreshold},
+		{env: "REDIS_BREAKER_COOLDOWN", yaml: "redis.breaker_cooldown", target: &c.Cache.BreakerCooldown},
+
+		{env: "STORAGE_DIR", yaml: "storage.dir", target: &c.Storage.BaseDir},
+
+		{env: "MAX_ACCEPTED_AMOUNT", yaml: "app.max_accepted_amount", target: &c.App.MaxAcceptedAmount},
+		{env: "TOPUP_VERIFICATION_TTL", yaml: "app.verification_ttl", target: &c.App.VerificationTTL},
+		{env: "PAYMENT_METHODS", yaml: "app.payment_methods", target: &c.App.PaymentMethods},
+		{env: "SETTINGS_CACHE_TTL", yaml: "settings.cache_ttl", target: &c.Settings.CacheTTL},
+		{env: "PRICING_FEES", yaml: "pricing.fees", target: &c.Pricing.Fees, json: true},
+		{env: "PRICING_BONUSES", yaml: "pricing.bonuses", target: &c.Pricing.Bonuses, json: true},
+
+		{env: "KYC_TIER0_MAX_AMOUNT", yaml: "kyc.tier0_max_amount", target: &c.KYC.Tier0MaxAmount},
+		{env: "KYC_TIER1_MAX_AMOUNT", yaml: "kyc.tier1_max_amount", target: &c.KYC.Tier1MaxAmount},
+		{env: "KYC_TIER2_MAX_AMOUNT", yaml: "kyc.tier2_max_amount", target: &c.KYC.Tier2MaxAmount},
+		{env: "KYC_TIER0_PAYMENT_METHODS", yaml: "kyc.tier0_payment_methods", target: &c.KYC.Tier0PaymentMethods},
+		{env: "KYC_TIER1_PAYMENT_METHODS", yaml: "kyc.tier1_payment_methods", target: &c.KYC.Tier1PaymentMethods},
+		{env: "KYC_TIER2_PAYMENT_METHODS", yaml: "kyc.tier2_payment_methods", target: &c.KYC.Tier2PaymentMethods},
+
+		{env: "RISK_CHALLENGE_SCORE", yaml: "risk.challenge_score", target: &c.Risk.ChallengeScore},
+		{env: "RISK_REJECT_SCORE", yaml: "risk.reject_score", target: &c.Risk.RejectScore},
+		{env: "RISK_VELOCITY_WINDOW", yaml: "risk.velocity_window", target: &c.Risk.VelocityWindow},
+		{env: "RISK_VELOCITY_MAX_COUNT", yaml: "risk.velocity_max_count", target: &c.Risk.VelocityMaxCount},
+		{env: "RISK_VELOCITY_SCORE", yaml: "risk.velocity_score", target: &c.Risk.VelocityScore},
+		{env: "RISK_NEW_ACCOUNT_AGE", yaml: "risk.new_account_age", target: &c.Risk.NewAccountAge},
+		{env: "RISK_NEW_ACCOUNT_LARGE_AMOUNT", yaml: "risk.new_account_large_amount", target: &c.Risk.NewAccountLargeAmount},
+		{env: "RISK_NEW_ACCOUNT_SCORE", yaml: "risk.new_account_score", target: &c.Risk.NewAccountScore},
+		{env: "RISK_FAILED_WINDOW", yaml: "risk.failed_window", target: &c.Risk.FailedWindow},
+		{env: "RISK_FAILED_MAX_COUNT", yaml: "risk.failed_max_count", target: &c.Risk.FailedMaxCount},
+		{env: "RISK_FAILED_SCORE", yaml: "risk.failed_score", target: &c.Risk.FailedScore},
+		{env: "RISK_NEAR_LIMIT_RATIO", yaml: "risk.near_limit_ratio", target: &c.Risk.NearLimitRatio},
+		{env: "RISK_NEAR_LIMIT_SCORE", yaml: "risk.near_limit_score", target: &c.Risk.NearLimitScore},
+
+		{env: "OUTBOX_POLL_INTERVAL", yaml: "outbox.poll_interval", target: &c.Outbox.PollInterval},
+		{env: "OUTBOX_BATCH_SIZE", yaml: "outbox.batch_size", target: &c.Outbox.BatchSize},
+		{env: "OUTBOX_MAX_ATTEMPTS", yaml: "outbox.max_attempts", target: &c.Outbox.MaxAttempts},
+		{env: "OUTBOX_BASE_BACKOFF", yaml: "outbox.base_backoff", target: &c.Outbox.BaseBackoff},
+		{env: "OUTBOX_MAX_BACKOFF", yaml: "outbox.max_backoff", target: &c.Outbox.MaxBackoff},
+
+		{env: "EXPIRY_SWEEP_INTERVAL", yaml: "expiry.sweep_interval", target: &c.Expiry.Interval},
+		{env: "EXPIRY_BATCH_SIZE", yaml: "expiry.batch_size", target: &c.Expiry.BatchSize},
+
+		{env: "PAYMENT_PROVIDER", yaml: "payment.provider", target: &c.Payment.Provider},
+		{env: "PAYMENT_INSTRUMENT_KEY", yaml: "payment.instrument_key", target: &c.Payment.InstrumentKey, secret: true},
+
+		{env: "ADMIN_API_KEY", yaml: "admin.api_key", target: &c.Admin.APIKey, secret: true},
+
+		{env: "AUTOTOPUP_INTERVAL", yaml: "autotopup.interval", target: &c.AutoTopup.Interval},
+		{env: "AUTOTOPUP_BATCH_SIZE", yaml: "autotopup.batch_size", target: &c.AutoTopup.BatchSize},
+		{env: "AUTOTOPUP_MAX_ATTEMPTS", yaml: "autotopup.max_attempts", target: &c.AutoTopup.MaxAttempts},
+		{env: "AUTOTOPUP_BASE_BACKOFF", yaml: "autotopup.base_backoff", target: &c.AutoTopup.BaseBackoff},
+		{env: "AUTOTOPUP_MAX_BACKOFF", yaml: "autotopup.max_backoff", target: &c.AutoTopup.MaxBackoff},
+		{env: "AUTOTOPUP_LEASE", yaml: "autotopup.lease", target: &c.AutoTopup.Lease},
+		{env: "AUTOTOPUP_THRESHOLD_COOLDOWN", yaml: "autotopup.threshold_cooldown", target: &c.AutoTopup.ThresholdCooldown},
+
+		{env: "CACHE_BACKEND", yaml: "cache.backend", target: &c.CacheBackend.Backend},
+		{env: "CACHE_MAX_ENTRIES", yaml: "cache.max_entries", target: &c.CacheBackend.MaxEntries},
+		{env: "CACHE_JANITOR_INTERVAL", yaml: "cache.janitor_interval", target: &c.CacheBackend.JanitorInterval},
+		{env: "CACHE_LOCAL_TTL", yaml: "cache.local_ttl", target: &c.CacheBackend.LocalTTL},
+		{env: "CACHE_INVALIDATION_CHANNEL", yaml: "cache.invalidation_channel", target: &c.CacheBackend.InvalidationChannel},
+
+		{env: "LOCK_PREFIX", yaml: "lock.prefix", target: &c.Lock.Prefix},
+		{env: "LOCK_TTL", yaml: "lock.ttl", target: &c.Lock.TTL},
+		{env: "LOCK_WAIT", yaml: "lock.wait", target: &c.Lock.Wait},
+		{env: "LOCK_RETRY_BASE", yaml: "lock.retry_base", target: &c.Lock.RetryBase},
+		{env: "LOCK_RETRY_MAX", yaml: "lock.retry_max", target: &c.Lock.RetryMax},
+
+		{env: "TRACE_EXPORTER", yaml: "tracing.exporter", target: &c.Tracing.Exporter},
+		{env: "TRACE_OTLP_ENDPOINT", yaml: "tracing.otlp_endpoint", target: &c.Tracing.OTLPEndpoint},
+		{env: "TRACE_OTLP_INSECURE", yaml: "tracing.otlp_insecure", target: &c.Tracing.OTLPInsecure},
+		{env: "TRACE_SERVICE_NAME", yaml: "tracing.service_name", target: &c.Tracing.ServiceName},
+		{env: "TRACE_SAMPLE_RATIO", yaml: "tracing.sample_ratio", target: &c.Tracing.SampleRatio},
+
+		{env: "HEALTH_CHECK_TIMEOUT", yaml: "health.check_timeout", target: &c.Health.CheckTimeout},
+		{env: "HEALTH_MAX_POOL_SATURATION", yaml: "health.max_pool_saturation", target: &c.Health.MaxPoolSaturation},
+
+		{env: "EVENT_PUBLISHER", yaml: "events.publisher", target: &c.Events.Publisher},
+		{env: "EVENT_STREAM_KEY", yaml: "events.stream_key", target: &c.Events.Stream.Key},
+		{env: "EVENT_STREAM_MAXLEN", yaml: "events.stream_maxlen", target: &c.Events.Stream.MaxLen},
+		{env: "EVENT_STREAM_APPROX_TRIM", yaml: "events.stream_approx_trim", target: &c.Events.Stream.ApproxTrim},
+
+		{env: "WEBHOOK_POLL_INTERVAL", yaml: "webhook.poll_interval", target: &c.Webhook.PollInterval},
+		{env: "WEBHOOK_BATCH_SIZE", yaml: "webhook.batch_size", target: &c.Webhook.BatchSize},
+		{env: "WEBHOOK_MAX_ATTEMPTS", yaml: "webhook.max_attempts", target: &c.Webhook.MaxAttempts},
+		{env: "WEBHOOK_BASE_BACKOFF", yaml: "webhook.base_backoff", target: &c.Webhook.BaseBackoff},
+		{env: "WEBHOOK_MAX_BACKOFF", yaml: "webhook.max_backoff", target: &c.Webhook.MaxBackoff},
+		{env: "WEBHOOK_TIMEOUT", yaml: "webhook.timeout", target: &c.Webhook.Timeout},
+		{env: "WEBHOOK_DISABLE_AFTER", yaml: "webhook.disable_after", target: &c.Webhook.DisableAfter},
+
+		{env: "LOG_LEVEL", yaml: "log.level", target: &c.Log.Level},
+		{env: "LOG_DIR", yaml: "log.dir", target: &c.Log.Dir},
+		{env: "LOG_FILE", yaml: "log.file", target: &c.Log.FileName},
+		{env: "LOG_MAX_SIZE", yaml: "log.max_size", target: &c.Log.MaxSizeMB},
+		{env: "LOG_MAX_BACKUPS", yaml: "log.max_backups", target: &c.Log.MaxBackups},
+		{env: "LOG_MAX_AGE", yaml: "log.max_age", target: &c.Log.MaxAgeDays},
+		{env: "LOG_COMPRESS", yaml: "log.compress", target: &c.Log.Compress},
+		{env: "LOG_ROTATE_DAILY", yaml: "log.rotate_daily", target: &c.Log.RotateDaily},
+		{env: "LOG_REDACT_FIELDS", yaml: "log.redact_fields", target: &c.Log.RedactFields},
+	}
+}
+
+// set parses raw strictly into the setting's target
+func (s setting) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch target := s.target.(type) {
+	case *string:
+		*target = raw
+	case *int:
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", s.env, raw)
+		}
+		*target = v
+	case *int64:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", s.env, raw)
+		}
+		*target = v
+	case *float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %q", s.env, raw)
+		}
+		*target = v
+	case *bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: invalid boolean %q", s.env, raw)
+		}
+		*target = v
+	case *[]string:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*target = items
+	default:
+		return fmt.Errorf("%s: unsupported setting type %T", s.env, s.target)
+	}
+	return nil
+}
+
+// setJSON encodes a structured YAML value as JSON and stores it
+func (s setting) setJSON(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return fmt.Errorf("%s: %w", s.env, err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.env, err)
+	}
+	return s.set(string(data))
+}
+
+// loadYAML applies a YAML file laid out as sections of keys, e.g. database.host.
+// Unknown keys are errors so typos do not go unnoticed.
+func loadYAML(path string, settings []setting) []error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []error{fmt.Errorf("read config file: %w", err)}
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []error{fmt.Errorf("parse config file %s: %w", path, err)}
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	byPath := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		byPath[s.yaml] = s
+	}
+	var errs []error
+	var walk func(prefix string, node *yaml.Node)
+	walk = func(prefix string, node *yaml.Node) {
+		if node.Kind != yaml.MappingNode {
+			errs = append(errs, fmt.Errorf("%s:%d: expected a mapping", path, node.Line))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			s, ok := byPath[key]
+			switch {
+			case !ok && value.Kind == yaml.MappingNode:
+				walk(key, value)
+			case !ok:
+				errs = append(errs, fmt.Errorf("%s:%d: unknown key %q", path, node.Content[i].Line, key))
+			case s.json && value.Kind != yaml.ScalarNode:
+				if err := s.setJSON(value); err != nil {
+					errs = append(errs, fmt.Errorf("%s:%d: %w", path, value.Line, err))
+				}
+			case value.Kind == yaml.SequenceNode:
+				items := make([]string, 0, len(value.Content))
+				for _, item := range value.Content {
+					items = append(items, item.Value)
+				}
+				if err := s.set(strings.Join(items, ",")); err != nil {
+					errs = append(errs, fmt.Errorf("%s:%d: %w", path, value.Line, err))
+				}
+			case value.Kind == yaml.ScalarNode:
+				if err := s.set(value.Value); err != nil {
+					errs = append(errs, fmt.Errorf("%s:%d: %w", path, value.Line, err))
+				}
+			default:
+				errs = append(errs, fmt.Errorf("%s:%d: %s must be a value or a list", path, value.Line, key))
+			}
+		}
+	}
+	walk("", root.Content[0])
+	return errs
+}
+
+// loadEnv applies environment variables, reading <KEY>_FILE when it is set instead of KEY
+func loadEnv(settings []setting) []error {
+	var errs []error
+	for _, s := range settings {
+		value, hasValue := os.LookupEnv(s.env)
+		file, hasFile := os.LookupEnv(s.env + "_FILE")
+		switch {
+		case hasValue && hasFile:
+			errs = append(errs, fmt.Errorf("%s: set either %s or %s_FILE, not both", s.env, s.env, s.env))
+			continue
+		case hasFile:
+			data, err := os.ReadFile(file)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s_FILE: %w", s.env, err))
+				continue
+			}
+			// Secret files usually end with a newline
+			value, hasValue = strings.TrimRight(string(data), "\r\n"), true
+		}
+		if !hasValue {
+			continue
+		}
+		if err := s.set(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// loadOverrides applies KEY=VALUE pairs using environment variable names
+func loadOverrides(overrides []string, settings []setting) []error {
+	byEnv := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		byEnv[s.env] = s
+	}
+	var errs []error
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			errs = append(errs, fmt.Errorf("override %q: expected KEY=VALUE", override))
+			continue
+		}
+		s, ok := byEnv[strings.TrimSpace(key)]
+		if !ok {
+			errs = append(errs, fmt.Errorf("override %q: unknown key %q", override, key))
+			continue
+		}
+		if err := s.set(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Redacted returns every setting keyed by its environment variable name, with secrets masked
+func (c *Config) Redacted() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, s := range c.settings() {
+		var value interface{}
+		switch target := s.target.(type) {
+		case *string:
+			value = *target
+		case *int:
+			value = *target
+		case *int64:
+			value = *target
+		case *float64:
+			value = *target
+		case *bool:
+			value = *target
+		case *[]string:
+			value = strings.Join(*target, ",")
+		}
+		if s.secret && value != "" {
+			value = "******"
+		}
+		values[s.env] = value
+	}
+	return values
+}
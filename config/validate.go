@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/pricing"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// minAdminAPIKeyLength keeps the admin API key out of reach of guessing
+const minAdminAPIKeyLength = 32
+
+// errorList collects validation failures so they can be reported together
+type errorList []error
+
+func (l *errorList) check(ok bool, key, format string, args ...interface{}) {
+	if !ok {
+		*l = append(*l, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (l *errorList) oneOf(value, key string, allowed ...string) {
+	l.check(slices.Contains(allowed, value), key, "must be one of %v, got %q", allowed, value)
+}
+
+func (l *errorList) positive(value int, key string) {
+	l.check(value > 0, key, "must be positive, got %d", value)
+}
+
+func (l *errorList) port(value, key string) {
+	port, err := strconv.Atoi(value)
+	l.check(err == nil && port > 0 && port <= 65535, key, "must be a port number, got %q", value)
+}
+
+func (l *errorList) ratio(value float64, key string) {
+	l.check(value > 0 && value <= 1, key, "must be greater than 0 and at most 1, got %g", value)
+}
+
+// Validate checks every setting and returns all problems at once, keyed by environment variable name
+func (c *Config) Validate() error {
+	var l errorList
+
+	l.oneOf(c.Server.Environment, "APP_ENV", EnvironmentDevelopment, EnvironmentStaging, EnvironmentProduction)
+	l.port(c.Server.Port, "PORT")
+	l.positive(c.Server.ReadTimeout, "SERVER_READ_TIMEOUT")
+	l.positive(c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	l.positive(c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT")
+	l.check(c.Server.DrainDelay >= 0 && c.Server.DrainDelay < c.Server.ShutdownTimeout, "SERVER_DRAIN_DELAY",
+		"must be at least 0 and less than SERVER_SHUTDOWN_TIMEOUT, got %d", c.Server.DrainDelay)
+
+	l.check(c.Database.Host != "", "DB_HOST", "is required")
+	l.port(c.Database.Port, "DB_PORT")
+	l.check(c.Database.User != "", "DB_USER", "is required")
+	l.check(c.Database.DBName != "", "DB_NAME", "is required")
+	l.oneOf(c.Database.SSLMode, "DB_SSLMODE", "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+
+	l.oneOf(c.CacheBackend.Backend, "CACHE_BACKEND", "redis", "memory", "tiered")
+	if c.CacheBackend.Backend != "memory" || c.Events.Publisher == "redis" {
+		l.check(c.Cache.Host != "", "REDIS_HOST", "is required")
+		l.port(strconv.Itoa(c.Cache.Port), "REDIS_PORT")
+		l.check(c.Cache.Db >= 0, "REDIS_DB", "must not be negative, got %d", c.Cache.Db)
+		l.positive(c.Cache.Timeout, "REDIS_TIMEOUT")
+		l.positive(c.Cache.BreakerThreshold, "REDIS_BREAKER_THRESHOLD")
+		l.positive(c.Cache.BreakerCooldown, "REDIS_BREAKER_COOLDOWN")
+	}
+	l.positive(c.CacheBackend.MaxEntries, "CACHE_MAX_ENTRIES")
+	l.positive(c.CacheBackend.JanitorInterval, "CACHE_JANITOR_INTERVAL")
+	l.positive(c.CacheBackend.LocalTTL, "CACHE_LOCAL_TTL")
+	if c.CacheBackend.Backend == "tiered" {
+		l.check(c.CacheBackend.InvalidationChannel != "", "CACHE_INVALIDATION_CHANNEL", "is required for the tiered cache")
+	}
+
+	l.check(c.Storage.BaseDir != "", "STORAGE_DIR", "is required")
+	l.check(c.App.MaxAcceptedAmount > 0, "MAX_ACCEPTED_AMOUNT", "must be positive, got %g", c.App.MaxAcceptedAmount)
+	l.positive(c.App.VerificationTTL, "TOPUP_VERIFICATION_TTL")
+	for _, method := range c.App.PaymentMethods {
+		_, err := vo.NewPaymentMethod(method)
+		l.check(err == nil, "PAYMENT_METHODS", "unknown payment method %q", method)
+	}
+	l.positive(c.Settings.CacheTTL, "SETTINGS_CACHE_TTL")
+	_, err := pricing.ParseFees(c.Pricing.Fees)
+	l.check(err == nil, "PRICING_FEES", "%v", err)
+	_, err = pricing.ParseBonuses(c.Pricing.Bonuses)
+	l.check(err == nil, "PRICING_BONUSES", "%v", err)
+
+	tiers := []struct {
+		key     string
+		amount  float64
+		methods []string
+	}{
+		{"KYC_TIER0", c.KYC.Tier0MaxAmount, c.KYC.Tier0PaymentMethods},
+		{"KYC_TIER1", c.KYC.Tier1MaxAmount, c.KYC.Tier1PaymentMethods},
+		{"KYC_TIER2", c.KYC.Tier2MaxAmount, c.KYC.Tier2PaymentMethods},
+	}
+	for _, tier := range tiers {
+		l.check(tier.amount > 0, tier.key+"_MAX_AMOUNT", "must be positive, got %g", tier.amount)
+		for _, method := range tier.methods {
+			_, err := vo.NewPaymentMethod(method)
+			l.check(err == nil, tier.key+"_PAYMENT_METHODS", "unknown payment method %q", method)
+		}
+	}
+
+	l.check(c.Risk.ChallengeScore > 0 && c.Risk.ChallengeScore < c.Risk.RejectScore, "RISK_CHALLENGE_SCORE",
+		"must be positive and below RISK_REJECT_SCORE, got %d", c.Risk.ChallengeScore)
+	l.positive(c.Risk.VelocityWindow, "RISK_VELOCITY_WINDOW")
+	l.positive(c.Risk.VelocityMaxCount, "RISK_VELOCITY_MAX_COUNT")
+	l.positive(c.Risk.NewAccountAge, "RISK_NEW_ACCOUNT_AGE")
+	l.positive(c.Risk.FailedWindow, "RISK_FAILED_WINDOW")
+	l.positive(c.Risk.FailedMaxCount, "RISK_FAILED_MAX_COUNT")
+	l.ratio(c.Risk.NearLimitRatio, "RISK_NEAR_LIMIT_RATIO")
+
+	l.positive(c.Outbox.PollInterval, "OUTBOX_POLL_INTERVAL")
+	l.positive(c.Outbox.BatchSize, "OUTBOX_BATCH_SIZE")
+	l.positive(c.Outbox.MaxAttempts, "OUTBOX_MAX_ATTEMPTS")
+	l.positive(c.Outbox.BaseBackoff, "OUTBOX_BASE_BACKOFF")
+	l.check(c.Outbox.MaxBackoff >= c.Outbox.BaseBackoff, "OUTBOX_MAX_BACKOFF", "must not be below OUTBOX_BASE_BACKOFF")
+
+	l.positive(c.Expiry.Interval, "EXPIRY_SWEEP_INTERVAL")
+	l.positive(c.Expiry.BatchSize, "EXPIRY_BATCH_SIZE")
+
+	l.oneOf(c.Payment.Provider, "PAYMENT_PROVIDER", "sandbox")
+	key, err := base64.StdEncoding.DecodeString(c.Payment.InstrumentKey)
+	l.check(err == nil && len(key) == 32, "PAYMENT_INSTRUMENT_KEY", "must be 32 bytes encoded as base64")
+	l.check(!c.IsProduction() || c.Payment.InstrumentKey != DevelopmentInstrumentKey, "PAYMENT_INSTRUMENT_KEY",
+		"must not be the development key in production")
+
+	l.check(len(c.Admin.APIKey) >= minAdminAPIKeyLength, "ADMIN_API_KEY", "must be at least %d characters", minAdminAPIKeyLength)
+	l.check(!c.IsProduction() || c.Admin.APIKey != DevelopmentAdminAPIKey, "ADMIN_API_KEY",
+		"must not be the development key in production")
+
+	l.positive(c.AutoTopup.Interval, "AUTOTOPUP_INTERVAL")
+	l.positive(c.AutoTopup.BatchSize, "AUTOTOPUP_BATCH_SIZE")
+	l.positive(c.AutoTopup.MaxAttempts, "AUTOTOPUP_MAX_ATTEMPTS")
+	l.positive(c.AutoTopup.BaseBackoff, "AUTOTOPUP_BASE_BACKOFF")
+	l.check(c.AutoTopup.MaxBackoff >= c.AutoTopup.BaseBackoff, "AUTOTOPUP_MAX_BACKOFF", "must not be below AUTOTOPUP_BASE_BACKOFF")
+	l.positive(c.AutoTopup.Lease, "AUTOTOPUP_LEASE")
+	l.check(c.AutoTopup.ThresholdCooldown >= 0, "AUTOTOPUP_THRESHOLD_COOLDOWN", "must not be negative, got %d", c.AutoTopup.ThresholdCooldown)
+
+	l.positive(c.Lock.TTL, "LOCK_TTL")
+	l.check(c.Lock.Wait >= 0, "LOCK_WAIT", "must not be negative, got %d", c.Lock.Wait)
+	l.positive(c.Lock.RetryBase, "LOCK_RETRY_BASE")
+	l.check(c.Lock.RetryMax >= c.Lock.RetryBase, "LOCK_RETRY_MAX", "must not be below LOCK_RETRY_BASE")
+
+	l.oneOf(c.Tracing.Exporter, "TRACE_EXPORTER", "none", "stdout", "otlp")
+	if c.Tracing.Exporter == "otlp" {
+		l.check(c.Tracing.OTLPEndpoint != "", "TRACE_OTLP_ENDPOINT", "is required for the otlp exporter")
+	}
+	l.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "TRACE_SAMPLE_RATIO",
+		"must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	l.positive(c.Health.CheckTimeout, "HEALTH_CHECK_TIMEOUT")
+	l.ratio(c.Health.MaxPoolSaturation, "HEALTH_MAX_POOL_SATURATION")
+
+	l.oneOf(c.Events.Publisher, "EVENT_PUBLISHER", "log", "redis")
+	if c.Events.Publisher == "redis" {
+		l.check(c.Events.Stream.Key != "", "EVENT_STREAM_KEY", "is required for the redis publisher")
+		l.check(c.Events.Stream.MaxLen >= 0, "EVENT_STREAM_MAXLEN", "must not be negative")
+	}
+
+	l.positive(c.Webhook.PollInterval, "WEBHOOK_POLL_INTERVAL")
+	l.positive(c.Webhook.BatchSize, "WEBHOOK_BATCH_SIZE")
+	l.positive(c.Webhook.MaxAttempts, "WEBHOOK_MAX_ATTEMPTS")
+	l.positive(c.Webhook.BaseBackoff, "WEBHOOK_BASE_BACKOFF")
+	l.check(c.Webhook.MaxBackoff >= c.Webhook.BaseBackoff, "WEBHOOK_MAX_BACKOFF", "must not be below WEBHOOK_BASE_BACKOFF")
+	l.positive(c.Webhook.Timeout, "WEBHOOK_TIMEOUT")
+	l.check(c.Webhook.Lease() <= MaxWebhookLease, "WEBHOOK_BATCH_SIZE",
+		"a batch is leased for WEBHOOK_BATCH_SIZE+1 times WEBHOOK_TIMEOUT, which must be at most %s, got %s", MaxWebhookLease, c.Webhook.Lease())
+	l.positive(c.Webhook.DisableAfter, "WEBHOOK_DISABLE_AFTER")
+
+	l.oneOf(c.Log.Level, "LOG_LEVEL", "debug", "info", "warn", "error")
+	if c.Log.Dir != "" {
+		l.check(c.Log.FileName != "", "LOG_FILE", "is required when LOG_DIR is set")
+		l.positive(c.Log.MaxSizeMB, "LOG_MAX_SIZE")
+	}
+	l.check(c.Log.MaxBackups >= 0, "LOG_MAX_BACKUPS", "must not be negative")
+	l.check(c.Log.MaxAgeDays >= 0, "LOG_MAX_AGE", "must not be negative")
+
+	return errors.Join(l...)
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+)
+
+// AutoTopupController handles HTTP requests for managing a user's auto top-up rules
+type AutoTopupController struct {
+	autoTopupUseCase usecase.AutoTopupUsecase
+}
+
+// NewAutoTopupController creates a new instance of AutoTopupController
+func NewAutoTopupController(autoTopupUseCase usecase.AutoTopupUsecase) *AutoTopupController {
+	return &AutoTopupController{
+		autoTopupUseCase: autoTopupUseCase,
+	}
+}
+
+// CreateRule saves a threshold or schedule rule for the user
+func (c *AutoTopupController) CreateRule(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+	var req dto.CreateAutoTopupRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	rule, err := c.autoTopupUseCase.Create(ctx.UserContext(), autotopup.RuleSpec{
+		UserID:       userID,
+		Trigger:      req.Trigger,
+		Threshold:    req.Threshold,
+		Schedule:     req.Schedule,
+		Amount:       req.Amount,
+		InstrumentID: req.InstrumentID,
+	})
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Auto top-up rule created successfully", toAutoTopupResponse(rule))
+}
+
+// ListRules lists the user's rules
+func (c *AutoTopupController) ListRules(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+
+	rules, err := c.autoTopupUseCase.List(ctx.UserContext(), userID)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.AutoTopupResponse, len(rules))
+	for i, rule := range rules {
+		response[i] = toAutoTopupResponse(rule)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Auto top-up rules retrieved successfully", response)
+}
+
+// DeleteRule removes one of the user's rules
+func (c *AutoTopupController) DeleteRule(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+	ruleID, ok := parseIDParam(ctx, "ruleId")
+	if !ok {
+		return invalidIDResp(ctx, "rule")
+	}
+
+	if err := c.autoTopupUseCase.Delete(ctx.UserContext(), userID, ruleID); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Auto top-up rule deleted successfully", nil)
+}
+
+// RegisterRoutes registers the routes for the auto top-up controller
+func (c *AutoTopupController) RegisterRoutes(router fiber.Router) {
+	group := router.Group("/users/:userId/auto-topups")
+	group.Post("/", c.CreateRule)
+	group.Get("/", c.ListRules)
+	group.Delete("/:ruleId", c.DeleteRule)
+}
+
+func toAutoTopupResponse(rule autotopup.Rule) dto.AutoTopupResponse {
+	return dto.AutoTopupResponse{
+		ID:           rule.ID,
+		UserID:       rule.UserID,
+		Trigger:      rule.Trigger.String(),
+		Threshold:    rule.Threshold,
+		Schedule:     rule.Schedule,
+		Amount:       rule.Amount.Amount(),
+		InstrumentID: rule.InstrumentID,
+		Enabled:      rule.Enabled,
+		NextRunAt:    rule.NextRunAt,
+		Attempts:     rule.Attempts,
+		LastRunAt:    rule.LastRunAt,
+		LastError:    rule.LastError,
+		CreatedAt:    rule.CreatedAt,
+	}
+}
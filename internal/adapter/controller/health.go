@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+)
+
+// HealthController handles probe requests
+type HealthController struct {
+	healthUseCase usecase.HealthUsecase
+}
+
+// NewHealthController creates a new instance of HealthController
+func NewHealthController(healthUseCase usecase.HealthUsecase) *HealthController {
+	return &HealthController{
+		healthUseCase: healthUseCase,
+	}
+}
+
+// Liveness reports that the process is up and serving requests; it checks no dependencies
+func (c *HealthController) Liveness(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(dto.LivenessResponse{Status: "alive"})
+}
+
+// Readiness reports whether the service can take traffic, with a result per dependency
+func (c *HealthController) Readiness(ctx *fiber.Ctx) error {
+	report := c.healthUseCase.Readiness(ctx.UserContext())
+	status := fiber.StatusOK
+	if !report.Ready() {
+		status = fiber.StatusServiceUnavailable
+	}
+	return ctx.Status(status).JSON(dto.NewHealthReportResponse(report))
+}
+
+// RegisterRoutes registers the probe routes outside the versioned API
+func (c *HealthController) RegisterRoutes(router fiber.Router) {
+	router.Get("/healthz", c.Liveness)
+	router.Get("/readyz", c.Readiness)
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+)
+
+// InstrumentController handles HTTP requests for a user's saved payment instruments
+type InstrumentController struct {
+	instrumentUseCase usecase.InstrumentUsecase
+}
+
+// NewInstrumentController creates a new instance of InstrumentController
+func NewInstrumentController(instrumentUseCase usecase.InstrumentUsecase) *InstrumentController {
+	return &InstrumentController{
+		instrumentUseCase: instrumentUseCase,
+	}
+}
+
+// SaveInstrument saves a tokenized card or bank account for the user
+func (c *InstrumentController) SaveInstrument(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+	var req dto.SaveInstrumentRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	saved, err := c.instrumentUseCase.Save(ctx.UserContext(), instrument.Spec{
+		UserID:        userID,
+		Type:          req.Type,
+		Token:         req.Token,
+		Brand:         req.Brand,
+		Last4:         req.Last4,
+		ExpMonth:      req.ExpMonth,
+		ExpYear:       req.ExpYear,
+		BankName:      req.BankName,
+		AccountHolder: req.AccountHolder,
+	})
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Payment instrument saved successfully", toInstrumentResponse(saved, time.Now()))
+}
+
+// ListInstruments lists the user's saved instruments
+func (c *InstrumentController) ListInstruments(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+
+	instruments, err := c.instrumentUseCase.List(ctx.UserContext(), userID)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	now := time.Now()
+	response := make([]dto.InstrumentResponse, len(instruments))
+	for i, saved := range instruments {
+		response[i] = toInstrumentResponse(saved, now)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Payment instruments retrieved successfully", response)
+}
+
+// DeleteInstrument removes one of the user's saved instruments
+func (c *InstrumentController) DeleteInstrument(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+	instrumentID, ok := parseIDParam(ctx, "instrumentId")
+	if !ok {
+		return invalidIDResp(ctx, "instrument")
+	}
+
+	if err := c.instrumentUseCase.Delete(ctx.UserContext(), userID, instrumentID); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Payment instrument deleted successfully", nil)
+}
+
+// RegisterRoutes registers the routes for the instrument controller
+func (c *InstrumentController) RegisterRoutes(router fiber.Router) {
+	group := router.Group("/users/:userId/payment-instruments")
+	group.Post("/", c.SaveInstrument)
+	group.Get("/", c.ListInstruments)
+	group.Delete("/:instrumentId", c.DeleteInstrument)
+}
+
+func toInstrumentResponse(i instrument.Instrument, now time.Time) dto.InstrumentResponse {
+	response := dto.InstrumentResponse{
+		ID:            i.ID,
+		UserID:        i.UserID,
+		Type:          i.Type.String(),
+		PaymentMethod: i.PaymentMethod().String(),
+		Expired:       i.Expired(now),
+		CreatedAt:     i.CreatedAt,
+	}
+	if i.Card != nil {
+		response.Card = &dto.CardResponse{
+			Brand:    i.Card.Brand,
+			Last4:    i.Card.Last4,
+			ExpMonth: i.Card.ExpMonth,
+			ExpYear:  i.Card.ExpYear,
+		}
+	}
+	if i.BankAccount != nil {
+		response.BankAccount = &dto.BankAccountResponse{
+			BankName:      i.BankAccount.BankName,
+			AccountHolder: i.BankAccount.AccountHolder,
+			Last4:         i.BankAccount.Last4,
+		}
+	}
+	return response
+}
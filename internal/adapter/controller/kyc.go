@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+)
+
+// maxKYCDocumentSize caps uploaded document size at 10 MB
+const maxKYCDocumentSize = 10 << 20
+
+// KYCController handles HTTP requests related to KYC verification
+type KYCController struct {
+	kycUseCase usecase.KYCUsecase
+}
+
+// NewKYCController creates a new instance of KYCController
+func NewKYCController(kycUseCase usecase.KYCUsecase) *KYCController {
+	return &KYCController{
+		kycUseCase: kycUseCase,
+	}
+}
+
+// SubmitDocument handles a multipart identity document upload
+func (c *KYCController) SubmitDocument(ctx *fiber.Ctx) error {
+	userID, err := ctx.ParamsInt("userId")
+	if err != nil || userID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid user ID",
+		})
+	}
+	documentType := ctx.FormValue("document_type")
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil || documentType == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "document_type and file are required",
+		})
+	}
+	if fileHeader.Size > maxKYCDocumentSize {
+		return ctx.Status(fiber.StatusRequestEntityTooLarge).JSON(ErrorResponse{
+			Status:  fiber.StatusRequestEntityTooLarge,
+			Message: "Document is too large",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+	defer file.Close()
+
+	document, err := c.kycUseCase.SubmitDocument(ctx.UserContext(), uint(userID), documentType, fileHeader.Filename, file)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "KYC document submitted successfully", toKYCDocumentResponse(document))
+}
+
+// GetStatus returns a user's KYC status
+func (c *KYCController) GetStatus(ctx *fiber.Ctx) error {
+	userID, err := ctx.ParamsInt("userId")
+	if err != nil || userID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid user ID",
+		})
+	}
+
+	u, documents, err := c.kycUseCase.GetStatus(ctx.UserContext(), uint(userID))
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "KYC status retrieved successfully", toKYCStatusResponse(u, documents))
+}
+
+// ListPending lists documents waiting for admin review
+func (c *KYCController) ListPending(ctx *fiber.Ctx) error {
+	documents, err := c.kycUseCase.ListPending(ctx.UserContext())
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.KYCDocumentResponse, len(documents))
+	for i, d := range documents {
+		response[i] = toKYCDocumentResponse(d)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Pending KYC documents retrieved successfully", response)
+}
+
+// Approve handles admin approval of a KYC document
+func (c *KYCController) Approve(ctx *fiber.Ctx) error {
+	documentID, err := ctx.ParamsInt("documentId")
+	if err != nil || documentID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid document ID",
+		})
+	}
+	var req dto.KYCApproveRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+	if req.Tier == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Tier is required",
+		})
+	}
+
+	u, err := c.kycUseCase.Approve(ctx.UserContext(), uint(documentID), req.Tier, req.Note)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "KYC document approved successfully", toKYCStatusResponse(u, nil))
+}
+
+// Reject handles admin rejection of a KYC document
+func (c *KYCController) Reject(ctx *fiber.Ctx) error {
+	documentID, err := ctx.ParamsInt("documentId")
+	if err != nil || documentID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid document ID",
+		})
+	}
+	var req dto.KYCRejectRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	u, err := c.kycUseCase.Reject(ctx.UserContext(), uint(documentID), req.Note)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "KYC document rejected successfully", toKYCStatusResponse(u, nil))
+}
+
+// RegisterRoutes registers the routes for the KYC controller
+func (c *KYCController) RegisterRoutes(router fiber.Router) {
+	userGroup := router.Group("/users/:userId/kyc")
+	userGroup.Get("/", c.GetStatus)
+	userGroup.Post("/documents", c.SubmitDocument)
+
+	adminGroup := router.Group("/admin/kyc")
+	adminGroup.Get("/documents", c.ListPending)
+	adminGroup.Post("/documents/:documentId/approve", c.Approve)
+	adminGroup.Post("/documents/:documentId/reject", c.Reject)
+}
+
+func toKYCDocumentResponse(d kyc.Document) dto.KYCDocumentResponse {
+	return dto.KYCDocumentResponse{
+		DocumentID:   d.ID,
+		UserID:       d.UserID,
+		DocumentType: d.DocumentType.String(),
+		Status:       d.Status.String(),
+		ReviewNote:   d.ReviewNote,
+		ReviewedAt:   d.ReviewedAt,
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+func toKYCStatusResponse(u user.User, documents []kyc.Document) dto.KYCStatusResponse {
+	response := dto.KYCStatusResponse{
+		UserID:    u.ID,
+		KYCStatus: u.KYCStatus.String(),
+		KYCTier:   u.KYCTier.String(),
+		Documents: make([]dto.KYCDocumentResponse, len(documents)),
+	}
+	for i, d := range documents {
+		response.Documents[i] = toKYCDocumentResponse(d)
+	}
+	return response
+}
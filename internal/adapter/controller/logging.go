@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+)
+
+// LoggingController handles runtime log level changes
+type LoggingController struct {
+	levels logger.LevelController
+}
+
+// NewLoggingController creates a new instance of LoggingController
+func NewLoggingController(levels logger.LevelController) *LoggingController {
+	return &LoggingController{
+		levels: levels,
+	}
+}
+
+// GetLevel returns the current log level
+func (c *LoggingController) GetLevel(ctx *fiber.Ctx) error {
+	return SuccessResp(ctx, fiber.StatusOK, "Log level retrieved successfully", dto.LogLevelResponse{Level: c.levels.Level()})
+}
+
+// SetLevel changes the log level without a restart
+func (c *LoggingController) SetLevel(ctx *fiber.Ctx) error {
+	var req dto.SetLogLevelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := c.levels.SetLevel(req.Level); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Log level updated successfully", dto.LogLevelResponse{Level: c.levels.Level()})
+}
+
+// RegisterRoutes registers the routes for the logging controller
+func (c *LoggingController) RegisterRoutes(router fiber.Router) {
+	adminGroup := router.Group("/admin/log-level")
+	adminGroup.Get("/", c.GetLevel)
+	adminGroup.Put("/", c.SetLevel)
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+)
+
+// OutboxController handles HTTP requests for inspecting and replaying dead-lettered events
+type OutboxController struct {
+	outboxUseCase usecase.OutboxUsecase
+}
+
+// NewOutboxController creates a new instance of OutboxController
+func NewOutboxController(outboxUseCase usecase.OutboxUsecase) *OutboxController {
+	return &OutboxController{
+		outboxUseCase: outboxUseCase,
+	}
+}
+
+// ListDeadLetters lists events the relay gave up on
+func (c *OutboxController) ListDeadLetters(ctx *fiber.Ctx) error {
+	messages, err := c.outboxUseCase.ListDeadLetters(ctx.UserContext())
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Dead-lettered events retrieved successfully", messages)
+}
+
+// Requeue puts a dead-lettered event back in the outbox
+func (c *OutboxController) Requeue(ctx *fiber.Ctx) error {
+	messageID, err := ctx.ParamsInt("messageId")
+	if err != nil || messageID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid message ID",
+		})
+	}
+
+	if err := c.outboxUseCase.Requeue(ctx.UserContext(), uint(messageID)); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Event requeued successfully", nil)
+}
+
+// RegisterRoutes registers the routes for the outbox controller
+func (c *OutboxController) RegisterRoutes(router fiber.Router) {
+	adminGroup := router.Group("/admin/outbox")
+	adminGroup.Get("/dead", c.ListDeadLetters)
+	adminGroup.Post("/:messageId/requeue", c.Requeue)
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// maxSettlementFileSize caps uploaded settlement files at 10 MB
+const maxSettlementFileSize = 10 << 20
+
+// ReconciliationController handles HTTP requests for settlement reconciliation
+type ReconciliationController struct {
+	reconciliationUseCase usecase.ReconciliationUsecase
+}
+
+// NewReconciliationController creates a new instance of ReconciliationController
+func NewReconciliationController(reconciliationUseCase usecase.ReconciliationUsecase) *ReconciliationController {
+	return &ReconciliationController{
+		reconciliationUseCase: reconciliationUseCase,
+	}
+}
+
+// Reconcile handles a multipart settlement file upload and returns the stored report
+func (c *ReconciliationController) Reconcile(ctx *fiber.Ctx) error {
+	settlementDate, err := time.Parse(time.DateOnly, ctx.FormValue("settlement_date"))
+	fileHeader, fileErr := ctx.FormFile("file")
+	if err != nil || fileErr != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "settlement_date (YYYY-MM-DD) and file are required",
+		})
+	}
+	if fileHeader.Size > maxSettlementFileSize {
+		return ctx.Status(fiber.StatusRequestEntityTooLarge).JSON(ErrorResponse{
+			Status:  fiber.StatusRequestEntityTooLarge,
+			Message: "Settlement file is too large",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+	defer file.Close()
+
+	report, err := c.reconciliationUseCase.Reconcile(ctx.UserContext(), reconciliation.Spec{
+		Provider:       ctx.FormValue("provider"),
+		SettlementDate: settlementDate,
+		FileName:       fileHeader.Filename,
+		Format:         ctx.FormValue("format"),
+		Actor:          ctx.FormValue("actor"),
+	}, file)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Settlement reconciled successfully", toReconciliationReportResponse(report))
+}
+
+// ListReports lists stored reports, optionally for one provider or settlement date
+func (c *ReconciliationController) ListReports(ctx *fiber.Ctx) error {
+	filter := &reconciliation.ReportFilter{}
+	if provider := ctx.Query("provider"); provider != "" {
+		filter.Provider = &provider
+	}
+	if date := ctx.Query("settlement_date"); date != "" {
+		settlementDate, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "settlement_date must be YYYY-MM-DD",
+			})
+		}
+		filter.SettlementDate = &settlementDate
+	}
+
+	reports, err := c.reconciliationUseCase.List(ctx.UserContext(), filter)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.ReconciliationReportResponse, len(reports))
+	for i, report := range reports {
+		response[i] = toReconciliationReportResponse(report)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Reconciliation reports retrieved successfully", response)
+}
+
+// GetReport returns a report with its items, optionally only those with the given status
+func (c *ReconciliationController) GetReport(ctx *fiber.Ctx) error {
+	reportID, ok := parseIDParam(ctx, "reportId")
+	if !ok {
+		return invalidIDResp(ctx, "report")
+	}
+
+	report, err := c.reconciliationUseCase.Get(ctx.UserContext(), reportID, ctx.Query("status"))
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Reconciliation report retrieved successfully", toReconciliationReportResponse(report))
+}
+
+// RegisterRoutes registers the routes for the reconciliation controller
+func (c *ReconciliationController) RegisterRoutes(router fiber.Router) {
+	adminGroup := router.Group("/admin/reconciliations")
+	adminGroup.Post("/", c.Reconcile)
+	adminGroup.Get("/", c.ListReports)
+	adminGroup.Get("/:reportId", c.GetReport)
+}
+
+func toReconciliationReportResponse(r reconciliation.Report) dto.ReconciliationReportResponse {
+	response := dto.ReconciliationReportResponse{
+		ID:              r.ID,
+		Provider:        r.Provider,
+		SettlementDate:  r.SettlementDate.Format(time.DateOnly),
+		FileName:        r.FileName,
+		Format:          r.Format.String(),
+		Records:         r.Records,
+		Matched:         r.Matched,
+		MissingInOurs:   r.MissingInOurs,
+		MissingInTheirs: r.MissingInTheirs,
+		AmountMismatch:  r.AmountMismatch,
+		Reconciled:      r.Discrepancies() == 0,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+	}
+	for _, item := range r.Items {
+		response.Items = append(response.Items, dto.ReconciliationItemResponse{
+			Status:            item.Status.String(),
+			ProviderReference: item.ProviderReference,
+			TransactionID:     item.TransactionID,
+			ProviderAmount:    optionalAmount(item.ProviderAmount),
+			TransactionAmount: optionalAmount(item.TransactionAmount),
+		})
+	}
+	return response
+}
+
+func optionalAmount(m vo.Money) *float64 {
+	if m.IsZero() {
+		return nil
+	}
+	amount := m.Amount()
+	return &amount
+}
@@ -58,6 +58,102 @@ func HandleError(c *fiber.Ctx, err error) error {
 	case errors.Is(err, errs.ErrInvalidTransactionStatus):
 		statusCode = http.StatusBadRequest
 		message = "Invalid transaction status"
+	case errors.Is(err, errs.ErrInvalidKYCStatus):
+		statusCode = http.StatusBadRequest
+		message = "Invalid KYC status"
+	case errors.Is(err, errs.ErrInvalidKYCTier):
+		statusCode = http.StatusBadRequest
+		message = "Invalid KYC tier"
+	case errors.Is(err, errs.ErrInvalidDocumentType):
+		statusCode = http.StatusBadRequest
+		message = "Invalid document type"
+	case errors.Is(err, errs.ErrKYCAlreadyPending):
+		statusCode = http.StatusConflict
+		message = "KYC review is already pending"
+	case errors.Is(err, errs.ErrKYCNotPending):
+		statusCode = http.StatusConflict
+		message = "KYC is not in 'pending' status"
+	case errors.Is(err, errs.ErrTierLimitExceeded):
+		statusCode = http.StatusForbidden
+		message = "Amount exceeds KYC tier limit"
+	case errors.Is(err, errs.ErrPaymentMethodNotAllowed):
+		statusCode = http.StatusForbidden
+		message = "Payment method not allowed for KYC tier"
+	case errors.Is(err, errs.ErrInvalidRiskDecision):
+		statusCode = http.StatusBadRequest
+		message = "Invalid risk decision"
+	case errors.Is(err, errs.ErrTopupRejected):
+		statusCode = http.StatusForbidden
+		message = "Top-up rejected by risk checks"
+	case errors.Is(err, errs.ErrChallengeRequired):
+		statusCode = http.StatusForbidden
+		message = "Transaction requires additional verification"
+	case errors.Is(err, errs.ErrConfirmInProgress):
+		statusCode = http.StatusConflict
+		message = "Transaction confirmation already in progress"
+	case errors.Is(err, errs.ErrTransactionNotChallenged):
+		statusCode = http.StatusConflict
+		message = "Transaction is not awaiting a risk challenge"
+	case errors.Is(err, errs.ErrInvalidLogLevel):
+		statusCode = http.StatusBadRequest
+		message = "Invalid log level"
+	case errors.Is(err, errs.ErrInvalidWebhookURL):
+		statusCode = http.StatusBadRequest
+		message = "Invalid webhook URL"
+	case errors.Is(err, errs.ErrInvalidEventType):
+		statusCode = http.StatusBadRequest
+		message = "Invalid event type"
+	case errors.Is(err, errs.ErrPaymentMethodDisabled):
+		statusCode = http.StatusForbidden
+		message = "Payment method is disabled"
+	case errors.Is(err, errs.ErrUnknownSetting):
+		statusCode = http.StatusNotFound
+		message = "Unknown setting"
+	case errors.Is(err, errs.ErrInvalidSettingValue):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrSettingActorRequired):
+		statusCode = http.StatusBadRequest
+		message = "Actor is required"
+	case errors.Is(err, errs.ErrAmountBelowFee):
+		statusCode = http.StatusBadRequest
+		message = "Amount does not cover the top-up fee"
+	case errors.Is(err, errs.ErrInvalidVoucher):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrVoucherNotActive):
+		statusCode = http.StatusBadRequest
+		message = "Voucher is not active"
+	case errors.Is(err, errs.ErrVoucherExhausted):
+		statusCode = http.StatusConflict
+		message = "Voucher has been fully redeemed"
+	case errors.Is(err, errs.ErrVoucherLimitReached):
+		statusCode = http.StatusConflict
+		message = "Voucher redemption limit reached for this user"
+	case errors.Is(err, errs.ErrInvalidAutoTopup):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrInvalidInstrument):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrInstrumentExpired):
+		statusCode = http.StatusBadRequest
+		message = "Payment instrument has expired"
+	case errors.Is(err, errs.ErrInstrumentInUse):
+		statusCode = http.StatusConflict
+		message = "Payment instrument is used by an auto top-up rule"
+	case errors.Is(err, errs.ErrInvalidReconciliation):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrInvalidStatement):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrInvalidProviderReference):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, errs.ErrProviderReferenceInUse):
+		statusCode = http.StatusConflict
+		message = "Provider reference is already recorded on another top-up"
 	default:
 		statusCode = http.StatusInternalServerError
 		message = "Something went wrong"
@@ -68,3 +164,20 @@ func HandleError(c *fiber.Ctx, err error) error {
 		Message: message,
 	})
 }
+
+// parseIDParam reads a positive integer route parameter
+func parseIDParam(c *fiber.Ctx, name string) (uint, bool) {
+	id, err := c.ParamsInt(name)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// invalidIDResp builds the 400 response for a malformed ID route parameter
+func invalidIDResp(c *fiber.Ctx, resource string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+		Status:  fiber.StatusBadRequest,
+		Message: "Invalid " + resource + " ID",
+	})
+}
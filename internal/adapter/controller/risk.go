@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// RiskController handles HTTP requests for reviewing risk decisions
+type RiskController struct {
+	riskUseCase usecase.RiskReviewUsecase
+}
+
+// NewRiskController creates a new instance of RiskController
+func NewRiskController(riskUseCase usecase.RiskReviewUsecase) *RiskController {
+	return &RiskController{
+		riskUseCase: riskUseCase,
+	}
+}
+
+// ListFlagged lists transactions by risk decision (defaults to "challenge")
+func (c *RiskController) ListFlagged(ctx *fiber.Ctx) error {
+	decision := ctx.Query("decision", vo.RiskDecisionChallenge.String())
+	transactions, err := c.riskUseCase.ListFlagged(ctx.UserContext(), decision)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Flagged transactions retrieved successfully", transactions)
+}
+
+// ResolveChallenge approves or rejects a challenged transaction
+func (c *RiskController) ResolveChallenge(ctx *fiber.Ctx) error {
+	transactionID, err := ctx.ParamsInt("transactionId")
+	if err != nil || transactionID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid transaction ID",
+		})
+	}
+	var req dto.ResolveChallengeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	transaction, err := c.riskUseCase.ResolveChallenge(ctx.UserContext(), uint(transactionID), req.Approve)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Risk challenge resolved successfully", transaction)
+}
+
+// RegisterRoutes registers the routes for the risk controller
+func (c *RiskController) RegisterRoutes(router fiber.Router) {
+	adminGroup := router.Group("/admin/risk")
+	adminGroup.Get("/transactions", c.ListFlagged)
+	adminGroup.Post("/transactions/:transactionId/resolve", c.ResolveChallenge)
+}
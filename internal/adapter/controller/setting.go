@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
+)
+
+// SettingController handles HTTP requests for runtime business settings
+type SettingController struct {
+	settingsUseCase usecase.SettingsUsecase
+}
+
+// NewSettingController creates a new instance of SettingController
+func NewSettingController(settingsUseCase usecase.SettingsUsecase) *SettingController {
+	return &SettingController{
+		settingsUseCase: settingsUseCase,
+	}
+}
+
+// ListSettings returns every setting with its value in effect
+func (c *SettingController) ListSettings(ctx *fiber.Ctx) error {
+	entries, err := c.settingsUseCase.List(ctx.UserContext())
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.SettingResponse, len(entries))
+	for i, e := range entries {
+		response[i] = toSettingResponse(e)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Settings retrieved successfully", response)
+}
+
+// UpdateSetting stores a new value for a setting
+func (c *SettingController) UpdateSetting(ctx *fiber.Ctx) error {
+	var req dto.UpdateSettingRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	entry, err := c.settingsUseCase.Update(ctx.UserContext(), ctx.Params("key"), req.Value, req.Actor, req.Reason)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Setting updated successfully", toSettingResponse(entry))
+}
+
+// ResetSetting removes the stored value so the configured default applies again
+func (c *SettingController) ResetSetting(ctx *fiber.Ctx) error {
+	var req dto.UpdateSettingRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	entry, err := c.settingsUseCase.Reset(ctx.UserContext(), ctx.Params("key"), req.Actor, req.Reason)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Setting reset successfully", toSettingResponse(entry))
+}
+
+// ListChanges returns the settings audit trail, newest first
+func (c *SettingController) ListChanges(ctx *fiber.Ctx) error {
+	changes, err := c.settingsUseCase.History(ctx.UserContext(), ctx.Query("key"), ctx.QueryInt("limit"))
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.SettingChangeResponse, len(changes))
+	for i, change := range changes {
+		response[i] = dto.SettingChangeResponse{
+			ChangeID:  change.ID,
+			Key:       change.Key.String(),
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			Actor:     change.Actor,
+			Reason:    change.Reason,
+			CreatedAt: change.CreatedAt,
+		}
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Setting changes retrieved successfully", response)
+}
+
+// RegisterRoutes registers the routes for the setting controller
+func (c *SettingController) RegisterRoutes(router fiber.Router) {
+	settingGroup := router.Group("/admin/settings")
+	settingGroup.Get("/", c.ListSettings)
+	settingGroup.Get("/history", c.ListChanges)
+	settingGroup.Put("/:key", c.UpdateSetting)
+	settingGroup.Delete("/:key", c.ResetSetting)
+}
+
+func toSettingResponse(e setting.Entry) dto.SettingResponse {
+	response := dto.SettingResponse{
+		Key:        e.Key.String(),
+		Value:      e.Value,
+		Default:    e.Default,
+		Overridden: e.Stored != nil,
+	}
+	if e.Stored != nil {
+		response.UpdatedBy = e.Stored.UpdatedBy
+		response.UpdatedAt = &e.Stored.UpdatedAt
+	}
+	return response
+}
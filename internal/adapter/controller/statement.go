@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+)
+
+// StatementController handles HTTP requests for wallet statements
+type StatementController struct {
+	statementUseCase usecase.StatementUsecase
+	// writeTimeout is how long a single write of a statement may take. The server sets its write
+	// deadline once per response, so a long statement would otherwise be cut off part way.
+	writeTimeout time.Duration
+}
+
+// NewStatementController creates a new instance of StatementController
+func NewStatementController(statementUseCase usecase.StatementUsecase, writeTimeout time.Duration) *StatementController {
+	return &StatementController{
+		statementUseCase: statementUseCase,
+		writeTimeout:     writeTimeout,
+	}
+}
+
+// ExportStatement streams a wallet statement for a date range as a CSV or PDF download
+func (c *StatementController) ExportStatement(ctx *fiber.Ctx) error {
+	userID, ok := parseIDParam(ctx, "userId")
+	if !ok {
+		return invalidIDResp(ctx, "user")
+	}
+	from, fromErr := time.Parse(time.DateOnly, ctx.Query("from"))
+	to, toErr := time.Parse(time.DateOnly, ctx.Query("to"))
+	if fromErr != nil || toErr != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "from and to are required as YYYY-MM-DD",
+		})
+	}
+
+	s, err := c.statementUseCase.Open(ctx.UserContext(), statement.Spec{
+		WalletID: userID,
+		From:     from,
+		To:       to,
+		Format:   ctx.Query("format", statement.FormatCSV.String()),
+	})
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, s.Format.ContentType())
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", s.FileName()))
+	// The fiber context is recycled once the handler returns, so the stream only uses the request context
+	userCtx := ctx.UserContext()
+	conn := ctx.Context().Conn()
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// A failed statement ends with the renderer's error marker, which is flushed like the rest
+		_, _ = c.statementUseCase.Write(userCtx, s, &deadlineWriter{w: w, conn: conn, timeout: c.writeTimeout})
+		_ = w.Flush()
+	})
+	return nil
+}
+
+// deadlineWriter extends the write deadline of conn before every write, so the write timeout
+// bounds each chunk of a streamed response rather than the whole response
+type deadlineWriter struct {
+	w       *bufio.Writer
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	if d.conn != nil && d.timeout > 0 {
+		_ = d.conn.SetWriteDeadline(time.Now().Add(d.timeout))
+	}
+	return d.w.Write(p)
+}
+
+// RegisterRoutes registers the routes for the statement controller
+func (c *StatementController) RegisterRoutes(router fiber.Router) {
+	router.Get("/users/:userId/statement", c.ExportStatement)
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+)
+
+// VoucherController handles HTTP requests for voucher redemption and administration
+type VoucherController struct {
+	voucherUseCase usecase.VoucherUsecase
+}
+
+// NewVoucherController creates a new instance of VoucherController
+func NewVoucherController(voucherUseCase usecase.VoucherUsecase) *VoucherController {
+	return &VoucherController{
+		voucherUseCase: voucherUseCase,
+	}
+}
+
+// RedeemVoucher credits a voucher to the user's wallet
+func (c *VoucherController) RedeemVoucher(ctx *fiber.Ctx) error {
+	var req dto.RedeemVoucherRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	if req.UserID == 0 || req.Code == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "UserID and Code are required",
+		})
+	}
+
+	transaction, wallet, err := c.voucherUseCase.Redeem(ctx.UserContext(), req.UserID, req.Code)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := dto.ConfirmResponse{
+		TransactionID: transaction.ID,
+		UserID:        transaction.UserID,
+		Amount:        transaction.Amount.Amount(),
+		Fee:           transaction.Fee.Amount(),
+		Bonus:         transaction.Bonus.Amount(),
+		NetAmount:     transaction.NetAmount().Amount(),
+		Status:        transaction.Status.String(),
+		Balance:       wallet.Balance.Amount(),
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Voucher redeemed successfully", response)
+}
+
+// GenerateVouchers creates a batch of voucher codes
+func (c *VoucherController) GenerateVouchers(ctx *fiber.Ctx) error {
+	var req dto.GenerateVouchersRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	spec := voucher.GenerateSpec{
+		Count:          req.Count,
+		Prefix:         req.Prefix,
+		Batch:          req.Batch,
+		Amount:         req.Amount,
+		MaxRedemptions: 1,
+		PerUserLimit:   1,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		Actor:          req.Actor,
+	}
+	if req.MaxRedemptions != nil {
+		spec.MaxRedemptions = *req.MaxRedemptions
+	}
+	if req.PerUserLimit != nil {
+		spec.PerUserLimit = *req.PerUserLimit
+	}
+	vouchers, err := c.voucherUseCase.Generate(ctx.UserContext(), spec)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := dto.GenerateVouchersResponse{Count: len(vouchers), Codes: make([]string, len(vouchers))}
+	for i, v := range vouchers {
+		response.Batch = v.Batch
+		response.Codes[i] = v.Code
+	}
+	return SuccessResp(ctx, fiber.StatusCreated, "Vouchers generated successfully", response)
+}
+
+// ExportVouchers returns the vouchers of a batch, or all vouchers, as a CSV download
+func (c *VoucherController) ExportVouchers(ctx *fiber.Ctx) error {
+	batch := ctx.Query("batch")
+	vouchers, err := c.voucherUseCase.List(ctx.UserContext(), batch)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	filename := "vouchers.csv"
+	if batch != "" {
+		filename = "vouchers-" + batch + ".csv"
+	}
+	ctx.Set(fiber.HeaderContentType, "text/csv")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	w := csv.NewWriter(ctx)
+	_ = w.Write([]string{"code", "batch", "amount", "max_redemptions", "per_user_limit", "redemptions", "valid_from", "valid_until", "created_by", "created_at"})
+	for _, v := range vouchers {
+		_ = w.Write([]string{
+			v.Code,
+			v.Batch,
+			v.Amount.String(),
+			strconv.Itoa(v.MaxRedemptions),
+			strconv.Itoa(v.PerUserLimit),
+			strconv.Itoa(v.Redemptions),
+			formatOptionalTime(v.ValidFrom),
+			formatOptionalTime(v.ValidUntil),
+			v.CreatedBy,
+			v.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// RegisterRoutes registers the routes for the voucher controller
+func (c *VoucherController) RegisterRoutes(router fiber.Router) {
+	router.Post("/vouchers/redeem", c.RedeemVoucher)
+
+	adminGroup := router.Group("/admin/vouchers")
+	adminGroup.Post("/", c.GenerateVouchers)
+	adminGroup.Get("/export", c.ExportVouchers)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
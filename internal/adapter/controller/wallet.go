@@ -25,18 +25,37 @@ func (c *WalletController) VerifyTopup(ctx *fiber.Ctx) error {
 		return HandleError(ctx, err)
 	}
 
-	if req.UserID == 0 || req.Amount <= 0 || req.PaymentMethod == "" {
+	if req.UserID == 0 || req.Amount <= 0 || req.InstrumentID == 0 {
 		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
 			Status:  fiber.StatusBadRequest,
-			Message: "UserID, Amount, and PaymentMethod are required and must be valid",
+			Message: "UserID, Amount, and InstrumentID are required and must be valid",
 		})
 	}
 
-	response, err := c.walletUseCase.VerifyTopup(ctx.Context(), req.UserID, req.Amount, req.PaymentMethod)
+	transaction, err := c.walletUseCase.VerifyTopup(ctx.UserContext(), req.UserID, req.Amount, req.InstrumentID)
 	if err != nil {
 		return HandleError(ctx, err)
 	}
 
+	response := dto.VerifyResponse{
+		ID:            transaction.ID,
+		UserID:        transaction.UserID,
+		Amount:        transaction.Amount.Amount(),
+		GrossAmount:   transaction.Amount.Amount(),
+		Fee:           transaction.Fee.Amount(),
+		Bonus:         transaction.Bonus.Amount(),
+		NetAmount:     transaction.NetAmount().Amount(),
+		Campaign:      transaction.Campaign,
+		PaymentMethod: transaction.PaymentMethod.String(),
+		InstrumentID:  transaction.InstrumentID,
+		Status:        transaction.Status.String(),
+		ExpiresAt:     transaction.ExpiresAt,
+		RiskScore:     transaction.RiskScore,
+		RiskDecision:  transaction.RiskDecision.String(),
+		RiskReasons:   transaction.RiskReasons,
+		CreatedAt:     transaction.CreatedAt,
+	}
+
 	return SuccessResp(ctx, fiber.StatusOK, "Top-up verified successfully", response)
 }
 
@@ -54,7 +73,7 @@ func (c *WalletController) ConfirmTopup(ctx *fiber.Ctx) error {
 		})
 	}
 
-	transaction, wallet, err := c.walletUseCase.ConfirmTopup(ctx.Context(), req.TransactionID)
+	transaction, wallet, err := c.walletUseCase.ConfirmTopup(ctx.UserContext(), req.TransactionID, req.ProviderReference)
 	if err != nil {
 		return HandleError(ctx, err)
 	}
@@ -63,6 +82,9 @@ func (c *WalletController) ConfirmTopup(ctx *fiber.Ctx) error {
 		TransactionID: transaction.ID,
 		UserID:        transaction.UserID,
 		Amount:        transaction.Amount.Amount(),
+		Fee:           transaction.Fee.Amount(),
+		Bonus:         transaction.Bonus.Amount(),
+		NetAmount:     transaction.NetAmount().Amount(),
 		Status:        transaction.Status.String(),
 		Balance:       wallet.Balance.Amount(),
 	}
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/dto"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+)
+
+// WebhookController handles HTTP requests for managing merchant webhooks
+type WebhookController struct {
+	webhookUseCase usecase.WebhookUsecase
+}
+
+// NewWebhookController creates a new instance of WebhookController
+func NewWebhookController(webhookUseCase usecase.WebhookUsecase) *WebhookController {
+	return &WebhookController{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// CreateSubscription registers a new webhook endpoint
+func (c *WebhookController) CreateSubscription(ctx *fiber.Ctx) error {
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err)
+	}
+	if req.URL == "" || len(req.EventTypes) == 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "URL and EventTypes are required",
+		})
+	}
+
+	subscription, err := c.webhookUseCase.CreateSubscription(ctx.UserContext(), req.URL, req.EventTypes, req.Secret)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := toWebhookSubscriptionResponse(subscription)
+	response.Secret = subscription.Secret
+	return SuccessResp(ctx, fiber.StatusCreated, "Webhook subscription created successfully", response)
+}
+
+// ListSubscriptions lists all webhook subscriptions
+func (c *WebhookController) ListSubscriptions(ctx *fiber.Ctx) error {
+	subscriptions, err := c.webhookUseCase.ListSubscriptions(ctx.UserContext())
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.WebhookSubscriptionResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		response[i] = toWebhookSubscriptionResponse(s)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Webhook subscriptions retrieved successfully", response)
+}
+
+// GetSubscription returns a single webhook subscription
+func (c *WebhookController) GetSubscription(ctx *fiber.Ctx) error {
+	id, ok := parseIDParam(ctx, "subscriptionId")
+	if !ok {
+		return invalidIDResp(ctx, "subscription")
+	}
+
+	subscription, err := c.webhookUseCase.GetSubscription(ctx.UserContext(), id)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Webhook subscription retrieved successfully", toWebhookSubscriptionResponse(subscription))
+}
+
+// DeleteSubscription removes a webhook subscription
+func (c *WebhookController) DeleteSubscription(ctx *fiber.Ctx) error {
+	id, ok := parseIDParam(ctx, "subscriptionId")
+	if !ok {
+		return invalidIDResp(ctx, "subscription")
+	}
+
+	if err := c.webhookUseCase.DeleteSubscription(ctx.UserContext(), id); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Webhook subscription deleted successfully", nil)
+}
+
+// EnableSubscription re-enables a subscription disabled after repeated failures
+func (c *WebhookController) EnableSubscription(ctx *fiber.Ctx) error {
+	id, ok := parseIDParam(ctx, "subscriptionId")
+	if !ok {
+		return invalidIDResp(ctx, "subscription")
+	}
+
+	if err := c.webhookUseCase.EnableSubscription(ctx.UserContext(), id); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Webhook subscription enabled successfully", nil)
+}
+
+// ListDeliveries lists deliveries for a subscription
+func (c *WebhookController) ListDeliveries(ctx *fiber.Ctx) error {
+	id, ok := parseIDParam(ctx, "subscriptionId")
+	if !ok {
+		return invalidIDResp(ctx, "subscription")
+	}
+
+	deliveries, err := c.webhookUseCase.ListDeliveries(ctx.UserContext(), id)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		response[i] = toWebhookDeliveryResponse(d)
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Webhook deliveries retrieved successfully", response)
+}
+
+// GetDelivery returns a delivery with its attempt log
+func (c *WebhookController) GetDelivery(ctx *fiber.Ctx) error {
+	id, ok := parseIDParam(ctx, "deliveryId")
+	if !ok {
+		return invalidIDResp(ctx, "delivery")
+	}
+
+	delivery, attempts, err := c.webhookUseCase.GetDelivery(ctx.UserContext(), id)
+	if err != nil {
+		return HandleError(ctx, err)
+	}
+
+	response := dto.WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: toWebhookDeliveryResponse(delivery),
+		AttemptLog:              make([]dto.WebhookAttemptResponse, len(attempts)),
+	}
+	for i, a := range attempts {
+		response.AttemptLog[i] = dto.WebhookAttemptResponse{
+			Attempt:      a.Attempt,
+			ResponseCode: a.ResponseCode,
+			Error:        a.Error,
+			DurationMs:   a.Duration.Milliseconds(),
+			CreatedAt:    a.CreatedAt,
+		}
+	}
+	return SuccessResp(ctx, fiber.StatusOK, "Webhook delivery retrieved successfully", response)
+}
+
+// Redeliver queues a delivery to be sent again
+func (c *WebhookController) Redeliver(ctx *fiber.Ctx) error {
+	id, ok := parseIDParam(ctx, "deliveryId")
+	if !ok {
+		return invalidIDResp(ctx, "delivery")
+	}
+
+	if err := c.webhookUseCase.Redeliver(ctx.UserContext(), id); err != nil {
+		return HandleError(ctx, err)
+	}
+
+	return SuccessResp(ctx, fiber.StatusAccepted, "Webhook delivery queued successfully", nil)
+}
+
+// RegisterRoutes registers the routes for the webhook controller
+func (c *WebhookController) RegisterRoutes(router fiber.Router) {
+	webhookGroup := router.Group("/admin/webhooks")
+	webhookGroup.Post("/subscriptions", c.CreateSubscription)
+	webhookGroup.Get("/subscriptions", c.ListSubscriptions)
+	webhookGroup.Get("/subscriptions/:subscriptionId", c.GetSubscription)
+	webhookGroup.Delete("/subscriptions/:subscriptionId", c.DeleteSubscription)
+	webhookGroup.Post("/subscriptions/:subscriptionId/enable", c.EnableSubscription)
+	webhookGroup.Get("/subscriptions/:subscriptionId/deliveries", c.ListDeliveries)
+	webhookGroup.Get("/deliveries/:deliveryId", c.GetDelivery)
+	webhookGroup.Post("/deliveries/:deliveryId/redeliver", c.Redeliver)
+}
+
+func toWebhookSubscriptionResponse(s webhook.Subscription) dto.WebhookSubscriptionResponse {
+	eventTypes := make([]string, len(s.EventTypes))
+	for i, t := range s.EventTypes {
+		eventTypes[i] = t.String()
+	}
+	return dto.WebhookSubscriptionResponse{
+		SubscriptionID:      s.ID,
+		URL:                 s.URL,
+		EventTypes:          eventTypes,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		CreatedAt:           s.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(d webhook.Delivery) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		DeliveryID:       d.ID,
+		SubscriptionID:   d.SubscriptionID,
+		EventID:          d.EventID,
+		EventType:        d.EventType.String(),
+		Status:           d.Status.String(),
+		Attempts:         d.Attempts,
+		LastResponseCode: d.LastResponseCode,
+		LastError:        d.LastError,
+		NextAttemptAt:    d.NextAttemptAt,
+		DeliveredAt:      d.DeliveredAt,
+		CreatedAt:        d.CreatedAt,
+	}
+}
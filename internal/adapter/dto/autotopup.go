@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// CreateAutoTopupRequest represents the input data for creating an auto top-up rule.
+// Threshold rules need a threshold; schedule rules need a five-field cron expression in UTC.
+type CreateAutoTopupRequest struct {
+	Trigger      string  `json:"trigger"`
+	Threshold    float64 `json:"threshold"`
+	Schedule     string  `json:"schedule"`
+	Amount       float64 `json:"amount"`
+	InstrumentID uint    `json:"instrument_id"`
+}
+
+// AutoTopupResponse represents an auto top-up rule
+type AutoTopupResponse struct {
+	ID           uint       `json:"id"`
+	UserID       uint       `json:"user_id"`
+	Trigger      string     `json:"trigger"`
+	Threshold    float64    `json:"threshold,omitempty"`
+	Schedule     string     `json:"schedule,omitempty"`
+	Amount       float64    `json:"amount"`
+	InstrumentID uint       `json:"instrument_id,omitempty"` // unset on rules disabled when saved instruments were introduced
+	Enabled      bool       `json:"enabled"`
+	NextRunAt    *time.Time `json:"next_run_at"`
+	Attempts     int        `json:"attempts"`
+	LastRunAt    *time.Time `json:"last_run_at"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
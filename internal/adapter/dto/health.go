@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/hydr0g3nz/wallet_topup_system/internal/domain/health"
+
+// HealthCheckResponse represents the result of one dependency check
+type HealthCheckResponse struct {
+	Status    string                 `json:"status"`
+	Critical  bool                   `json:"critical"`
+	LatencyMs float64                `json:"latency_ms"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// LivenessResponse represents the liveness probe result
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// HealthReportResponse represents the readiness report
+type HealthReportResponse struct {
+	Status string                         `json:"status"`
+	Checks map[string]HealthCheckResponse `json:"checks"`
+}
+
+// NewHealthReportResponse converts a health report, expressing latencies in milliseconds
+func NewHealthReportResponse(report health.Report) HealthReportResponse {
+	checks := make(map[string]HealthCheckResponse, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = HealthCheckResponse{
+			Status:    result.Status,
+			Critical:  result.Critical,
+			LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+			Error:     result.Error,
+			Details:   result.Details,
+		}
+	}
+	return HealthReportResponse{Status: report.Status, Checks: checks}
+}
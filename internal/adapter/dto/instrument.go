@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// SaveInstrumentRequest represents a tokenized card or bank account to save. The token comes
+// from the payment provider; card numbers and account numbers must never be sent.
+type SaveInstrumentRequest struct {
+	Type          string `json:"type"` // "card" or "bank_account"
+	Token         string `json:"token"`
+	Brand         string `json:"brand"`
+	Last4         string `json:"last4"`
+	ExpMonth      int    `json:"exp_month"`
+	ExpYear       int    `json:"exp_year"`
+	BankName      string `json:"bank_name"`
+	AccountHolder string `json:"account_holder"`
+}
+
+// InstrumentResponse represents a saved payment instrument. The token is never returned.
+type InstrumentResponse struct {
+	ID            uint                 `json:"id"`
+	UserID        uint                 `json:"user_id"`
+	Type          string               `json:"type"`
+	PaymentMethod string               `json:"payment_method"`
+	Card          *CardResponse        `json:"card,omitempty"`
+	BankAccount   *BankAccountResponse `json:"bank_account,omitempty"`
+	Expired       bool                 `json:"expired"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+// CardResponse represents the displayable details of a saved card
+type CardResponse struct {
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+}
+
+// BankAccountResponse represents the displayable details of a saved bank account
+type BankAccountResponse struct {
+	BankName      string `json:"bank_name"`
+	AccountHolder string `json:"account_holder"`
+	Last4         string `json:"last4"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// KYCDocumentResponse represents a submitted KYC document
+type KYCDocumentResponse struct {
+	DocumentID   uint       `json:"document_id"`
+	UserID       uint       `json:"user_id"`
+	DocumentType string     `json:"document_type"`
+	Status       string     `json:"status"`
+	ReviewNote   string     `json:"review_note,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// KYCStatusResponse represents a user's KYC status and document history
+type KYCStatusResponse struct {
+	UserID    uint                  `json:"user_id"`
+	KYCStatus string                `json:"kyc_status"`
+	KYCTier   string                `json:"kyc_tier"`
+	Documents []KYCDocumentResponse `json:"documents"`
+}
+
+// KYCApproveRequest represents the input data for approving a KYC document
+type KYCApproveRequest struct {
+	Tier string `json:"tier"`
+	Note string `json:"note"`
+}
+
+// KYCRejectRequest represents the input data for rejecting a KYC document
+type KYCRejectRequest struct {
+	Note string `json:"note"`
+}
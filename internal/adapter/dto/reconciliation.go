@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// ReconciliationReportResponse represents the outcome of reconciling a settlement file
+type ReconciliationReportResponse struct {
+	ID              uint                         `json:"id"`
+	Provider        string                       `json:"provider"`
+	SettlementDate  string                       `json:"settlement_date"` // YYYY-MM-DD
+	FileName        string                       `json:"file_name"`
+	Format          string                       `json:"format"`
+	Records         int                          `json:"records"`
+	Matched         int                          `json:"matched"`
+	MissingInOurs   int                          `json:"missing_in_ours"`
+	MissingInTheirs int                          `json:"missing_in_theirs"`
+	AmountMismatch  int                          `json:"amount_mismatch"`
+	Reconciled      bool                         `json:"reconciled"`
+	CreatedBy       string                       `json:"created_by"`
+	CreatedAt       time.Time                    `json:"created_at"`
+	Items           []ReconciliationItemResponse `json:"items,omitempty"`
+}
+
+// ReconciliationItemResponse represents one record of a reconciliation report. An amount is
+// null on the side that has no record.
+type ReconciliationItemResponse struct {
+	Status            string   `json:"status"`
+	ProviderReference string   `json:"provider_reference"`
+	TransactionID     uint     `json:"transaction_id,omitempty"`
+	ProviderAmount    *float64 `json:"provider_amount"`
+	TransactionAmount *float64 `json:"transaction_amount"`
+}
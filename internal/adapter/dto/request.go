@@ -2,33 +2,65 @@ package dto
 
 import "time"
 
-// VerifyRequest represents the input data for verifying a top-up request
+// VerifyRequest represents the input data for verifying a top-up request.
+// The payment method follows from the saved instrument.
 type VerifyRequest struct {
-	UserID        uint    `json:"user_id"`
-	Amount        float64 `json:"amount"`
-	PaymentMethod string  `json:"payment_method"`
+	UserID       uint    `json:"user_id"`
+	Amount       float64 `json:"amount"`
+	InstrumentID uint    `json:"instrument_id"`
 }
 
 // VerifyResponse represents the output data for verifying a top-up request
 type VerifyResponse struct {
-	TransactionID uint      `json:"transaction_id"`
+	ID            uint      `json:"id"`
 	UserID        uint      `json:"user_id"`
-	Amount        float64   `json:"amount"`
+	Amount        float64   `json:"amount"` // same as gross_amount, kept for existing clients
+	GrossAmount   float64   `json:"gross_amount"`
+	Fee           float64   `json:"fee"`
+	Bonus         float64   `json:"bonus"`
+	NetAmount     float64   `json:"net_amount"` // credited to the wallet on confirm
+	Campaign      string    `json:"campaign,omitempty"`
 	PaymentMethod string    `json:"payment_method"`
+	InstrumentID  uint      `json:"instrument_id"`
 	Status        string    `json:"status"`
 	ExpiresAt     time.Time `json:"expires_at"`
+	RiskScore     int       `json:"risk_score"`
+	RiskDecision  string    `json:"risk_decision,omitempty"`
+	RiskReasons   []string  `json:"risk_reasons,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // ConfirmRequest represents the input data for confirming a top-up transaction
 type ConfirmRequest struct {
 	TransactionID uint `json:"transaction_id"`
+	// ProviderReference is the payment provider's reference for the charge, which settlement
+	// reconciliation matches on; optional
+	ProviderReference string `json:"provider_reference"`
 }
 
 // ConfirmResponse represents the output data for confirming a top-up transaction
 type ConfirmResponse struct {
 	TransactionID uint    `json:"transaction_id"`
 	UserID        uint    `json:"user_id"`
-	Amount        float64 `json:"amount"`
+	Amount        float64 `json:"amount"` // gross amount paid
+	Fee           float64 `json:"fee"`
+	Bonus         float64 `json:"bonus"`
+	NetAmount     float64 `json:"net_amount"`
 	Status        string  `json:"status"`
 	Balance       float64 `json:"balance"`
 }
+
+// ResolveChallengeRequest represents a reviewer's decision on a challenged transaction
+type ResolveChallengeRequest struct {
+	Approve bool `json:"approve"`
+}
+
+// SetLogLevelRequest represents a runtime log level change
+type SetLogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// LogLevelResponse represents the current log level
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
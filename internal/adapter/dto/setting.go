@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// UpdateSettingRequest represents the input data for changing or resetting a runtime setting
+type UpdateSettingRequest struct {
+	Value  string `json:"value"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// SettingResponse represents a runtime setting. Overridden is false while the configured default applies.
+type SettingResponse struct {
+	Key        string     `json:"key"`
+	Value      string     `json:"value"`
+	Default    string     `json:"default"`
+	Overridden bool       `json:"overridden"`
+	UpdatedBy  string     `json:"updated_by,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// SettingChangeResponse represents one entry of the settings audit trail. A null value means the default applied.
+type SettingChangeResponse struct {
+	ChangeID  uint      `json:"change_id"`
+	Key       string    `json:"key"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// RedeemVoucherRequest represents the input data for redeeming a voucher code
+type RedeemVoucherRequest struct {
+	UserID uint   `json:"user_id"`
+	Code   string `json:"code"`
+}
+
+// GenerateVouchersRequest represents the input data for generating a batch of voucher codes.
+// Both limits default to 1, a one-off code; 0 means unlimited.
+type GenerateVouchersRequest struct {
+	Count          int        `json:"count"`
+	Amount         float64    `json:"amount"`
+	Prefix         string     `json:"prefix"`
+	Batch          string     `json:"batch"`
+	MaxRedemptions *int       `json:"max_redemptions"`
+	PerUserLimit   *int       `json:"per_user_limit"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	Actor          string     `json:"actor"`
+}
+
+// GenerateVouchersResponse represents a generated batch of voucher codes
+type GenerateVouchersResponse struct {
+	Batch string   `json:"batch"`
+	Count int      `json:"count"`
+	Codes []string `json:"codes"`
+}
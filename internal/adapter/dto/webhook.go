@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// CreateWebhookSubscriptionRequest represents the input data for registering a webhook endpoint
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+// WebhookSubscriptionResponse represents a webhook subscription. Secret is only set on creation.
+type WebhookSubscriptionResponse struct {
+	SubscriptionID      uint       `json:"subscription_id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Secret              string     `json:"secret,omitempty"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// WebhookDeliveryResponse represents one event delivery to a subscription
+type WebhookDeliveryResponse struct {
+	DeliveryID       uint       `json:"delivery_id"`
+	SubscriptionID   uint       `json:"subscription_id"`
+	EventID          uint       `json:"event_id"`
+	EventType        string     `json:"event_type"`
+	Status           string     `json:"status"`
+	Attempts         int        `json:"attempts"`
+	LastResponseCode int        `json:"last_response_code,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+	NextAttemptAt    time.Time  `json:"next_attempt_at"`
+	DeliveredAt      *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// WebhookAttemptResponse represents a single entry of the delivery log
+type WebhookAttemptResponse struct {
+	Attempt      int       `json:"attempt"`
+	ResponseCode int       `json:"response_code,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	DurationMs   int64     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WebhookDeliveryDetailResponse represents a delivery together with its attempt log
+type WebhookDeliveryDetailResponse struct {
+	WebhookDeliveryResponse
+	AttemptLog []WebhookAttemptResponse `json:"attempt_log"`
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// AutoTopupRule represents the auto_topup_rules table
+type AutoTopupRule struct {
+	ID               uint    `gorm:"primarykey"`
+	UserID           uint    `gorm:"not null;index"`
+	Trigger          string  `gorm:"size:20;not null;check:trigger IN ('threshold','schedule')"`
+	Threshold        float64 `gorm:"type:decimal(18,2);not null;default:0"`
+	Schedule         string  `gorm:"size:100;not null;default:''"`
+	Amount           float64 `gorm:"type:decimal(18,2);not null;check:amount > 0"`
+	InstrumentID     *uint   `gorm:"check:instrument_id IS NOT NULL OR NOT enabled"` // NULL only on rules disabled when instruments were introduced
+	Enabled          bool    `gorm:"not null;default:true"`
+	NextRunAt        *time.Time
+	Attempts         int    `gorm:"not null;default:0"`
+	RunKey           string `gorm:"size:100;not null;default:''"`
+	RunTransactionID *uint
+	LastRunAt        *time.Time
+	LastError        string `gorm:"type:text;not null;default:''"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (r AutoTopupRule) ToDomain() (autotopup.Rule, error) {
+	trigger, err := autotopup.NewTrigger(r.Trigger)
+	if err != nil {
+		return autotopup.Rule{}, err
+	}
+	amount, err := vo.NewMoney(r.Amount)
+	if err != nil {
+		return autotopup.Rule{}, err
+	}
+	return autotopup.Rule{
+		ID:               r.ID,
+		UserID:           r.UserID,
+		Trigger:          trigger,
+		Threshold:        r.Threshold,
+		Schedule:         r.Schedule,
+		Amount:           amount,
+		InstrumentID:     valueOrZero(r.InstrumentID),
+		Enabled:          r.Enabled,
+		NextRunAt:        r.NextRunAt,
+		Attempts:         r.Attempts,
+		RunKey:           r.RunKey,
+		RunTransactionID: valueOrZero(r.RunTransactionID),
+		LastRunAt:        r.LastRunAt,
+		LastError:        r.LastError,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}, nil
+}
+
+func CreateAutoTopupRuleFromDomain(r autotopup.Rule) AutoTopupRule {
+	return AutoTopupRule{
+		ID:               r.ID,
+		UserID:           r.UserID,
+		Trigger:          r.Trigger.String(),
+		Threshold:        r.Threshold,
+		Schedule:         r.Schedule,
+		Amount:           r.Amount.Amount(),
+		InstrumentID:     nilIfZero(r.InstrumentID),
+		Enabled:          r.Enabled,
+		NextRunAt:        r.NextRunAt,
+		Attempts:         r.Attempts,
+		RunKey:           r.RunKey,
+		RunTransactionID: nilIfZero(r.RunTransactionID),
+		LastRunAt:        r.LastRunAt,
+		LastError:        r.LastError,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
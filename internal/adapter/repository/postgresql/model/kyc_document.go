@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+// KYCDocument represents the kyc_documents table
+type KYCDocument struct {
+	gorm.Model
+	UserID       uint   `gorm:"not null;index"`
+	DocumentType string `gorm:"size:30;not null;check:document_type IN ('national_id','passport','driving_license')"`
+	StorageKey   string `gorm:"size:255;not null"`
+	Status       string `gorm:"size:20;not null;check:status IN ('pending','approved','rejected')"`
+	ReviewNote   string `gorm:"size:500"`
+	ReviewedAt   *time.Time
+}
+
+func (d KYCDocument) ToDomain() (*kyc.Document, error) {
+	docType, err := vo.NewDocumentType(d.DocumentType)
+	if err != nil {
+		return nil, err
+	}
+	status, err := vo.NewKYCStatus(d.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &kyc.Document{
+		ID:           d.ID,
+		UserID:       d.UserID,
+		DocumentType: docType,
+		StorageKey:   d.StorageKey,
+		Status:       status,
+		ReviewNote:   d.ReviewNote,
+		ReviewedAt:   d.ReviewedAt,
+		CreatedAt:    d.CreatedAt,
+	}, nil
+}
+
+func CreateKYCDocumentFromDomain(d kyc.Document) KYCDocument {
+	return KYCDocument{
+		Model:        gorm.Model{ID: d.ID},
+		UserID:       d.UserID,
+		DocumentType: d.DocumentType.String(),
+		StorageKey:   d.StorageKey,
+		Status:       d.Status.String(),
+		ReviewNote:   d.ReviewNote,
+		ReviewedAt:   d.ReviewedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+)
+
+// OutboxMessage represents the outbox_messages table
+type OutboxMessage struct {
+	ID            uint      `gorm:"primarykey"`
+	AggregateType string    `gorm:"size:50;not null;index:idx_outbox_aggregate,priority:1"`
+	AggregateID   string    `gorm:"size:64;not null;index:idx_outbox_aggregate,priority:2"`
+	EventType     string    `gorm:"size:100;not null"`
+	Payload       []byte    `gorm:"type:jsonb;not null"`
+	Status        string    `gorm:"size:20;not null;default:'pending';index;check:status IN ('pending','published','dead')"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string    `gorm:"type:text"`
+	NextAttemptAt time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+func (m OutboxMessage) ToDomain() outbox.Message {
+	return outbox.Message{
+		ID:            m.ID,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		EventType:     event.Type(m.EventType),
+		Payload:       m.Payload,
+		Status:        outbox.Status(m.Status),
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		NextAttemptAt: m.NextAttemptAt,
+		CreatedAt:     m.CreatedAt,
+		PublishedAt:   m.PublishedAt,
+	}
+}
+
+func CreateOutboxMessageFromDomain(m outbox.Message) OutboxMessage {
+	return OutboxMessage{
+		ID:            m.ID,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		EventType:     m.EventType.String(),
+		Payload:       m.Payload,
+		Status:        m.Status.String(),
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		NextAttemptAt: m.NextAttemptAt,
+		CreatedAt:     m.CreatedAt,
+		PublishedAt:   m.PublishedAt,
+	}
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// PaymentInstrument represents the payment_instruments table. The token and the card or bank
+// account details are kept only in EncryptedData.
+type PaymentInstrument struct {
+	ID            uint   `gorm:"primarykey"`
+	UserID        uint   `gorm:"not null;index"`
+	Type          string `gorm:"size:20;not null;check:type IN ('card','bank_account')"`
+	EncryptedData []byte `gorm:"type:bytea;not null"`
+	CreatedAt     time.Time
+}
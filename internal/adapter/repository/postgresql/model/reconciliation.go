@@ -0,0 +1,126 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// ReconciliationReport represents the reconciliation_reports table
+type ReconciliationReport struct {
+	ID              uint      `gorm:"primarykey"`
+	Provider        string    `gorm:"size:50;not null;index:idx_reconciliation_reports_provider_date"`
+	SettlementDate  time.Time `gorm:"type:date;not null;index:idx_reconciliation_reports_provider_date"`
+	FileName        string    `gorm:"size:255;not null;default:''"`
+	Format          string    `gorm:"size:10;not null;check:format IN ('csv','json')"`
+	Records         int       `gorm:"not null;default:0"`
+	Matched         int       `gorm:"not null;default:0"`
+	MissingInOurs   int       `gorm:"not null;default:0"`
+	MissingInTheirs int       `gorm:"not null;default:0"`
+	AmountMismatch  int       `gorm:"not null;default:0"`
+	CreatedBy       string    `gorm:"size:100;not null"`
+	CreatedAt       time.Time
+}
+
+func (r ReconciliationReport) ToDomain() (reconciliation.Report, error) {
+	format, err := reconciliation.NewFormat(r.Format, "")
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	return reconciliation.Report{
+		ID:              r.ID,
+		Provider:        r.Provider,
+		SettlementDate:  r.SettlementDate,
+		FileName:        r.FileName,
+		Format:          format,
+		Records:         r.Records,
+		Matched:         r.Matched,
+		MissingInOurs:   r.MissingInOurs,
+		MissingInTheirs: r.MissingInTheirs,
+		AmountMismatch:  r.AmountMismatch,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+	}, nil
+}
+
+func CreateReconciliationReportFromDomain(r reconciliation.Report) ReconciliationReport {
+	return ReconciliationReport{
+		ID:              r.ID,
+		Provider:        r.Provider,
+		SettlementDate:  r.SettlementDate,
+		FileName:        r.FileName,
+		Format:          r.Format.String(),
+		Records:         r.Records,
+		Matched:         r.Matched,
+		MissingInOurs:   r.MissingInOurs,
+		MissingInTheirs: r.MissingInTheirs,
+		AmountMismatch:  r.AmountMismatch,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+	}
+}
+
+// ReconciliationItem represents the reconciliation_items table. An amount is NULL on the side
+// that has no record.
+type ReconciliationItem struct {
+	ID                uint   `gorm:"primarykey"`
+	ReportID          uint   `gorm:"not null;index:idx_reconciliation_items_report_status"`
+	Status            string `gorm:"size:20;not null;index:idx_reconciliation_items_report_status;check:status IN ('matched','missing_in_ours','missing_in_theirs','amount_mismatch')"`
+	ProviderReference string `gorm:"size:100;not null"`
+	TransactionID     *uint
+	ProviderAmount    *float64 `gorm:"type:decimal(18,2)"`
+	TransactionAmount *float64 `gorm:"type:decimal(18,2)"`
+}
+
+func (i ReconciliationItem) ToDomain() (reconciliation.Item, error) {
+	status, err := reconciliation.NewStatus(i.Status)
+	if err != nil {
+		return reconciliation.Item{}, err
+	}
+	providerAmount, err := vo.NewMoney(amountOrZero(i.ProviderAmount))
+	if err != nil {
+		return reconciliation.Item{}, err
+	}
+	transactionAmount, err := vo.NewMoney(amountOrZero(i.TransactionAmount))
+	if err != nil {
+		return reconciliation.Item{}, err
+	}
+	return reconciliation.Item{
+		ID:                i.ID,
+		ReportID:          i.ReportID,
+		Status:            status,
+		ProviderReference: i.ProviderReference,
+		TransactionID:     valueOrZero(i.TransactionID),
+		ProviderAmount:    providerAmount,
+		TransactionAmount: transactionAmount,
+	}, nil
+}
+
+func CreateReconciliationItemFromDomain(i reconciliation.Item) ReconciliationItem {
+	return ReconciliationItem{
+		ID:                i.ID,
+		ReportID:          i.ReportID,
+		Status:            i.Status.String(),
+		ProviderReference: i.ProviderReference,
+		TransactionID:     nilIfZero(i.TransactionID),
+		ProviderAmount:    nilIfZeroAmount(i.ProviderAmount),
+		TransactionAmount: nilIfZeroAmount(i.TransactionAmount),
+	}
+}
+
+// nilIfZeroAmount stores a missing amount as NULL; recorded amounts are always positive
+func nilIfZeroAmount(m vo.Money) *float64 {
+	if m.IsZero() {
+		return nil
+	}
+	amount := m.Amount()
+	return &amount
+}
+
+func amountOrZero(amount *float64) float64 {
+	if amount == nil {
+		return 0
+	}
+	return *amount
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
+)
+
+// Setting represents the settings table
+type Setting struct {
+	Key       string `gorm:"primarykey;size:100"`
+	Value     string `gorm:"type:text;not null"`
+	UpdatedBy string `gorm:"size:100;not null"`
+	UpdatedAt time.Time
+}
+
+func (s Setting) ToDomain() setting.Setting {
+	return setting.Setting{
+		Key:       setting.Key(s.Key),
+		Value:     s.Value,
+		UpdatedBy: s.UpdatedBy,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func CreateSettingFromDomain(s setting.Setting) Setting {
+	return Setting{
+		Key:       s.Key.String(),
+		Value:     s.Value,
+		UpdatedBy: s.UpdatedBy,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// SettingChange represents the setting_changes table
+type SettingChange struct {
+	ID        uint    `gorm:"primarykey"`
+	Key       string  `gorm:"size:100;not null;index"`
+	OldValue  *string `gorm:"type:text"`
+	NewValue  *string `gorm:"type:text"`
+	Actor     string  `gorm:"size:100;not null"`
+	Reason    string  `gorm:"size:500;not null;default:''"`
+	CreatedAt time.Time
+}
+
+func (c SettingChange) ToDomain() setting.Change {
+	return setting.Change{
+		ID:        c.ID,
+		Key:       setting.Key(c.Key),
+		OldValue:  c.OldValue,
+		NewValue:  c.NewValue,
+		Actor:     c.Actor,
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func CreateSettingChangeFromDomain(c setting.Change) SettingChange {
+	return SettingChange{
+		ID:        c.ID,
+		Key:       c.Key.String(),
+		OldValue:  c.OldValue,
+		NewValue:  c.NewValue,
+		Actor:     c.Actor,
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+	}
+}
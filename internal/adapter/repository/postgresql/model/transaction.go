@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
@@ -8,14 +9,26 @@ import (
 	"gorm.io/gorm"
 )
 
+// riskReasonSeparator joins risk reasons into a single text column
+const riskReasonSeparator = "; "
+
 // Transaction represents the transactions table
 type Transaction struct {
 	gorm.Model
-	UserID        uint      `gorm:"not null"`
-	Amount        float64   `gorm:"type:decimal(18,2);not null;check:amount > 0"`
-	PaymentMethod string    `gorm:"size:50;not null;check:payment_method IN ('credit_card')"`
-	Status        string    `gorm:"size:20;not null;check:status IN ('verified','completed','failed','expired')"`
-	ExpiresAt     time.Time `gorm:"not null"`
+	UserID            uint    `gorm:"not null"`
+	Amount            float64 `gorm:"type:decimal(18,2);not null;check:amount > 0"`
+	Fee               float64 `gorm:"type:decimal(18,2);not null;default:0;check:fee >= 0"`
+	Bonus             float64 `gorm:"type:decimal(18,2);not null;default:0;check:bonus >= 0"`
+	Campaign          string  `gorm:"size:100;not null;default:''"`
+	PaymentMethod     string  `gorm:"size:50;not null;check:payment_method IN ('credit_card','bank_transfer','voucher')"`
+	InstrumentID      *uint
+	ProviderReference *string    `gorm:"size:100;uniqueIndex"`
+	Status            string     `gorm:"size:20;not null;check:status IN ('verified','completed','failed','expired')"`
+	ExpiresAt         time.Time  `gorm:"not null"`
+	RiskScore         int        `gorm:"not null;default:0"`
+	RiskDecision      string     `gorm:"size:20;not null;default:'allow';check:risk_decision IN ('allow','challenge','reject')"`
+	RiskReasons       string     `gorm:"type:text"`
+	CompletedAt       *time.Time `gorm:"index"`
 }
 
 func (t Transaction) ToDomain() (*transaction.Transaction, error) {
@@ -31,22 +44,89 @@ func (t Transaction) ToDomain() (*transaction.Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
+	fee, err := vo.NewMoney(t.Fee)
+	if err != nil {
+		return nil, err
+	}
+	bonus, err := vo.NewMoney(t.Bonus)
+	if err != nil {
+		return nil, err
+	}
+	riskDecision, err := vo.NewRiskDecision(t.RiskDecision)
+	if err != nil {
+		return nil, err
+	}
+	var riskReasons []string
+	if t.RiskReasons != "" {
+		riskReasons = strings.Split(t.RiskReasons, riskReasonSeparator)
+	}
 	return &transaction.Transaction{
-		ID:            t.ID,
-		UserID:        t.UserID,
-		Amount:        amount,
-		PaymentMethod: paymentMethod,
-		Status:        status,
-		ExpiresAt:     t.ExpiresAt,
+		ID:                t.ID,
+		UserID:            t.UserID,
+		Amount:            amount,
+		Fee:               fee,
+		Bonus:             bonus,
+		Campaign:          t.Campaign,
+		PaymentMethod:     paymentMethod,
+		InstrumentID:      valueOrZero(t.InstrumentID),
+		ProviderReference: stringOrEmpty(t.ProviderReference),
+		Status:            status,
+		ExpiresAt:         t.ExpiresAt,
+		RiskScore:         t.RiskScore,
+		RiskDecision:      riskDecision,
+		RiskReasons:       riskReasons,
+		CompletedAt:       t.CompletedAt,
+		CreatedAt:         t.CreatedAt,
 	}, nil
 }
 func CreateTransactionFromDomain(t transaction.Transaction) Transaction {
 	return Transaction{
-		Model:         gorm.Model{ID: t.ID},
-		UserID:        t.UserID,
-		Amount:        t.Amount.Amount(),
-		PaymentMethod: t.PaymentMethod.String(),
-		Status:        t.Status.String(),
-		ExpiresAt:     t.ExpiresAt,
+		Model:             gorm.Model{ID: t.ID},
+		UserID:            t.UserID,
+		Amount:            t.Amount.Amount(),
+		Fee:               t.Fee.Amount(),
+		Bonus:             t.Bonus.Amount(),
+		Campaign:          t.Campaign,
+		PaymentMethod:     t.PaymentMethod.String(),
+		InstrumentID:      nilIfZero(t.InstrumentID),
+		ProviderReference: nilIfEmpty(t.ProviderReference),
+		Status:            t.Status.String(),
+		ExpiresAt:         t.ExpiresAt,
+		RiskScore:         t.RiskScore,
+		RiskDecision:      t.RiskDecision.String(),
+		RiskReasons:       strings.Join(t.RiskReasons, riskReasonSeparator),
+		CompletedAt:       t.CompletedAt,
+	}
+}
+
+// nilIfZero stores an unset optional ID as NULL
+func nilIfZero(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// valueOrZero reads an optional ID, with NULL as zero
+func valueOrZero(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+// nilIfEmpty stores an unset optional string as NULL
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// stringOrEmpty reads an optional string, with NULL as empty
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
 	}
+	return *s
 }
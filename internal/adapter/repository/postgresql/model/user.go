@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
 
 	"gorm.io/gorm"
 )
@@ -14,6 +15,8 @@ type User struct {
 	Email     string `gorm:"size:100;uniqueIndex;not null"`
 	Password  string `gorm:"size:255;not null"`
 	Phone     string `gorm:"size:20;not null"`
+	KYCStatus string `gorm:"column:kyc_status;size:20;not null;default:'none'"`
+	KYCTier   string `gorm:"column:kyc_tier;size:20;not null;default:'tier_0'"`
 }
 
 func CreateUserFromDomain(u user.User) User {
@@ -24,10 +27,20 @@ func CreateUserFromDomain(u user.User) User {
 		Email:     u.Email,
 		Password:  u.Password,
 		Phone:     u.Phone,
+		KYCStatus: u.KYCStatus.String(),
+		KYCTier:   u.KYCTier.String(),
 	}
 }
 
 func (u User) ToDomain() user.User {
+	kycStatus, err := vo.NewKYCStatus(u.KYCStatus)
+	if err != nil {
+		kycStatus = vo.KYCStatusNone
+	}
+	kycTier, err := vo.NewKYCTier(u.KYCTier)
+	if err != nil {
+		kycTier = vo.KYCTier0
+	}
 	return user.User{
 		ID:        u.ID,
 		FirstName: u.FirstName,
@@ -35,5 +48,8 @@ func (u User) ToDomain() user.User {
 		Email:     u.Email,
 		Password:  u.Password,
 		Phone:     u.Phone,
+		KYCStatus: kycStatus,
+		KYCTier:   kycTier,
+		CreatedAt: u.CreatedAt,
 	}
 }
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+)
+
+// Voucher represents the vouchers table
+type Voucher struct {
+	ID             uint    `gorm:"primarykey"`
+	Code           string  `gorm:"size:64;not null;uniqueIndex"`
+	Batch          string  `gorm:"size:100;not null;index"`
+	Amount         float64 `gorm:"type:decimal(18,2);not null;check:amount > 0"`
+	MaxRedemptions int     `gorm:"not null;default:1"`
+	PerUserLimit   int     `gorm:"not null;default:1"`
+	Redemptions    int     `gorm:"not null;default:0"`
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	CreatedBy      string `gorm:"size:100;not null"`
+	CreatedAt      time.Time
+}
+
+func (v Voucher) ToDomain() (voucher.Voucher, error) {
+	amount, err := vo.NewMoney(v.Amount)
+	if err != nil {
+		return voucher.Voucher{}, err
+	}
+	return voucher.Voucher{
+		ID:             v.ID,
+		Code:           v.Code,
+		Batch:          v.Batch,
+		Amount:         amount,
+		MaxRedemptions: v.MaxRedemptions,
+		PerUserLimit:   v.PerUserLimit,
+		Redemptions:    v.Redemptions,
+		ValidFrom:      v.ValidFrom,
+		ValidUntil:     v.ValidUntil,
+		CreatedBy:      v.CreatedBy,
+		CreatedAt:      v.CreatedAt,
+	}, nil
+}
+
+func CreateVoucherFromDomain(v voucher.Voucher) Voucher {
+	return Voucher{
+		ID:             v.ID,
+		Code:           v.Code,
+		Batch:          v.Batch,
+		Amount:         v.Amount.Amount(),
+		MaxRedemptions: v.MaxRedemptions,
+		PerUserLimit:   v.PerUserLimit,
+		Redemptions:    v.Redemptions,
+		ValidFrom:      v.ValidFrom,
+		ValidUntil:     v.ValidUntil,
+		CreatedBy:      v.CreatedBy,
+		CreatedAt:      v.CreatedAt,
+	}
+}
+
+// VoucherRedemption represents the voucher_redemptions table
+type VoucherRedemption struct {
+	ID            uint `gorm:"primarykey"`
+	VoucherID     uint `gorm:"not null;index:idx_voucher_redemptions_voucher_user"`
+	UserID        uint `gorm:"not null;index:idx_voucher_redemptions_voucher_user"`
+	TransactionID uint `gorm:"not null;uniqueIndex"`
+	CreatedAt     time.Time
+}
+
+func CreateVoucherRedemptionFromDomain(r voucher.Redemption) VoucherRedemption {
+	return VoucherRedemption{
+		ID:            r.ID,
+		VoucherID:     r.VoucherID,
+		UserID:        r.UserID,
+		TransactionID: r.TransactionID,
+		CreatedAt:     r.CreatedAt,
+	}
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
 	"gorm.io/gorm"
@@ -29,3 +31,45 @@ func (w Wallet) ToDomain() (wallet.Wallet, error) {
 		Balance: money,
 	}, nil
 }
+
+// BalanceAdjustment represents the balance_adjustments table
+type BalanceAdjustment struct {
+	ID        uint    `gorm:"primarykey"`
+	WalletID  uint    `gorm:"not null;index"`
+	Amount    float64 `gorm:"type:decimal(18,2);not null;check:amount <> 0"`
+	Reason    string  `gorm:"size:500;not null"`
+	Actor     string  `gorm:"size:100;not null"`
+	CreatedAt time.Time
+}
+
+func CreateBalanceAdjustmentFromDomain(a wallet.Adjustment) BalanceAdjustment {
+	return BalanceAdjustment{
+		ID:        a.ID,
+		WalletID:  a.WalletID,
+		Amount:    a.Amount,
+		Reason:    a.Reason,
+		Actor:     a.Actor,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// WalletPosting represents the wallet_postings table
+type WalletPosting struct {
+	ID            uint    `gorm:"primarykey"`
+	WalletID      uint    `gorm:"not null;index"`
+	TransactionID uint    `gorm:"not null;index"`
+	Type          string  `gorm:"size:20;not null;check:type IN ('topup','fee','bonus')"`
+	Amount        float64 `gorm:"type:decimal(18,2);not null;check:amount <> 0"`
+	CreatedAt     time.Time
+}
+
+func CreateWalletPostingFromDomain(p wallet.Posting) WalletPosting {
+	return WalletPosting{
+		ID:            p.ID,
+		WalletID:      p.WalletID,
+		TransactionID: p.TransactionID,
+		Type:          p.Type.String(),
+		Amount:        p.Amount,
+		CreatedAt:     p.CreatedAt,
+	}
+}
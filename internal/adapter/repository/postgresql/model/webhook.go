@@ -0,0 +1,141 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription represents the webhook_subscriptions table
+type WebhookSubscription struct {
+	gorm.Model
+	URL                 string `gorm:"size:500;not null"`
+	Secret              string `gorm:"size:100;not null"`
+	EventTypes          string `gorm:"size:500;not null"` // comma-separated event types
+	Active              bool   `gorm:"not null;default:true"`
+	ConsecutiveFailures int    `gorm:"not null;default:0"`
+	DisabledAt          *time.Time
+}
+
+func (s WebhookSubscription) ToDomain() webhook.Subscription {
+	var eventTypes []event.Type
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t != "" {
+			eventTypes = append(eventTypes, event.Type(t))
+		}
+	}
+	return webhook.Subscription{
+		ID:                  s.ID,
+		URL:                 s.URL,
+		Secret:              s.Secret,
+		EventTypes:          eventTypes,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		CreatedAt:           s.CreatedAt,
+	}
+}
+
+func CreateWebhookSubscriptionFromDomain(s webhook.Subscription) WebhookSubscription {
+	eventTypes := make([]string, len(s.EventTypes))
+	for i, t := range s.EventTypes {
+		eventTypes[i] = t.String()
+	}
+	return WebhookSubscription{
+		Model:               gorm.Model{ID: s.ID},
+		URL:                 s.URL,
+		Secret:              s.Secret,
+		EventTypes:          strings.Join(eventTypes, ","),
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+	}
+}
+
+// WebhookDelivery represents the webhook_deliveries table
+type WebhookDelivery struct {
+	ID               uint   `gorm:"primarykey"`
+	SubscriptionID   uint   `gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:1"`
+	EventID          uint   `gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:2"`
+	EventType        string `gorm:"size:100;not null"`
+	Payload          []byte `gorm:"type:jsonb;not null"`
+	Status           string `gorm:"size:20;not null;default:'pending';index;check:status IN ('pending','succeeded','failed')"`
+	Attempts         int    `gorm:"not null;default:0"`
+	LastResponseCode int
+	LastError        string    `gorm:"type:text"`
+	NextAttemptAt    time.Time `gorm:"not null;index"`
+	DeliveredAt      *time.Time
+	CreatedAt        time.Time
+}
+
+func (d WebhookDelivery) ToDomain() webhook.Delivery {
+	return webhook.Delivery{
+		ID:               d.ID,
+		SubscriptionID:   d.SubscriptionID,
+		EventID:          d.EventID,
+		EventType:        event.Type(d.EventType),
+		Payload:          d.Payload,
+		Status:           webhook.DeliveryStatus(d.Status),
+		Attempts:         d.Attempts,
+		LastResponseCode: d.LastResponseCode,
+		LastError:        d.LastError,
+		NextAttemptAt:    d.NextAttemptAt,
+		DeliveredAt:      d.DeliveredAt,
+		CreatedAt:        d.CreatedAt,
+	}
+}
+
+func CreateWebhookDeliveryFromDomain(d webhook.Delivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:               d.ID,
+		SubscriptionID:   d.SubscriptionID,
+		EventID:          d.EventID,
+		EventType:        d.EventType.String(),
+		Payload:          d.Payload,
+		Status:           d.Status.String(),
+		Attempts:         d.Attempts,
+		LastResponseCode: d.LastResponseCode,
+		LastError:        d.LastError,
+		NextAttemptAt:    d.NextAttemptAt,
+		DeliveredAt:      d.DeliveredAt,
+		CreatedAt:        d.CreatedAt,
+	}
+}
+
+// WebhookDeliveryAttempt represents the webhook_delivery_attempts table
+type WebhookDeliveryAttempt struct {
+	ID           uint `gorm:"primarykey"`
+	DeliveryID   uint `gorm:"not null;index"`
+	Attempt      int  `gorm:"not null"`
+	ResponseCode int
+	Error        string `gorm:"type:text"`
+	DurationMs   int64  `gorm:"not null"`
+	CreatedAt    time.Time
+}
+
+func (a WebhookDeliveryAttempt) ToDomain() webhook.Attempt {
+	return webhook.Attempt{
+		ID:           a.ID,
+		DeliveryID:   a.DeliveryID,
+		Attempt:      a.Attempt,
+		ResponseCode: a.ResponseCode,
+		Error:        a.Error,
+		Duration:     time.Duration(a.DurationMs) * time.Millisecond,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func CreateWebhookDeliveryAttemptFromDomain(a webhook.Attempt) WebhookDeliveryAttempt {
+	return WebhookDeliveryAttempt{
+		ID:           a.ID,
+		DeliveryID:   a.DeliveryID,
+		Attempt:      a.Attempt,
+		ResponseCode: a.ResponseCode,
+		Error:        a.Error,
+		DurationMs:   a.Duration.Milliseconds(),
+		CreatedAt:    a.CreatedAt,
+	}
+}
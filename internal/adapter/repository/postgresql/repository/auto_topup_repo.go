@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AutoTopupRepository struct {
+	db *gorm.DB
+}
+
+func NewAutoTopupRepository(db *gorm.DB) *AutoTopupRepository {
+	return &AutoTopupRepository{db: db}
+}
+
+func (r *AutoTopupRepository) Create(ctx context.Context, rule autotopup.Rule) (uint, error) {
+	ruleModel := model.CreateAutoTopupRuleFromDomain(rule)
+	if err := r.getDB(ctx).Create(&ruleModel).Error; err != nil {
+		return 0, err
+	}
+	return ruleModel.ID, nil
+}
+
+func (r *AutoTopupRepository) Update(ctx context.Context, rule autotopup.Rule) error {
+	ruleModel := model.CreateAutoTopupRuleFromDomain(rule)
+	result := r.getDB(ctx).Model(&model.AutoTopupRule{}).Where("id = ?", rule.ID).
+		Select("enabled", "next_run_at", "attempts", "run_key", "run_transaction_id", "last_run_at", "last_error", "updated_at").
+		Updates(&ruleModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AutoTopupRepository) Delete(ctx context.Context, id, userID uint) error {
+	result := r.getDB(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.AutoTopupRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AutoTopupRepository) FindAll(ctx context.Context, filter *autotopup.RuleFilter) ([]autotopup.Rule, error) {
+	var ruleModels []model.AutoTopupRule
+	query := r.getDB(ctx).Model(&model.AutoTopupRule{})
+	if filter != nil && filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if err := query.Order("id").Find(&ruleModels).Error; err != nil {
+		return nil, err
+	}
+	return toAutoTopupRules(ruleModels)
+}
+
+func (r *AutoTopupRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]autotopup.Rule, error) {
+	var ruleModels []model.AutoTopupRule
+	err := r.getDB(ctx).
+		Where("enabled AND next_run_at <= ?", now).
+		Order("next_run_at").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&ruleModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toAutoTopupRules(ruleModels)
+}
+
+func (r *AutoTopupRepository) MarkThresholdDue(ctx context.Context, userID uint, balance float64, now, cooldownSince time.Time) (int64, error) {
+	result := r.getDB(ctx).Model(&model.AutoTopupRule{}).
+		Where("user_id = ? AND trigger = ? AND enabled AND next_run_at IS NULL AND threshold > ?",
+			userID, autotopup.TriggerThreshold.String(), balance).
+		Where("last_run_at IS NULL OR last_run_at < ?", cooldownSince).
+		Updates(map[string]interface{}{"next_run_at": now, "updated_at": now})
+	return result.RowsAffected, result.Error
+}
+
+func toAutoTopupRules(ruleModels []model.AutoTopupRule) ([]autotopup.Rule, error) {
+	rules := make([]autotopup.Rule, len(ruleModels))
+	for i, m := range ruleModels {
+		rule, err := m.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+func (r *AutoTopupRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"gorm.io/gorm"
+)
+
+type KYCRepository struct {
+	db *gorm.DB
+}
+
+func NewKYCRepository(db *gorm.DB) *KYCRepository {
+	return &KYCRepository{db: db}
+}
+func getQueryFromDocumentFilter(tx *gorm.DB, filter *kyc.DocumentFilter) *gorm.DB {
+	if filter == nil {
+		return tx
+	}
+	if filter.ID != nil {
+		tx = tx.Where("id = ?", *filter.ID)
+	}
+	if filter.UserID != nil {
+		tx = tx.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Status != nil {
+		tx = tx.Where("status = ?", filter.Status.String())
+	}
+	return tx
+}
+
+func (r *KYCRepository) FindAll(filter *kyc.DocumentFilter) ([]kyc.Document, error) {
+	var documentModels []model.KYCDocument
+	query := r.db.Model(&model.KYCDocument{})
+	query = getQueryFromDocumentFilter(query, filter)
+	if err := query.Order("id DESC").Find(&documentModels).Error; err != nil {
+		return nil, err
+	}
+	documents := make([]kyc.Document, len(documentModels))
+	for i, dm := range documentModels {
+		d, err := dm.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		documents[i] = *d
+	}
+	return documents, nil
+}
+
+func (r *KYCRepository) FindById(id uint) (*kyc.Document, error) {
+	var documentModel model.KYCDocument
+	if err := r.db.First(&documentModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return documentModel.ToDomain()
+}
+
+func (r *KYCRepository) Create(ctx context.Context, document kyc.Document) (uint, error) {
+	db := r.getDB(ctx)
+	documentModel := model.CreateKYCDocumentFromDomain(document)
+	if err := db.Create(&documentModel).Error; err != nil {
+		return 0, err
+	}
+	return documentModel.ID, nil
+}
+
+func (r *KYCRepository) Update(ctx context.Context, filter *kyc.DocumentFilter, document kyc.Document) error {
+	db := r.getDB(ctx)
+	query := db.Model(&model.KYCDocument{})
+	query = getQueryFromDocumentFilter(query, filter)
+	if result := query.Updates(document.ToNotEmptyValueMap()); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *KYCRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) Create(ctx context.Context, message outbox.Message) (uint, error) {
+	db := r.getDB(ctx)
+	messageModel := model.CreateOutboxMessageFromDomain(message)
+	if err := db.Create(&messageModel).Error; err != nil {
+		return 0, err
+	}
+	return messageModel.ID, nil
+}
+
+func (r *OutboxRepository) FetchPending(ctx context.Context, limit int, now time.Time) ([]outbox.Message, error) {
+	var messageModels []model.OutboxMessage
+	// Only the oldest pending message of each aggregate is eligible, so a message that is
+	// backing off holds back later events for the same aggregate and ordering is kept.
+	err := r.getDB(ctx).
+		Where("status = ? AND next_attempt_at <= ?", outbox.StatusPending.String(), now).
+		Where(`NOT EXISTS (SELECT 1 FROM outbox_messages earlier
+			WHERE earlier.aggregate_type = outbox_messages.aggregate_type
+			AND earlier.aggregate_id = outbox_messages.aggregate_id
+			AND earlier.status = ? AND earlier.id < outbox_messages.id)`, outbox.StatusPending.String()).
+		Order("id").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&messageModels).Error
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]outbox.Message, len(messageModels))
+	for i, m := range messageModels {
+		messages[i] = m.ToDomain()
+	}
+	return messages, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id uint, publishedAt time.Time) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":       outbox.StatusPublished.String(),
+		"published_at": publishedAt,
+		"last_error":   "",
+	})
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	})
+}
+
+func (r *OutboxRepository) MarkDead(ctx context.Context, id uint, attempts int, lastError string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":     outbox.StatusDead.String(),
+		"attempts":   attempts,
+		"last_error": lastError,
+	})
+}
+
+func (r *OutboxRepository) Requeue(ctx context.Context, id uint) error {
+	db := r.getDB(ctx)
+	result := db.Model(&model.OutboxMessage{}).
+		Where("id = ? AND status = ?", id, outbox.StatusDead.String()).
+		Updates(map[string]interface{}{
+			"status":          outbox.StatusPending.String(),
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OutboxRepository) FindAll(filter *outbox.MessageFilter) ([]outbox.Message, error) {
+	var messageModels []model.OutboxMessage
+	query := r.db.Model(&model.OutboxMessage{})
+	if filter != nil {
+		if filter.ID != nil {
+			query = query.Where("id = ?", *filter.ID)
+		}
+		if filter.Status != nil {
+			query = query.Where("status = ?", filter.Status.String())
+		}
+	}
+	if err := query.Order("id").Find(&messageModels).Error; err != nil {
+		return nil, err
+	}
+	messages := make([]outbox.Message, len(messageModels))
+	for i, m := range messageModels {
+		messages[i] = m.ToDomain()
+	}
+	return messages, nil
+}
+
+func (r *OutboxRepository) update(ctx context.Context, id uint, values map[string]interface{}) error {
+	db := r.getDB(ctx)
+	if result := db.Model(&model.OutboxMessage{}).Where("id = ?", id).Updates(values); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OutboxRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
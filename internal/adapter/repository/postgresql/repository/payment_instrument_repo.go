@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"gorm.io/gorm"
+)
+
+// instrumentSecret is the encrypted part of a payment instrument
+type instrumentSecret struct {
+	Token       string                  `json:"token"`
+	Card        *instrument.Card        `json:"card,omitempty"`
+	BankAccount *instrument.BankAccount `json:"bank_account,omitempty"`
+}
+
+// PaymentInstrumentRepository stores instruments with their secrets encrypted by cipher
+type PaymentInstrumentRepository struct {
+	db     *gorm.DB
+	cipher instrument.Cipher
+}
+
+func NewPaymentInstrumentRepository(db *gorm.DB, cipher instrument.Cipher) *PaymentInstrumentRepository {
+	return &PaymentInstrumentRepository{db: db, cipher: cipher}
+}
+
+func (r *PaymentInstrumentRepository) Create(ctx context.Context, i instrument.Instrument) (uint, error) {
+	plaintext, err := json.Marshal(instrumentSecret{Token: i.Token, Card: i.Card, BankAccount: i.BankAccount})
+	if err != nil {
+		return 0, err
+	}
+	encrypted, err := r.cipher.Encrypt(plaintext, instrumentAdditionalData(i.UserID))
+	if err != nil {
+		return 0, fmt.Errorf("encrypt payment instrument: %w", err)
+	}
+	instrumentModel := model.PaymentInstrument{
+		UserID:        i.UserID,
+		Type:          i.Type.String(),
+		EncryptedData: encrypted,
+		CreatedAt:     i.CreatedAt,
+	}
+	if err := r.getDB(ctx).Create(&instrumentModel).Error; err != nil {
+		return 0, err
+	}
+	return instrumentModel.ID, nil
+}
+
+func (r *PaymentInstrumentRepository) FindByID(ctx context.Context, id uint) (*instrument.Instrument, error) {
+	var instrumentModel model.PaymentInstrument
+	if err := r.getDB(ctx).First(&instrumentModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	i, err := r.toDomain(instrumentModel)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (r *PaymentInstrumentRepository) FindByUser(ctx context.Context, userID uint) ([]instrument.Instrument, error) {
+	var instrumentModels []model.PaymentInstrument
+	if err := r.getDB(ctx).Where("user_id = ?", userID).Order("id").Find(&instrumentModels).Error; err != nil {
+		return nil, err
+	}
+	instruments := make([]instrument.Instrument, len(instrumentModels))
+	for i, m := range instrumentModels {
+		var err error
+		if instruments[i], err = r.toDomain(m); err != nil {
+			return nil, err
+		}
+	}
+	return instruments, nil
+}
+
+func (r *PaymentInstrumentRepository) Delete(ctx context.Context, id, userID uint) error {
+	result := r.getDB(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.PaymentInstrument{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PaymentInstrumentRepository) toDomain(m model.PaymentInstrument) (instrument.Instrument, error) {
+	instrumentType, err := instrument.NewType(m.Type)
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	plaintext, err := r.cipher.Decrypt(m.EncryptedData, instrumentAdditionalData(m.UserID))
+	if err != nil {
+		return instrument.Instrument{}, fmt.Errorf("decrypt payment instrument %d: %w", m.ID, err)
+	}
+	var secret instrumentSecret
+	if err := json.Unmarshal(plaintext, &secret); err != nil {
+		return instrument.Instrument{}, fmt.Errorf("decode payment instrument %d: %w", m.ID, err)
+	}
+	return instrument.Instrument{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Type:        instrumentType,
+		Token:       secret.Token,
+		Card:        secret.Card,
+		BankAccount: secret.BankAccount,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
+
+// instrumentAdditionalData binds a ciphertext to its owner, so it cannot be copied to another user's row
+func instrumentAdditionalData(userID uint) []byte {
+	return []byte(fmt.Sprintf("payment_instrument:user:%d", userID))
+}
+
+func (r *PaymentInstrumentRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInstrumentRepo(t *testing.T) *PaymentInstrumentRepository {
+	t.Helper()
+	cipher, err := infrastructure.NewAESGCMCipher(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	require.NoError(t, err)
+	return NewPaymentInstrumentRepository(nil, cipher)
+}
+
+func encryptedInstrument(t *testing.T, r *PaymentInstrumentRepository, userID uint, secret instrumentSecret) model.PaymentInstrument {
+	t.Helper()
+	plaintext, err := json.Marshal(secret)
+	require.NoError(t, err)
+	encrypted, err := r.cipher.Encrypt(plaintext, instrumentAdditionalData(userID))
+	require.NoError(t, err)
+	return model.PaymentInstrument{ID: 3, UserID: userID, Type: "card", EncryptedData: encrypted}
+}
+
+func TestInstrumentAdditionalDataIsPerUser(t *testing.T) {
+	assert.Equal(t, []byte("payment_instrument:user:1"), instrumentAdditionalData(1))
+	assert.NotEqual(t, instrumentAdditionalData(1), instrumentAdditionalData(11))
+}
+
+func TestPaymentInstrumentDecryptsForItsOwner(t *testing.T) {
+	r := newTestInstrumentRepo(t)
+	card := &instrument.Card{Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030}
+	m := encryptedInstrument(t, r, 1, instrumentSecret{Token: "tok_123", Card: card})
+	assert.NotContains(t, string(m.EncryptedData), "tok_123")
+
+	i, err := r.toDomain(m)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), i.UserID)
+	assert.Equal(t, "tok_123", i.Token)
+	assert.Equal(t, card, i.Card)
+}
+
+func TestPaymentInstrumentCopiedToAnotherUserDoesNotDecrypt(t *testing.T) {
+	r := newTestInstrumentRepo(t)
+	m := encryptedInstrument(t, r, 1, instrumentSecret{Token: "tok_123"})
+
+	m.UserID = 2
+	_, err := r.toDomain(m)
+	assert.Error(t, err)
+}
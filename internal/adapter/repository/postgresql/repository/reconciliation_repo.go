@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"gorm.io/gorm"
+)
+
+// reconciliationItemInsertBatchSize keeps bulk inserts under the Postgres bind parameter limit
+const reconciliationItemInsertBatchSize = 1000
+
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+func (r *ReconciliationRepository) Create(ctx context.Context, report reconciliation.Report) (uint, error) {
+	reportModel := model.CreateReconciliationReportFromDomain(report)
+	if err := r.getDB(ctx).Create(&reportModel).Error; err != nil {
+		return 0, err
+	}
+	return reportModel.ID, nil
+}
+
+func (r *ReconciliationRepository) CreateItems(ctx context.Context, items []reconciliation.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	itemModels := make([]model.ReconciliationItem, len(items))
+	for i, item := range items {
+		itemModels[i] = model.CreateReconciliationItemFromDomain(item)
+	}
+	if err := r.getDB(ctx).CreateInBatches(&itemModels, reconciliationItemInsertBatchSize).Error; err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].ID = itemModels[i].ID
+	}
+	return nil
+}
+
+func (r *ReconciliationRepository) FindByID(ctx context.Context, id uint) (*reconciliation.Report, error) {
+	var reportModel model.ReconciliationReport
+	if err := r.getDB(ctx).First(&reportModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	report, err := reportModel.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ReconciliationRepository) FindAll(ctx context.Context, filter *reconciliation.ReportFilter) ([]reconciliation.Report, error) {
+	var reportModels []model.ReconciliationReport
+	query := r.getDB(ctx).Model(&model.ReconciliationReport{})
+	if filter != nil {
+		if filter.Provider != nil {
+			query = query.Where("provider = ?", *filter.Provider)
+		}
+		if filter.SettlementDate != nil {
+			query = query.Where("settlement_date = ?", filter.SettlementDate.Format("2006-01-02"))
+		}
+	}
+	if err := query.Order("id DESC").Find(&reportModels).Error; err != nil {
+		return nil, err
+	}
+	reports := make([]reconciliation.Report, len(reportModels))
+	for i, m := range reportModels {
+		report, err := m.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		reports[i] = report
+	}
+	return reports, nil
+}
+
+func (r *ReconciliationRepository) FindItems(ctx context.Context, reportID uint, status *reconciliation.Status) ([]reconciliation.Item, error) {
+	var itemModels []model.ReconciliationItem
+	query := r.getDB(ctx).Where("report_id = ?", reportID)
+	if status != nil {
+		query = query.Where("status = ?", status.String())
+	}
+	if err := query.Order("id").Find(&itemModels).Error; err != nil {
+		return nil, err
+	}
+	items := make([]reconciliation.Item, len(itemModels))
+	for i, m := range itemModels {
+		item, err := m.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func (r *ReconciliationRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
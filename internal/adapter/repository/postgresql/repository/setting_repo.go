@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingRepository(db *gorm.DB) *SettingRepository {
+	return &SettingRepository{db: db}
+}
+
+func (r *SettingRepository) FindAll(ctx context.Context) ([]setting.Setting, error) {
+	var settingModels []model.Setting
+	if err := r.getDB(ctx).Order("key").Find(&settingModels).Error; err != nil {
+		return nil, err
+	}
+	settings := make([]setting.Setting, len(settingModels))
+	for i, s := range settingModels {
+		settings[i] = s.ToDomain()
+	}
+	return settings, nil
+}
+
+func (r *SettingRepository) FindByKey(ctx context.Context, key setting.Key) (*setting.Setting, error) {
+	var settingModel model.Setting
+	// Lock the row when called inside a transaction so concurrent updates record a correct audit trail
+	query := r.getDB(ctx)
+	if IRepository.GetTx(ctx) != nil {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.Where("key = ?", key.String()).First(&settingModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	s := settingModel.ToDomain()
+	return &s, nil
+}
+
+func (r *SettingRepository) Save(ctx context.Context, s setting.Setting) error {
+	settingModel := model.CreateSettingFromDomain(s)
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+	}).Create(&settingModel).Error
+}
+
+func (r *SettingRepository) Delete(ctx context.Context, key setting.Key) error {
+	if result := r.getDB(ctx).Where("key = ?", key.String()).Delete(&model.Setting{}); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *SettingRepository) CreateChange(ctx context.Context, change setting.Change) (uint, error) {
+	changeModel := model.CreateSettingChangeFromDomain(change)
+	if err := r.getDB(ctx).Create(&changeModel).Error; err != nil {
+		return 0, err
+	}
+	return changeModel.ID, nil
+}
+
+func (r *SettingRepository) FindChanges(ctx context.Context, filter *setting.ChangeFilter) ([]setting.Change, error) {
+	var changeModels []model.SettingChange
+	query := r.getDB(ctx).Model(&model.SettingChange{})
+	if filter != nil {
+		if filter.Key != nil {
+			query = query.Where("key = ?", filter.Key.String())
+		}
+		if filter.Limit > 0 {
+			query = query.Limit(filter.Limit)
+		}
+	}
+	if err := query.Order("id DESC").Find(&changeModels).Error; err != nil {
+		return nil, err
+	}
+	changes := make([]setting.Change, len(changeModels))
+	for i, c := range changeModels {
+		changes[i] = c.ToDomain()
+	}
+	return changes, nil
+}
+
+func (r *SettingRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
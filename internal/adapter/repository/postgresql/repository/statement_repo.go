@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+// StatementRepository reads the wallet ledger the same way reconciliation does: completed
+// top-ups, net of fees and bonuses, plus balance adjustments. Top-ups are dated by when they
+// were credited, so a statement agrees with the balance at its period boundaries.
+type StatementRepository struct {
+	db *gorm.DB
+}
+
+func NewStatementRepository(db *gorm.DB) *StatementRepository {
+	return &StatementRepository{db: db}
+}
+
+// statementRow is one ledger entry as selected by StreamEntries
+type statementRow struct {
+	Type        string
+	ReferenceID uint
+	Description string
+	Amount      float64
+	Fee         float64
+	Bonus       float64
+	Net         float64
+	PostedAt    time.Time
+}
+
+func (r *StatementRepository) OpeningBalance(ctx context.Context, walletID uint, before time.Time) (float64, error) {
+	var balance float64
+	err := r.getDB(ctx).Raw(`SELECT
+		COALESCE((SELECT SUM(amount - fee + bonus) FROM transactions
+			WHERE user_id = ? AND status = ? AND deleted_at IS NULL AND completed_at < ?), 0) +
+		COALESCE((SELECT SUM(amount) FROM balance_adjustments WHERE wallet_id = ? AND created_at < ?), 0)`,
+		walletID, vo.StatusCompleted.String(), before, walletID, before).
+		Scan(&balance).Error
+	return balance, err
+}
+
+func (r *StatementRepository) StreamEntries(ctx context.Context, walletID uint, from, until time.Time, fn func(statement.Entry) error) error {
+	db := r.getDB(ctx)
+	rows, err := db.Raw(`SELECT CAST(? AS TEXT) AS type, id AS reference_id, payment_method AS description,
+			amount, fee, bonus, amount - fee + bonus AS net, completed_at AS posted_at
+		FROM transactions
+		WHERE user_id = ? AND status = ? AND deleted_at IS NULL AND completed_at >= ? AND completed_at < ?
+		UNION ALL
+		SELECT CAST(? AS TEXT), id, reason, amount, 0, 0, amount, created_at
+		FROM balance_adjustments
+		WHERE wallet_id = ? AND created_at >= ? AND created_at < ?
+		ORDER BY posted_at, type, reference_id`,
+		statement.EntryTopup.String(), walletID, vo.StatusCompleted.String(), from, until,
+		statement.EntryAdjustment.String(), walletID, from, until).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row statementRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		err := fn(statement.Entry{
+			Type:        statement.EntryType(row.Type),
+			ReferenceID: row.ReferenceID,
+			Description: row.Description,
+			Amount:      row.Amount,
+			Fee:         row.Fee,
+			Bonus:       row.Bonus,
+			Net:         row.Net,
+			PostedAt:    row.PostedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *StatementRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
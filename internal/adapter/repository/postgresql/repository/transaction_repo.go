@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
 	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
 	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TransactionRepository struct {
@@ -25,18 +28,56 @@ func getQueryFromTrancsactionFilter(tx *gorm.DB, filter *transaction.Transaction
 	if filter.ID != nil {
 		tx = tx.Where("id = ?", filter.ID)
 	}
+	if filter.UserID != nil {
+		tx = tx.Where("user_id = ?", *filter.UserID)
+	}
 	if filter.PaymentMethod != nil {
 		tx = tx.Where("payment_method = ?", filter.PaymentMethod.String())
 	}
+	if len(filter.PaymentMethodNotIn) > 0 {
+		methods := make([]string, len(filter.PaymentMethodNotIn))
+		for i, m := range filter.PaymentMethodNotIn {
+			methods[i] = m.String()
+		}
+		tx = tx.Where("payment_method NOT IN ?", methods)
+	}
 	if filter.Status != nil {
 		tx = tx.Where("status = ?", filter.Status.String())
 	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = s.String()
+		}
+		tx = tx.Where("status IN ?", statuses)
+	}
 	if filter.Amount != nil {
 		tx = tx.Where("amount = ?", filter.Amount.Amount())
 	}
 	if filter.ExpiredAt != nil {
 		tx = tx.Where("expires_at <= ?", *filter.ExpiredAt)
 	}
+	if filter.CreatedAfter != nil {
+		tx = tx.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		tx = tx.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.CompletedAfter != nil {
+		tx = tx.Where("completed_at >= ?", *filter.CompletedAfter)
+	}
+	if filter.CompletedBefore != nil {
+		tx = tx.Where("completed_at < ?", *filter.CompletedBefore)
+	}
+	if filter.RiskDecision != nil {
+		tx = tx.Where("risk_decision = ?", filter.RiskDecision.String())
+	}
+	if len(filter.ProviderReferences) > 0 {
+		tx = tx.Where("provider_reference IN ?", filter.ProviderReferences)
+	}
+	if filter.HasProviderReference {
+		tx = tx.Where("provider_reference IS NOT NULL")
+	}
 	return tx
 }
 
@@ -59,9 +100,9 @@ func (r *TransactionRepository) FindAll(filter *transaction.TransactionFilter) (
 	return transactions, nil
 }
 
-func (r *TransactionRepository) FindById(id uint) (*transaction.Transaction, error) {
+func (r *TransactionRepository) FindById(ctx context.Context, id uint) (*transaction.Transaction, error) {
 	var transactionModel model.Transaction
-	if err := r.db.First(&transactionModel, id).Error; err != nil {
+	if err := r.getDB(ctx).First(&transactionModel, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errs.ErrNotFound
 		}
@@ -93,6 +134,37 @@ func (r *TransactionRepository) Update(ctx context.Context, filter *transaction.
 	}
 	return nil
 }
+func (r *TransactionRepository) Count(ctx context.Context, filter *transaction.TransactionFilter) (int64, error) {
+	var count int64
+	query := r.getDB(ctx).Model(&model.Transaction{})
+	query = getQueryFromTrancsactionFilter(query, filter)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+func (r *TransactionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]transaction.Transaction, error) {
+	var transactionModels []model.Transaction
+	err := r.getDB(ctx).
+		Where("status = ? AND expires_at <= ?", vo.StatusVerified.String(), now).
+		Order("expires_at").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&transactionModels).Error
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]transaction.Transaction, len(transactionModels))
+	for i, tm := range transactionModels {
+		t, err := tm.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = *t
+	}
+	return transactions, nil
+}
+
 func (r *TransactionRepository) getDB(ctx context.Context) *gorm.DB {
 	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
 		return tx
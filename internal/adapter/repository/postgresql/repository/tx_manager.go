@@ -17,7 +17,7 @@ func NewTxManagerGorm(db *gorm.DB) IRepository.TxManager {
 }
 
 func (tm *txManagerGorm) BeginTx(ctx context.Context) (context.Context, error) {
-	tx := tm.db.Begin()
+	tx := tm.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"context"
 	"errors"
 
 	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
 	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
 	"gorm.io/gorm"
 )
 
@@ -32,6 +35,9 @@ func getQueryFromUserFilter(tx *gorm.DB, filter *user.UserFilter) *gorm.DB {
 	if filter.Phone != nil {
 		tx = tx.Where("phone = ?", *filter.Phone)
 	}
+	if filter.KYCStatus != nil {
+		tx = tx.Where("kyc_status = ?", filter.KYCStatus.String())
+	}
 	return tx
 }
 
@@ -49,9 +55,9 @@ func (r *UserRepository) FindAll(userFilter *user.UserFilter) ([]user.User, erro
 	return users, nil
 }
 
-func (r *UserRepository) FindById(id uint) (user.User, error) {
+func (r *UserRepository) FindById(ctx context.Context, id uint) (user.User, error) {
 	var userModel model.User
-	if err := r.db.First(&userModel, id).Error; err != nil {
+	if err := r.getDB(ctx).First(&userModel, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return user.User{}, errs.ErrNotFound
 		}
@@ -65,6 +71,35 @@ func (r *UserRepository) Create(user user.User) error {
 	userModel := model.CreateUserFromDomain(user)
 	return r.db.Create(&userModel).Error
 }
-func (r *UserRepository) Update(user user.User) error {
-	return r.db.Model(&model.User{}).Where("id = ?", user.ID).Updates(user.ToNotEmptyValueMap()).Error
+func (r *UserRepository) Update(ctx context.Context, user user.User) error {
+	db := r.getDB(ctx)
+	if result := db.Model(&model.User{}).Where("id = ?", user.ID).Updates(user.ToNotEmptyValueMap()); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// MarkKYCPending checks and sets the status in one statement, so of two concurrent submissions
+// only one can move the user into pending review
+func (r *UserRepository) MarkKYCPending(ctx context.Context, id uint) error {
+	pending := vo.KYCStatusPending.String()
+	result := r.getDB(ctx).Model(&model.User{}).
+		Where("id = ? AND kyc_status <> ?", id, pending).
+		Update("kyc_status", pending)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrKYCAlreadyPending
+	}
+	return nil
+}
+
+func (r *UserRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
 }
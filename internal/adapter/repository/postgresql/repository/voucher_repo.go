@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// voucherInsertBatchSize keeps bulk inserts under the Postgres bind parameter limit
+const voucherInsertBatchSize = 500
+
+type VoucherRepository struct {
+	db *gorm.DB
+}
+
+func NewVoucherRepository(db *gorm.DB) *VoucherRepository {
+	return &VoucherRepository{db: db}
+}
+
+func (r *VoucherRepository) CreateMany(ctx context.Context, vouchers []voucher.Voucher) error {
+	voucherModels := make([]model.Voucher, len(vouchers))
+	for i, v := range vouchers {
+		voucherModels[i] = model.CreateVoucherFromDomain(v)
+	}
+	if err := r.getDB(ctx).CreateInBatches(&voucherModels, voucherInsertBatchSize).Error; err != nil {
+		return err
+	}
+	for i := range vouchers {
+		vouchers[i].ID = voucherModels[i].ID
+	}
+	return nil
+}
+
+func (r *VoucherRepository) FindByCode(ctx context.Context, code string) (*voucher.Voucher, error) {
+	var voucherModel model.Voucher
+	query := r.getDB(ctx)
+	if IRepository.GetTx(ctx) != nil {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.Where("code = ?", code).First(&voucherModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	v, err := voucherModel.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *VoucherRepository) FindAll(ctx context.Context, filter *voucher.VoucherFilter) ([]voucher.Voucher, error) {
+	var voucherModels []model.Voucher
+	query := r.getDB(ctx).Model(&model.Voucher{})
+	if filter != nil && filter.Batch != nil {
+		query = query.Where("batch = ?", *filter.Batch)
+	}
+	if err := query.Order("id").Find(&voucherModels).Error; err != nil {
+		return nil, err
+	}
+	vouchers := make([]voucher.Voucher, len(voucherModels))
+	for i, m := range voucherModels {
+		v, err := m.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		vouchers[i] = v
+	}
+	return vouchers, nil
+}
+
+func (r *VoucherRepository) IncrementRedemptions(ctx context.Context, id uint) error {
+	result := r.getDB(ctx).Model(&model.Voucher{}).
+		Where("id = ? AND (max_redemptions = 0 OR redemptions < max_redemptions)", id).
+		Update("redemptions", gorm.Expr("redemptions + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrVoucherExhausted
+	}
+	return nil
+}
+
+func (r *VoucherRepository) CountRedemptions(ctx context.Context, voucherID, userID uint) (int64, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&model.VoucherRedemption{}).
+		Where("voucher_id = ? AND user_id = ?", voucherID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *VoucherRepository) CreateRedemption(ctx context.Context, redemption voucher.Redemption) (uint, error) {
+	redemptionModel := model.CreateVoucherRedemptionFromDomain(redemption)
+	if err := r.getDB(ctx).Create(&redemptionModel).Error; err != nil {
+		return 0, err
+	}
+	return redemptionModel.ID, nil
+}
+
+func (r *VoucherRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
@@ -5,8 +5,11 @@ import (
 
 	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
 	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WalletRepository struct {
@@ -26,9 +29,9 @@ func (r *WalletRepository) Update(ctx context.Context, wallet wallet.Wallet) err
 	db := r.getDB(ctx)
 	return db.Model(&model.Wallet{}).Where("id = ?", wallet.ID).Updates(wallet.ToNotEmptyValueMap()).Error
 }
-func (r *WalletRepository) FindById(id uint) (*wallet.Wallet, error) {
+func (r *WalletRepository) FindById(ctx context.Context, id uint) (*wallet.Wallet, error) {
 	var walletModel model.Wallet
-	if err := r.db.Take(&walletModel, id).Error; err != nil {
+	if err := r.getDB(ctx).Take(&walletModel, id).Error; err != nil {
 		return nil, err
 	}
 	w, err := walletModel.ToDomain()
@@ -37,6 +40,68 @@ func (r *WalletRepository) FindById(id uint) (*wallet.Wallet, error) {
 	}
 	return &w, nil
 }
+func (r *WalletRepository) AddBalance(ctx context.Context, id uint, delta float64) (*wallet.Wallet, error) {
+	db := r.getDB(ctx)
+	var walletModel model.Wallet
+	result := db.Model(&walletModel).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND balance + ? >= 0", id, delta).
+		Update("balance", gorm.Expr("balance + ?", delta))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := db.Model(&model.Wallet{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, errs.ErrNotFound
+		}
+		return nil, errs.ErrInsufficientBalance
+	}
+	w, err := walletModel.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *WalletRepository) CreateAdjustment(ctx context.Context, adjustment wallet.Adjustment) (uint, error) {
+	adjustmentModel := model.CreateBalanceAdjustmentFromDomain(adjustment)
+	if err := r.getDB(ctx).Create(&adjustmentModel).Error; err != nil {
+		return 0, err
+	}
+	return adjustmentModel.ID, nil
+}
+
+func (r *WalletRepository) CreatePostings(ctx context.Context, postings []wallet.Posting) error {
+	if len(postings) == 0 {
+		return nil
+	}
+	postingModels := make([]model.WalletPosting, len(postings))
+	for i, p := range postings {
+		postingModels[i] = model.CreateWalletPostingFromDomain(p)
+	}
+	return r.getDB(ctx).Create(&postingModels).Error
+}
+
+func (r *WalletRepository) FindDiscrepancies(ctx context.Context) ([]wallet.Discrepancy, error) {
+	var discrepancies []wallet.Discrepancy
+	err := r.getDB(ctx).Raw(`SELECT w.id AS wallet_id, w.balance, COALESCE(t.total, 0) + COALESCE(a.total, 0) AS expected
+		FROM wallets w
+		LEFT JOIN (SELECT user_id, SUM(amount - fee + bonus) AS total FROM transactions
+			WHERE status = ? AND deleted_at IS NULL GROUP BY user_id) t ON t.user_id = w.id
+		LEFT JOIN (SELECT wallet_id, SUM(amount) AS total FROM balance_adjustments GROUP BY wallet_id) a ON a.wallet_id = w.id
+		WHERE w.deleted_at IS NULL AND w.balance <> COALESCE(t.total, 0) + COALESCE(a.total, 0)
+		ORDER BY w.id`, vo.StatusCompleted.String()).
+		Scan(&discrepancies).Error
+	if err != nil {
+		return nil, err
+	}
+	return discrepancies, nil
+}
+
 func (r *WalletRepository) getDB(ctx context.Context) *gorm.DB {
 	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
 		return tx
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	IRepository "github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription webhook.Subscription) (uint, error) {
+	db := r.getDB(ctx)
+	subscriptionModel := model.CreateWebhookSubscriptionFromDomain(subscription)
+	if err := db.Create(&subscriptionModel).Error; err != nil {
+		return 0, err
+	}
+	return subscriptionModel.ID, nil
+}
+
+func (r *WebhookSubscriptionRepository) FindById(id uint) (*webhook.Subscription, error) {
+	var subscriptionModel model.WebhookSubscription
+	if err := r.db.First(&subscriptionModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	s := subscriptionModel.ToDomain()
+	return &s, nil
+}
+
+func (r *WebhookSubscriptionRepository) FindAll(filter *webhook.SubscriptionFilter) ([]webhook.Subscription, error) {
+	var subscriptionModels []model.WebhookSubscription
+	query := r.db.Model(&model.WebhookSubscription{})
+	if filter != nil {
+		if filter.Active != nil {
+			query = query.Where("active = ?", *filter.Active)
+		}
+		if filter.EventType != nil {
+			query = query.Where("(',' || event_types || ',') LIKE ?", "%,"+filter.EventType.String()+",%")
+		}
+	}
+	if err := query.Order("id").Find(&subscriptionModels).Error; err != nil {
+		return nil, err
+	}
+	subscriptions := make([]webhook.Subscription, len(subscriptionModels))
+	for i, s := range subscriptionModels {
+		subscriptions[i] = s.ToDomain()
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id uint) error {
+	db := r.getDB(ctx)
+	if result := db.Delete(&model.WebhookSubscription{}, id); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) SetActive(ctx context.Context, id uint, active bool) error {
+	values := map[string]interface{}{"active": active, "disabled_at": nil}
+	if active {
+		values["consecutive_failures"] = 0
+	} else {
+		values["disabled_at"] = time.Now()
+	}
+	return r.update(ctx, id, values)
+}
+
+func (r *WebhookSubscriptionRepository) RecordFailure(ctx context.Context, id uint, disableAfter int) (bool, error) {
+	db := r.getDB(ctx)
+	var disabled []bool
+	// Increment and disable in one statement so concurrent workers cannot both miss the threshold
+	err := db.Raw(`UPDATE webhook_subscriptions s
+		SET consecutive_failures = s.consecutive_failures + 1,
+			active = CASE WHEN s.consecutive_failures + 1 >= ? THEN FALSE ELSE s.active END,
+			disabled_at = CASE WHEN s.active AND s.consecutive_failures + 1 >= ? THEN NOW() ELSE s.disabled_at END,
+			updated_at = NOW()
+		FROM (SELECT id, active FROM webhook_subscriptions WHERE id = ? AND deleted_at IS NULL FOR UPDATE) prev
+		WHERE s.id = prev.id
+		RETURNING prev.active AND NOT s.active`, disableAfter, disableAfter, id).
+		Scan(&disabled).Error
+	if err != nil {
+		return false, err
+	}
+	return len(disabled) == 1 && disabled[0], nil
+}
+
+func (r *WebhookSubscriptionRepository) ResetFailures(ctx context.Context, id uint) error {
+	db := r.getDB(ctx)
+	return db.Model(&model.WebhookSubscription{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		Update("consecutive_failures", 0).Error
+}
+
+func (r *WebhookSubscriptionRepository) update(ctx context.Context, id uint, values map[string]interface{}) error {
+	db := r.getDB(ctx)
+	if result := db.Model(&model.WebhookSubscription{}).Where("id = ?", id).Updates(values); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) CreateMany(ctx context.Context, deliveries []webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	db := r.getDB(ctx)
+	deliveryModels := make([]model.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		deliveryModels[i] = model.CreateWebhookDeliveryFromDomain(d)
+	}
+	// A redelivered outbox event must not produce a second webhook delivery
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveryModels).Error
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]webhook.Delivery, error) {
+	var deliveryModels []model.WebhookDelivery
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("status = ? AND next_attempt_at <= ?", webhook.DeliveryStatusPending.String(), now).
+			Where("subscription_id IN (?)", tx.Model(&model.WebhookSubscription{}).Select("id").Where("active")).
+			Order("next_attempt_at").
+			Limit(limit).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&deliveryModels).Error
+		if err != nil || len(deliveryModels) == 0 {
+			return err
+		}
+		ids := make([]uint, len(deliveryModels))
+		for i, d := range deliveryModels {
+			ids[i] = d.ID
+		}
+		return tx.Model(&model.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]webhook.Delivery, len(deliveryModels))
+	for i, d := range deliveryModels {
+		deliveries[i] = d.ToDomain()
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery webhook.Delivery) error {
+	db := r.getDB(ctx)
+	result := db.Model(&model.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+		"status":             delivery.Status.String(),
+		"attempts":           delivery.Attempts,
+		"last_response_code": delivery.LastResponseCode,
+		"last_error":         delivery.LastError,
+		"next_attempt_at":    delivery.NextAttemptAt,
+		"delivered_at":       delivery.DeliveredAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) FindById(id uint) (*webhook.Delivery, error) {
+	var deliveryModel model.WebhookDelivery
+	if err := r.db.First(&deliveryModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	d := deliveryModel.ToDomain()
+	return &d, nil
+}
+
+func (r *WebhookDeliveryRepository) FindAll(filter *webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	var deliveryModels []model.WebhookDelivery
+	query := r.db.Model(&model.WebhookDelivery{})
+	if filter != nil {
+		if filter.SubscriptionID != nil {
+			query = query.Where("subscription_id = ?", *filter.SubscriptionID)
+		}
+		if filter.Status != nil {
+			query = query.Where("status = ?", filter.Status.String())
+		}
+	}
+	if err := query.Order("id DESC").Find(&deliveryModels).Error; err != nil {
+		return nil, err
+	}
+	deliveries := make([]webhook.Delivery, len(deliveryModels))
+	for i, d := range deliveryModels {
+		deliveries[i] = d.ToDomain()
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) CreateAttempt(ctx context.Context, attempt webhook.Attempt) error {
+	db := r.getDB(ctx)
+	attemptModel := model.CreateWebhookDeliveryAttemptFromDomain(attempt)
+	return db.Create(&attemptModel).Error
+}
+
+func (r *WebhookDeliveryRepository) FindAttempts(deliveryID uint) ([]webhook.Attempt, error) {
+	var attemptModels []model.WebhookDeliveryAttempt
+	if err := r.db.Where("delivery_id = ?", deliveryID).Order("attempt").Find(&attemptModels).Error; err != nil {
+		return nil, err
+	}
+	attempts := make([]webhook.Attempt, len(attemptModels))
+	for i, a := range attemptModels {
+		attempts[i] = a.ToDomain()
+	}
+	return attempts, nil
+}
+
+func (r *WebhookDeliveryRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := IRepository.GetTx(ctx).(*gorm.DB); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
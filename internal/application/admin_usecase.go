@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/metrics"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+)
+
+// AdminUsecase holds operator actions on wallets and transactions
+type AdminUsecase interface {
+	AdjustBalance(ctx context.Context, walletID uint, amount float64, reason, actor string) (wallet.Wallet, error)
+	ExpireTransaction(ctx context.Context, transactionID uint) (transaction.Transaction, error)
+	Reconcile(ctx context.Context) ([]wallet.Discrepancy, error)
+}
+
+type AdminUsecaseImpl struct {
+	walletRepo      wallet.Repository
+	transactionRepo transaction.Repository
+	outboxRepo      outbox.Repository
+	cache           cache.CacheService
+	tx              domain.TxManager
+	logger          logger.Logger
+	metrics         metrics.Recorder
+}
+
+// NewAdminUsecase creates a new instance of AdminUsecase
+func NewAdminUsecase(
+	walletRepo wallet.Repository,
+	transactionRepo transaction.Repository,
+	outboxRepo outbox.Repository,
+	cache cache.CacheService,
+	tx domain.TxManager,
+	logger logger.Logger,
+	metrics metrics.Recorder,
+) AdminUsecase {
+	return &AdminUsecaseImpl{
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+		outboxRepo:      outboxRepo,
+		cache:           cache,
+		tx:              tx,
+		logger:          logger,
+		metrics:         metrics,
+	}
+}
+
+// AdjustBalance credits or debits a wallet outside the top-up flow. The adjustment is recorded
+// so reconciliation still balances, and a wallet.balance_changed event is emitted.
+func (uc *AdminUsecaseImpl) AdjustBalance(ctx context.Context, walletID uint, amount float64, reason, actor string) (wallet.Wallet, error) {
+	if amount == 0 {
+		return wallet.Wallet{}, errs.ErrInvalidAdjustment
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return wallet.Wallet{}, errs.ErrAdjustmentReasonRequired
+	}
+
+	var updated *wallet.Wallet
+	err := runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		var err error
+		updated, err = uc.walletRepo.AddBalance(txCtx, walletID, amount)
+		if err != nil {
+			return err
+		}
+		adjustmentID, err := uc.walletRepo.CreateAdjustment(txCtx, wallet.Adjustment{
+			WalletID:  walletID,
+			Amount:    amount,
+			Reason:    reason,
+			Actor:     actor,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		message, err := newOutboxMessage(event.TypeWalletBalanceChanged, event.AggregateWallet, walletID, event.BalanceChangedPayload{
+			WalletID:     walletID,
+			AdjustmentID: adjustmentID,
+			Delta:        amount,
+			Balance:      updated.Balance.Amount(),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = uc.outboxRepo.Create(txCtx, message)
+		return err
+	})
+	if err != nil {
+		return wallet.Wallet{}, err
+	}
+
+	uc.logger.WithContext(ctx).Info("Wallet balance adjusted", map[string]interface{}{
+		"wallet_id": walletID,
+		"amount":    amount,
+		"balance":   updated.Balance.Amount(),
+		"reason":    reason,
+		"actor":     actor,
+	})
+	return *updated, nil
+}
+
+// ExpireTransaction expires a verified top-up immediately, whatever its expiry time
+func (uc *AdminUsecaseImpl) ExpireTransaction(ctx context.Context, transactionID uint) (transaction.Transaction, error) {
+	tx, err := uc.transactionRepo.FindById(ctx, transactionID)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	if tx.Status != vo.StatusVerified {
+		return transaction.Transaction{}, errs.ErrTransactionNotVerified
+	}
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		return expireTopup(txCtx, uc.transactionRepo, uc.outboxRepo, tx)
+	})
+	if errors.Is(err, errs.ErrNotFound) {
+		// Confirmed or expired since it was read
+		return transaction.Transaction{}, errs.ErrTransactionNotVerified
+	}
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+
+	uc.metrics.TopupExpired(tx.PaymentMethod.String(), tx.Amount.Amount())
+	_ = uc.cache.Delete(context.WithoutCancel(ctx), getTransactionCacheKey(tx.ID))
+	uc.logger.WithContext(ctx).Info("Transaction expired by operator", map[string]interface{}{"transaction_id": tx.ID})
+	return *tx, nil
+}
+
+// Reconcile returns wallets whose balance does not equal their completed top-ups plus adjustments
+func (uc *AdminUsecaseImpl) Reconcile(ctx context.Context) ([]wallet.Discrepancy, error) {
+	discrepancies, err := uc.walletRepo.FindDiscrepancies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range discrepancies {
+		uc.logger.WithContext(ctx).Warn("Wallet balance does not match ledger", map[string]interface{}{
+			"wallet_id":  d.WalletID,
+			"balance":    d.Balance,
+			"expected":   d.Expected,
+			"difference": d.Difference(),
+		})
+	}
+	return discrepancies, nil
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/payment"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// errChargedNotCredited means a run's top-up was charged but can no longer be confirmed. Retrying
+// cannot help, and the charge has to be reconciled with the provider.
+var errChargedNotCredited = errors.New("auto top-up was charged but can no longer be credited")
+
+// AutoTopupScheduler runs due auto top-up rules. Each run verifies a top-up, charges the rule's
+// saved payment instrument and confirms the top-up. Failed runs are retried with backoff; after the
+// last attempt the rule reports autotopup.failed and waits for its next trigger.
+type AutoTopupScheduler struct {
+	ruleRepo        autotopup.Repository
+	instrumentRepo  instrument.Repository
+	transactionRepo transaction.Repository
+	outboxRepo      outbox.Repository
+	wallet          WalletUsecase
+	provider        payment.Provider
+	tx              domain.TxManager
+	logger          logger.Logger
+	cfg             config.AutoTopupConfig
+}
+
+// NewAutoTopupScheduler creates a new instance of AutoTopupScheduler
+func NewAutoTopupScheduler(
+	ruleRepo autotopup.Repository,
+	instrumentRepo instrument.Repository,
+	transactionRepo transaction.Repository,
+	outboxRepo outbox.Repository,
+	wallet WalletUsecase,
+	provider payment.Provider,
+	tx domain.TxManager,
+	logger logger.Logger,
+	cfg config.AutoTopupConfig,
+) *AutoTopupScheduler {
+	return &AutoTopupScheduler{
+		ruleRepo:        ruleRepo,
+		instrumentRepo:  instrumentRepo,
+		transactionRepo: transactionRepo,
+		outboxRepo:      outboxRepo,
+		wallet:          wallet,
+		provider:        provider,
+		tx:              tx,
+		logger:          logger,
+		cfg:             cfg,
+	}
+}
+
+// Run executes due rules until ctx is cancelled
+func (s *AutoTopupScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.cfg.Interval) * time.Second)
+	defer ticker.Stop()
+
+	s.logger.Info("Auto top-up scheduler started", nil)
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Auto top-up scheduler stopped", nil)
+			return
+		case <-ticker.C:
+			for {
+				n, err := s.RunOnce(ctx)
+				if err != nil {
+					s.logger.Error("Auto top-up run failed", map[string]interface{}{"error": err.Error()})
+					break
+				}
+				if n < s.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RunOnce executes one batch of due rules and returns how many were claimed.
+// Claimed rules are leased by moving their next run forward, so several schedulers can run
+// side by side and a rule whose scheduler dies is picked up again once the lease ends.
+func (s *AutoTopupScheduler) RunOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	leaseUntil := now.Add(time.Duration(s.cfg.Lease) * time.Second)
+	var claimed []autotopup.Rule
+	err := runInTx(ctx, s.tx, func(txCtx context.Context) error {
+		rules, err := s.ruleRepo.FindDue(txCtx, now, s.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range rules {
+			rules[i].NextRunAt = &leaseUntil
+			rules[i].UpdatedAt = now
+			if err := s.ruleRepo.Update(txCtx, rules[i]); err != nil {
+				return err
+			}
+		}
+		claimed = rules
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, rule := range claimed {
+		if ctx.Err() != nil {
+			// The remaining rules run again once their lease ends
+			break
+		}
+		s.execute(ctx, rule)
+	}
+	return len(claimed), nil
+}
+
+// execute runs a rule once and records the outcome
+func (s *AutoTopupScheduler) execute(ctx context.Context, rule autotopup.Rule) {
+	ctx = logger.ContextWithFields(ctx, s.logger, map[string]interface{}{
+		"rule_id": rule.ID,
+		"user_id": rule.UserID,
+	})
+	transactionID, runErr := s.topup(ctx, &rule)
+
+	now := time.Now()
+	rule.Attempts++
+	rule.UpdatedAt = now
+	payload := event.AutoTopupPayload{
+		RuleID:        rule.ID,
+		UserID:        rule.UserID,
+		Trigger:       rule.Trigger.String(),
+		TransactionID: transactionID,
+		Amount:        rule.Amount.Amount(),
+		Attempts:      rule.Attempts,
+	}
+	var eventType event.Type
+	switch {
+	case runErr == nil:
+		eventType = event.TypeAutoTopupSucceeded
+		rule.Completed(now, "")
+	case rule.Attempts < s.cfg.MaxAttempts && !errors.Is(runErr, errChargedNotCredited):
+		next := now.Add(backoffWithJitter(rule.Attempts, time.Duration(s.cfg.BaseBackoff)*time.Second, time.Duration(s.cfg.MaxBackoff)*time.Second))
+		rule.NextRunAt = &next
+		rule.LastError = runErr.Error()
+	default:
+		eventType = event.TypeAutoTopupFailed
+		payload.Error = runErr.Error()
+		rule.Completed(now, runErr.Error())
+	}
+
+	err := runInTx(ctx, s.tx, func(txCtx context.Context) error {
+		if err := s.ruleRepo.Update(txCtx, rule); err != nil {
+			return err
+		}
+		if eventType == "" {
+			return nil
+		}
+		message, err := newOutboxMessage(eventType, event.AggregateAutoTopup, rule.ID, payload)
+		if err != nil {
+			return err
+		}
+		_, err = s.outboxRepo.Create(txCtx, message)
+		return err
+	})
+	if errors.Is(err, errs.ErrNotFound) {
+		// The rule was deleted while it ran
+		err = nil
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to record auto top-up outcome", map[string]interface{}{"error": err.Error()})
+	}
+
+	fields := map[string]interface{}{"transaction_id": transactionID, "attempts": payload.Attempts}
+	switch {
+	case runErr == nil:
+		s.logger.WithContext(ctx).Info("Auto top-up completed", fields)
+	case eventType == "":
+		fields["error"] = runErr.Error()
+		fields["next_run_at"] = rule.NextRunAt
+		s.logger.WithContext(ctx).Warn("Auto top-up failed, will retry", fields)
+	default:
+		fields["error"] = runErr.Error()
+		s.logger.WithContext(ctx).Error("Auto top-up failed", fields)
+	}
+}
+
+// topup drives the verify, charge and confirm flow for a rule and returns the transaction ID,
+// if one was created. The run's top-up is saved on the rule before it is charged, and the charge
+// carries the run key, so a retry or a scheduler taking over an abandoned run only confirms what
+// was already charged. A top-up that is verified but never confirmed expires on its own.
+func (s *AutoTopupScheduler) topup(ctx context.Context, rule *autotopup.Rule) (uint, error) {
+	tx, err := s.runTransaction(ctx, rule)
+	if err != nil || tx.Status == vo.StatusCompleted {
+		return tx.ID, err
+	}
+	if tx.ProviderReference == "" {
+		paymentInstrument, err := s.instrumentRepo.FindByID(ctx, rule.InstrumentID)
+		if err != nil {
+			return tx.ID, err
+		}
+		receipt, err := s.provider.Charge(ctx, payment.Charge{
+			Token:         paymentInstrument.Token,
+			PaymentMethod: tx.PaymentMethod,
+			Amount:        tx.Amount.Amount(),
+			Reference:     rule.RunKey,
+		})
+		if errors.Is(err, payment.ErrDeclined) {
+			// Nothing was collected. A fresh key keeps the provider from replaying the decline.
+			rule.RunKey = ""
+		}
+		if err != nil {
+			return tx.ID, err
+		}
+		// The stored reference marks the top-up as charged. Should this fail, the retry charges
+		// again with the same run key and the provider returns this receipt.
+		tx.ProviderReference = receipt.ProviderReference
+		err = s.transactionRepo.Update(ctx, &transaction.TransactionFilter{ID: &tx.ID}, transaction.Transaction{ProviderReference: tx.ProviderReference})
+		if err != nil {
+			return tx.ID, err
+		}
+	}
+	// The reference is already stored, so the confirm need not record it
+	if _, _, err := s.wallet.ConfirmTopup(ctx, tx.ID, ""); err != nil {
+		// The money was collected but not credited yet; retries only confirm
+		s.logger.WithContext(ctx).Error("Auto top-up charged but not confirmed", map[string]interface{}{
+			"transaction_id":     tx.ID,
+			"provider_reference": tx.ProviderReference,
+			"error":              err.Error(),
+		})
+		if errors.Is(err, errs.ErrExpiredTransaction) {
+			return tx.ID, fmt.Errorf("%w: %v", errChargedNotCredited, err)
+		}
+		return tx.ID, err
+	}
+	return tx.ID, nil
+}
+
+// runTransaction returns the top-up of the rule's current run. When the run has none that can
+// still be charged and confirmed, it verifies a new one. A new run key or top-up is saved on the
+// rule before anything is charged with it.
+func (s *AutoTopupScheduler) runTransaction(ctx context.Context, rule *autotopup.Rule) (transaction.Transaction, error) {
+	newKey := rule.RunKey == ""
+	if newKey {
+		rule.RunKey = fmt.Sprintf("autotopup-%d-%d", rule.ID, time.Now().UnixNano())
+	}
+	if rule.RunTransactionID != 0 {
+		tx, err := s.transactionRepo.FindById(ctx, rule.RunTransactionID)
+		if err != nil {
+			return transaction.Transaction{}, err
+		}
+		switch {
+		case tx.Status == vo.StatusCompleted:
+			// Confirmed by an earlier attempt whose outcome was not recorded
+			return *tx, nil
+		case tx.ProviderReference != "" && tx.Status == vo.StatusVerified:
+			return *tx, nil
+		case tx.ProviderReference != "":
+			return *tx, fmt.Errorf("%w: top-up is %s", errChargedNotCredited, tx.Status)
+		case tx.Status == vo.StatusVerified && time.Now().Before(tx.ExpiresAt):
+			if newKey {
+				return *tx, s.ruleRepo.Update(ctx, *rule)
+			}
+			return *tx, nil
+		}
+		// Not charged as far as we know and no longer confirmable. A charge that did go through
+		// before it was recorded is returned again for the new top-up, as it shares the run key.
+	}
+	tx, err := s.wallet.VerifyTopup(ctx, rule.UserID, rule.Amount.Amount(), rule.InstrumentID)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	rule.RunTransactionID = tx.ID
+	return tx, s.ruleRepo.Update(ctx, *rule)
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/payment"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopTxManager struct{}
+
+func (nopTxManager) BeginTx(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (nopTxManager) CommitTx(context.Context) error                       { return nil }
+func (nopTxManager) RollbackTx(context.Context) error                     { return nil }
+
+type fakeRuleRepo struct {
+	autotopup.Repository
+	rules map[uint]autotopup.Rule
+}
+
+func (r *fakeRuleRepo) Update(ctx context.Context, rule autotopup.Rule) error {
+	if _, ok := r.rules[rule.ID]; !ok {
+		return errs.ErrNotFound
+	}
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+func (r *fakeRuleRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]autotopup.Rule, error) {
+	var due []autotopup.Rule
+	for _, rule := range r.rules {
+		if rule.Enabled && rule.NextRunAt != nil && !rule.NextRunAt.After(now) {
+			due = append(due, rule)
+		}
+	}
+	return due, nil
+}
+
+// makeDue moves a waiting retry forward so the next RunOnce picks it up
+func (r *fakeRuleRepo) makeDue(id uint) {
+	rule := r.rules[id]
+	past := time.Now().Add(-time.Second)
+	rule.NextRunAt = &past
+	r.rules[id] = rule
+}
+
+type fakeInstrumentRepo struct{ instrument.Repository }
+
+func (fakeInstrumentRepo) FindByID(ctx context.Context, id uint) (*instrument.Instrument, error) {
+	return &instrument.Instrument{ID: id, UserID: 1, Type: instrument.TypeCard, Token: "tok_visa"}, nil
+}
+
+type fakeTransactionRepo struct {
+	transaction.Repository
+	transactions map[uint]*transaction.Transaction
+	failUpdates  int
+}
+
+func (r *fakeTransactionRepo) FindById(ctx context.Context, id uint) (*transaction.Transaction, error) {
+	t, ok := r.transactions[id]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	copied := *t
+	return &copied, nil
+}
+
+func (r *fakeTransactionRepo) Update(ctx context.Context, filter *transaction.TransactionFilter, update transaction.Transaction) error {
+	if r.failUpdates > 0 {
+		r.failUpdates--
+		return errors.New("database unavailable")
+	}
+	t, ok := r.transactions[*filter.ID]
+	if !ok || (filter.Status != nil && t.Status != *filter.Status) {
+		return errs.ErrNotFound
+	}
+	if update.Status != "" {
+		t.Status = update.Status
+	}
+	if update.ProviderReference != "" {
+		t.ProviderReference = update.ProviderReference
+	}
+	return nil
+}
+
+type fakeOutboxRepo struct {
+	outbox.Repository
+	events []event.Type
+}
+
+func (r *fakeOutboxRepo) Create(ctx context.Context, message outbox.Message) (uint, error) {
+	r.events = append(r.events, message.EventType)
+	return uint(len(r.events)), nil
+}
+
+// fakeWallet verifies and confirms top-ups against fakeTransactionRepo
+type fakeWallet struct {
+	transactions *fakeTransactionRepo
+	failConfirms int
+	confirmErr   error
+	confirms     []uint
+}
+
+func (w *fakeWallet) VerifyTopup(ctx context.Context, userID uint, amount float64, instrumentID uint) (transaction.Transaction, error) {
+	t, err := transaction.NewTransaction(userID, amount, vo.PaymentMethodCreditCard.String(), vo.StatusVerified.String(), time.Now().Add(time.Hour))
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	t.ID = uint(len(w.transactions.transactions) + 1)
+	t.InstrumentID = instrumentID
+	w.transactions.transactions[t.ID] = &t
+	return t, nil
+}
+
+func (w *fakeWallet) ConfirmTopup(ctx context.Context, transactionID uint, providerReference string) (transaction.Transaction, wallet.Wallet, error) {
+	w.confirms = append(w.confirms, transactionID)
+	if w.failConfirms > 0 {
+		w.failConfirms--
+		return transaction.Transaction{}, wallet.Wallet{}, w.confirmErr
+	}
+	t := w.transactions.transactions[transactionID]
+	if t.Status != vo.StatusVerified {
+		return transaction.Transaction{}, wallet.Wallet{}, errs.ErrTransactionNotVerified
+	}
+	t.Status = vo.StatusCompleted
+	return *t, wallet.Wallet{ID: t.UserID}, nil
+}
+
+// fakeProvider collects once per reference, like a real provider honouring idempotency keys
+type fakeProvider struct {
+	charges   []string
+	collected map[string]float64
+}
+
+func (p *fakeProvider) Charge(ctx context.Context, charge payment.Charge) (payment.Receipt, error) {
+	p.charges = append(p.charges, charge.Reference)
+	if _, ok := p.collected[charge.Reference]; !ok {
+		p.collected[charge.Reference] = charge.Amount
+	}
+	return payment.Receipt{ProviderReference: "prov_" + charge.Reference}, nil
+}
+
+type schedulerFixture struct {
+	rules        *fakeRuleRepo
+	transactions *fakeTransactionRepo
+	outbox       *fakeOutboxRepo
+	wallet       *fakeWallet
+	provider     *fakeProvider
+	scheduler    *AutoTopupScheduler
+}
+
+func newSchedulerFixture(t *testing.T) *schedulerFixture {
+	t.Helper()
+	now := time.Now().Add(-time.Second)
+	amount, err := vo.NewMoney(50)
+	require.NoError(t, err)
+	f := &schedulerFixture{
+		rules: &fakeRuleRepo{rules: map[uint]autotopup.Rule{
+			1: {ID: 1, UserID: 1, Trigger: autotopup.TriggerThreshold, Threshold: 10, Amount: amount, InstrumentID: 3, Enabled: true, NextRunAt: &now},
+		}},
+		transactions: &fakeTransactionRepo{transactions: map[uint]*transaction.Transaction{}},
+		outbox:       &fakeOutboxRepo{},
+		provider:     &fakeProvider{collected: map[string]float64{}},
+	}
+	f.wallet = &fakeWallet{transactions: f.transactions}
+	f.scheduler = NewAutoTopupScheduler(f.rules, fakeInstrumentRepo{}, f.transactions, f.outbox, f.wallet, f.provider, nopTxManager{}, nopLogger{},
+		config.AutoTopupConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: 1, MaxBackoff: 1, Lease: 60})
+	return f
+}
+
+func (f *schedulerFixture) runOnce(t *testing.T) {
+	t.Helper()
+	n, err := f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestAutoTopupRetryAfterFailedConfirmDoesNotChargeAgain(t *testing.T) {
+	f := newSchedulerFixture(t)
+	f.wallet.failConfirms = 1
+	f.wallet.confirmErr = errors.New("database unavailable")
+
+	f.runOnce(t)
+	require.Len(t, f.provider.charges, 1)
+	rule := f.rules.rules[1]
+	assert.Equal(t, 1, rule.Attempts)
+	assert.Equal(t, uint(1), rule.RunTransactionID)
+	assert.Equal(t, f.provider.charges[0], rule.RunKey)
+	assert.Equal(t, "prov_"+rule.RunKey, f.transactions.transactions[1].ProviderReference)
+
+	f.rules.makeDue(1)
+	f.runOnce(t)
+
+	assert.Len(t, f.provider.charges, 1, "the retry must only confirm")
+	assert.Len(t, f.transactions.transactions, 1, "the retry must not verify a new top-up")
+	assert.Equal(t, []uint{1, 1}, f.wallet.confirms)
+	assert.Equal(t, vo.StatusCompleted, f.transactions.transactions[1].Status)
+	rule = f.rules.rules[1]
+	assert.Zero(t, rule.Attempts)
+	assert.Empty(t, rule.RunKey)
+	assert.Zero(t, rule.RunTransactionID)
+	assert.Equal(t, []event.Type{event.TypeAutoTopupSucceeded}, f.outbox.events)
+}
+
+func TestAutoTopupRetryAfterUnrecordedChargeReusesRunKey(t *testing.T) {
+	f := newSchedulerFixture(t)
+	// The charge goes through but storing its reference fails, as if the worker died right after charging
+	f.transactions.failUpdates = 1
+
+	f.runOnce(t)
+	require.Len(t, f.provider.charges, 1)
+	assert.Empty(t, f.transactions.transactions[1].ProviderReference)
+
+	f.rules.makeDue(1)
+	f.runOnce(t)
+
+	// The charge is repeated with the same key, so the provider returns the first receipt
+	require.Len(t, f.provider.charges, 2)
+	assert.Equal(t, f.provider.charges[0], f.provider.charges[1])
+	assert.Len(t, f.provider.collected, 1)
+	assert.Equal(t, vo.StatusCompleted, f.transactions.transactions[1].Status)
+	assert.Equal(t, []uint{1}, f.wallet.confirms)
+}
+
+func TestAutoTopupResumesCompletedRunWithoutCharging(t *testing.T) {
+	f := newSchedulerFixture(t)
+	// An earlier scheduler confirmed the top-up but died before recording the outcome
+	tx, err := f.wallet.VerifyTopup(context.Background(), 1, 50, 3)
+	require.NoError(t, err)
+	f.transactions.transactions[tx.ID].ProviderReference = "prov_autotopup-1-1"
+	f.transactions.transactions[tx.ID].Status = vo.StatusCompleted
+	rule := f.rules.rules[1]
+	rule.RunKey = "autotopup-1-1"
+	rule.RunTransactionID = tx.ID
+	f.rules.rules[1] = rule
+
+	f.runOnce(t)
+
+	assert.Empty(t, f.provider.charges)
+	assert.Empty(t, f.wallet.confirms)
+	assert.Empty(t, f.rules.rules[1].RunKey)
+	assert.Equal(t, []event.Type{event.TypeAutoTopupSucceeded}, f.outbox.events)
+}
+
+func TestAutoTopupChargedButExpiredFailsWithoutRetry(t *testing.T) {
+	f := newSchedulerFixture(t)
+	f.wallet.failConfirms = 1
+	f.wallet.confirmErr = errs.ErrExpiredTransaction
+
+	f.runOnce(t)
+
+	require.Len(t, f.provider.charges, 1)
+	rule := f.rules.rules[1]
+	assert.Zero(t, rule.Attempts)
+	assert.Empty(t, rule.RunKey)
+	assert.Nil(t, rule.NextRunAt, "a threshold rule waits for its next trigger")
+	assert.Contains(t, rule.LastError, errChargedNotCredited.Error())
+	assert.Equal(t, []event.Type{event.TypeAutoTopupFailed}, f.outbox.events)
+}
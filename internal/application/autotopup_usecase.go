@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+)
+
+// AutoTopupUsecase manages the rules that top up wallets automatically
+type AutoTopupUsecase interface {
+	Create(ctx context.Context, spec autotopup.RuleSpec) (autotopup.Rule, error)
+	List(ctx context.Context, userID uint) ([]autotopup.Rule, error)
+	Delete(ctx context.Context, userID, ruleID uint) error
+}
+
+type AutoTopupUsecaseImpl struct {
+	ruleRepo       autotopup.Repository
+	userRepo       user.Repository
+	walletRepo     wallet.Repository
+	instrumentRepo instrument.Repository
+	logger         logger.Logger
+}
+
+// NewAutoTopupUsecase creates a new instance of AutoTopupUsecase
+func NewAutoTopupUsecase(
+	ruleRepo autotopup.Repository,
+	userRepo user.Repository,
+	walletRepo wallet.Repository,
+	instrumentRepo instrument.Repository,
+	logger logger.Logger,
+) AutoTopupUsecase {
+	return &AutoTopupUsecaseImpl{
+		ruleRepo:       ruleRepo,
+		userRepo:       userRepo,
+		walletRepo:     walletRepo,
+		instrumentRepo: instrumentRepo,
+		logger:         logger,
+	}
+}
+
+// Create saves a rule. A schedule rule first runs at the next matching time; a threshold rule
+// runs right away if the balance is already below its threshold.
+func (uc *AutoTopupUsecaseImpl) Create(ctx context.Context, spec autotopup.RuleSpec) (autotopup.Rule, error) {
+	rule, err := newAutoTopupRule(spec)
+	if err != nil {
+		return autotopup.Rule{}, err
+	}
+	if _, err := uc.userRepo.FindById(ctx, spec.UserID); err != nil {
+		return autotopup.Rule{}, err
+	}
+	paymentInstrument, err := uc.instrumentRepo.FindByID(ctx, rule.InstrumentID)
+	if err != nil {
+		return autotopup.Rule{}, err
+	}
+	if paymentInstrument.UserID != spec.UserID {
+		return autotopup.Rule{}, errs.ErrNotFound
+	}
+
+	now := time.Now()
+	switch rule.Trigger {
+	case autotopup.TriggerSchedule:
+		schedule, _ := autotopup.ParseSchedule(rule.Schedule)
+		next := schedule.Next(now)
+		rule.NextRunAt = &next
+	case autotopup.TriggerThreshold:
+		// Wallets share their owner's ID
+		w, err := uc.walletRepo.FindById(ctx, spec.UserID)
+		if err != nil {
+			return autotopup.Rule{}, err
+		}
+		if w.Balance.Amount() < rule.Threshold {
+			rule.NextRunAt = &now
+		}
+	}
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	if rule.ID, err = uc.ruleRepo.Create(ctx, rule); err != nil {
+		return autotopup.Rule{}, err
+	}
+
+	uc.logger.WithContext(ctx).Info("Auto top-up rule created", map[string]interface{}{
+		"rule_id": rule.ID,
+		"user_id": rule.UserID,
+		"trigger": rule.Trigger.String(),
+		"amount":  rule.Amount,
+	})
+	return rule, nil
+}
+
+// List returns the rules of a user
+func (uc *AutoTopupUsecaseImpl) List(ctx context.Context, userID uint) ([]autotopup.Rule, error) {
+	return uc.ruleRepo.FindAll(ctx, &autotopup.RuleFilter{UserID: &userID})
+}
+
+// Delete removes a rule of a user. A run already in progress still finishes.
+func (uc *AutoTopupUsecaseImpl) Delete(ctx context.Context, userID, ruleID uint) error {
+	if err := uc.ruleRepo.Delete(ctx, ruleID, userID); err != nil {
+		return err
+	}
+	uc.logger.WithContext(ctx).Info("Auto top-up rule deleted", map[string]interface{}{
+		"rule_id": ruleID,
+		"user_id": userID,
+	})
+	return nil
+}
+
+// newAutoTopupRule validates spec into an enabled rule that is not yet scheduled
+func newAutoTopupRule(spec autotopup.RuleSpec) (autotopup.Rule, error) {
+	invalid := func(problem string) (autotopup.Rule, error) {
+		return autotopup.Rule{}, fmt.Errorf("%w: %s", errs.ErrInvalidAutoTopup, problem)
+	}
+	trigger, err := autotopup.NewTrigger(spec.Trigger)
+	if err != nil {
+		return invalid("trigger must be threshold or schedule")
+	}
+	if spec.Amount <= 0 {
+		return invalid("amount must be positive")
+	}
+	amount, err := vo.NewMoney(spec.Amount)
+	if err != nil {
+		return autotopup.Rule{}, err
+	}
+	if spec.InstrumentID == 0 {
+		return invalid("instrument_id is required")
+	}
+
+	rule := autotopup.Rule{
+		UserID:       spec.UserID,
+		Trigger:      trigger,
+		Amount:       amount,
+		InstrumentID: spec.InstrumentID,
+		Enabled:      true,
+	}
+	switch trigger {
+	case autotopup.TriggerThreshold:
+		if spec.Threshold <= 0 {
+			return invalid("threshold must be positive")
+		}
+		rule.Threshold = spec.Threshold
+	case autotopup.TriggerSchedule:
+		rule.Schedule = strings.Join(strings.Fields(spec.Schedule), " ")
+		schedule, err := autotopup.ParseSchedule(rule.Schedule)
+		if err != nil {
+			return autotopup.Rule{}, err
+		}
+		if schedule.Next(time.Now()).IsZero() {
+			return invalid("schedule never matches")
+		}
+	}
+	return rule, nil
+}
+
+// AutoTopupTrigger implements event.EventPublisher by making threshold rules due when a
+// wallet balance changes. It reads the current balance, so late or repeated events are harmless.
+// It never fails the event: the relay would publish it to every other publisher again, and the
+// threshold is checked again on the wallet's next balance change.
+type AutoTopupTrigger struct {
+	ruleRepo   autotopup.Repository
+	walletRepo wallet.Repository
+	logger     logger.Logger
+	cfg        config.AutoTopupConfig
+}
+
+func NewAutoTopupTrigger(ruleRepo autotopup.Repository, walletRepo wallet.Repository, logger logger.Logger, cfg config.AutoTopupConfig) *AutoTopupTrigger {
+	return &AutoTopupTrigger{
+		ruleRepo:   ruleRepo,
+		walletRepo: walletRepo,
+		logger:     logger,
+		cfg:        cfg,
+	}
+}
+
+func (t *AutoTopupTrigger) Publish(ctx context.Context, e event.Event) error {
+	if e.Type != event.TypeWalletBalanceChanged {
+		return nil
+	}
+	if err := t.trigger(ctx, e); err != nil {
+		t.logger.WithContext(ctx).Error("Failed to check auto top-up thresholds", map[string]interface{}{
+			"event_id": e.ID,
+			"error":    err.Error(),
+		})
+	}
+	return nil
+}
+
+// trigger makes the threshold rules of the event's wallet due when its balance is below them
+func (t *AutoTopupTrigger) trigger(ctx context.Context, e event.Event) error {
+	var payload event.BalanceChangedPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal balance changed payload: %w", err)
+	}
+	w, err := t.walletRepo.FindById(ctx, payload.WalletID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cooldownSince := now.Add(-time.Duration(t.cfg.ThresholdCooldown) * time.Second)
+	// Wallets share their owner's ID
+	n, err := t.ruleRepo.MarkThresholdDue(ctx, w.ID, w.Balance.Amount(), now, cooldownSince)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		t.logger.Info("Auto top-up threshold reached", map[string]interface{}{
+			"wallet_id": w.ID,
+			"balance":   w.Balance,
+			"rules":     n,
+		})
+	}
+	return nil
+}
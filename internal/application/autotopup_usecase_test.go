@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type thresholdRuleRepo struct {
+	autotopup.Repository
+	marked []uint
+}
+
+func (r *thresholdRuleRepo) MarkThresholdDue(ctx context.Context, userID uint, balance float64, now, cooldownSince time.Time) (int64, error) {
+	r.marked = append(r.marked, userID)
+	return 1, nil
+}
+
+type balanceWalletRepo struct {
+	wallet.Repository
+	err error
+}
+
+func (r balanceWalletRepo) FindById(ctx context.Context, id uint) (*wallet.Wallet, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &wallet.Wallet{ID: id}, nil
+}
+
+// countingPublisher counts the events it was given
+type countingPublisher struct{ published int }
+
+func (p *countingPublisher) Publish(ctx context.Context, e event.Event) error {
+	p.published++
+	return nil
+}
+
+func balanceChanged(t *testing.T, walletID uint) event.Event {
+	payload, err := json.Marshal(event.BalanceChangedPayload{WalletID: walletID, Balance: 5})
+	require.NoError(t, err)
+	return event.Event{ID: 1, Type: event.TypeWalletBalanceChanged, Payload: payload}
+}
+
+func TestAutoTopupTriggerMarksThresholdRulesDue(t *testing.T) {
+	rules := &thresholdRuleRepo{}
+	trigger := NewAutoTopupTrigger(rules, balanceWalletRepo{}, nopLogger{}, config.AutoTopupConfig{ThresholdCooldown: 60})
+
+	require.NoError(t, trigger.Publish(context.Background(), balanceChanged(t, 3)))
+	require.NoError(t, trigger.Publish(context.Background(), event.Event{Type: event.TypeTopupCompleted}))
+	assert.Equal(t, []uint{3}, rules.marked, "only balance changes are checked")
+}
+
+func TestAutoTopupTriggerFailureDoesNotFailTheEvent(t *testing.T) {
+	rules := &thresholdRuleRepo{}
+	trigger := NewAutoTopupTrigger(rules, balanceWalletRepo{err: errors.New("database unavailable")}, nopLogger{}, config.AutoTopupConfig{})
+	stream, webhooks := &countingPublisher{}, &countingPublisher{}
+	publisher := event.MultiPublisher{stream, webhooks, trigger}
+
+	require.NoError(t, publisher.Publish(context.Background(), balanceChanged(t, 3)), "the relay must not retry the event")
+	require.NoError(t, publisher.Publish(context.Background(), event.Event{ID: 2, Type: event.TypeWalletBalanceChanged, Payload: []byte("{")}))
+
+	assert.Equal(t, 2, stream.published)
+	assert.Equal(t, 2, webhooks.published)
+	assert.Empty(t, rules.marked)
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/metrics"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// ExpirySweeper expires verified top-ups whose confirmation window has passed,
+// so they do not wait for a confirm attempt to be marked expired
+type ExpirySweeper struct {
+	transactionRepo transaction.Repository
+	outboxRepo      outbox.Repository
+	cache           cache.CacheService
+	tx              domain.TxManager
+	logger          logger.Logger
+	metrics         metrics.Recorder
+	cfg             config.ExpiryConfig
+}
+
+// NewExpirySweeper creates a new instance of ExpirySweeper
+func NewExpirySweeper(
+	transactionRepo transaction.Repository,
+	outboxRepo outbox.Repository,
+	cache cache.CacheService,
+	tx domain.TxManager,
+	logger logger.Logger,
+	metrics metrics.Recorder,
+	cfg config.ExpiryConfig,
+) *ExpirySweeper {
+	return &ExpirySweeper{
+		transactionRepo: transactionRepo,
+		outboxRepo:      outboxRepo,
+		cache:           cache,
+		tx:              tx,
+		logger:          logger,
+		metrics:         metrics,
+		cfg:             cfg,
+	}
+}
+
+// Run sweeps expired top-ups until ctx is cancelled
+func (s *ExpirySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.cfg.Interval) * time.Second)
+	defer ticker.Stop()
+
+	s.logger.Info("Expiry sweeper started", nil)
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Expiry sweeper stopped", nil)
+			return
+		case <-ticker.C:
+			for {
+				n, err := s.SweepOnce(ctx)
+				if err != nil {
+					s.logger.Error("Expiry sweep failed", map[string]interface{}{"error": err.Error()})
+					break
+				}
+				if n < s.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// SweepOnce expires one batch of overdue top-ups and returns how many were expired.
+// Rows are locked with SKIP LOCKED, so several sweepers can run side by side.
+func (s *ExpirySweeper) SweepOnce(ctx context.Context) (int, error) {
+	var expired []transaction.Transaction
+	err := runInTx(ctx, s.tx, func(txCtx context.Context) error {
+		transactions, err := s.transactionRepo.FindExpired(txCtx, time.Now(), s.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range transactions {
+			if err := expireTopup(txCtx, s.transactionRepo, s.outboxRepo, &transactions[i]); err != nil {
+				return err
+			}
+		}
+		expired = transactions
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, tx := range expired {
+		s.metrics.TopupExpired(tx.PaymentMethod.String(), tx.Amount.Amount())
+		_ = s.cache.Delete(context.WithoutCancel(ctx), getTransactionCacheKey(tx.ID))
+	}
+	if len(expired) > 0 {
+		s.logger.Info("Expired overdue top-ups", map[string]interface{}{"count": len(expired)})
+	}
+	return len(expired), nil
+}
+
+// expireTopup marks a verified top-up expired and records topup.expired using the transaction carried by ctx.
+// It returns ErrNotFound if the top-up is no longer verified.
+func expireTopup(ctx context.Context, transactionRepo transaction.Repository, outboxRepo outbox.Repository, tx *transaction.Transaction) error {
+	status := vo.StatusVerified
+	err := transactionRepo.Update(ctx, &transaction.TransactionFilter{ID: &tx.ID, Status: &status}, transaction.Transaction{
+		Status: vo.StatusExpired,
+	})
+	if err != nil {
+		return err
+	}
+	tx.Status = vo.StatusExpired
+	message, err := newOutboxMessage(event.TypeTopupExpired, event.AggregateTransaction, tx.ID, newTopupPayload(*tx))
+	if err != nil {
+		return err
+	}
+	_, err = outboxRepo.Create(ctx, message)
+	return err
+}
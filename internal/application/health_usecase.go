@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/health"
+)
+
+type HealthUsecase interface {
+	Readiness(ctx context.Context) health.Report
+	// MarkShuttingDown makes every later readiness check fail so load balancers stop sending traffic
+	MarkShuttingDown()
+}
+
+// HealthUsecaseImpl runs dependency checks for readiness probes
+type HealthUsecaseImpl struct {
+	checks       []health.Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewHealthUsecase creates a new instance of HealthUsecase
+func NewHealthUsecase(timeout time.Duration, checks ...health.Check) HealthUsecase {
+	return &HealthUsecaseImpl{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// MarkShuttingDown flips readiness to not-ready for the rest of the process lifetime
+func (uc *HealthUsecaseImpl) MarkShuttingDown() {
+	uc.shuttingDown.Store(true)
+}
+
+// Readiness runs every check concurrently, each bounded by the configured timeout
+func (uc *HealthUsecaseImpl) Readiness(ctx context.Context) health.Report {
+	if uc.shuttingDown.Load() {
+		return health.Report{Status: health.StatusShuttingDown, Checks: map[string]health.Result{}}
+	}
+
+	results := make([]health.Result, len(uc.checks))
+	done := make(chan struct{}, len(uc.checks))
+	for i, check := range uc.checks {
+		go func(i int, check health.Check) {
+			defer func() { done <- struct{}{} }()
+			checkCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+			defer cancel()
+			start := time.Now()
+			details, err := check.Probe(checkCtx)
+			result := health.Result{Status: health.StatusUp, Critical: check.Critical, Latency: time.Since(start), Details: details}
+			if err != nil {
+				result.Status = health.StatusDown
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}(i, check)
+	}
+	for range uc.checks {
+		<-done
+	}
+
+	report := health.Report{Status: health.StatusReady, Checks: make(map[string]health.Result, len(uc.checks))}
+	for i, check := range uc.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+		if result.Status == health.StatusUp {
+			continue
+		}
+		if check.Critical {
+			report.Status = health.StatusNotReady
+		} else if report.Status == health.StatusReady {
+			report.Status = health.StatusDegraded
+		}
+	}
+	return report
+}
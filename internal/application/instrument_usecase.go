@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+)
+
+// InstrumentUsecase manages the payment instruments users save for top-ups
+type InstrumentUsecase interface {
+	Save(ctx context.Context, spec instrument.Spec) (instrument.Instrument, error)
+	List(ctx context.Context, userID uint) ([]instrument.Instrument, error)
+	Delete(ctx context.Context, userID, instrumentID uint) error
+}
+
+type InstrumentUsecaseImpl struct {
+	instrumentRepo instrument.Repository
+	userRepo       user.Repository
+	ruleRepo       autotopup.Repository
+	logger         logger.Logger
+}
+
+// NewInstrumentUsecase creates a new instance of InstrumentUsecase
+func NewInstrumentUsecase(
+	instrumentRepo instrument.Repository,
+	userRepo user.Repository,
+	ruleRepo autotopup.Repository,
+	logger logger.Logger,
+) InstrumentUsecase {
+	return &InstrumentUsecaseImpl{
+		instrumentRepo: instrumentRepo,
+		userRepo:       userRepo,
+		ruleRepo:       ruleRepo,
+		logger:         logger,
+	}
+}
+
+// Save stores a tokenized card or bank account for the user
+func (uc *InstrumentUsecaseImpl) Save(ctx context.Context, spec instrument.Spec) (instrument.Instrument, error) {
+	now := time.Now()
+	i, err := newInstrument(spec, now)
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	if _, err := uc.userRepo.FindById(ctx, spec.UserID); err != nil {
+		return instrument.Instrument{}, err
+	}
+	i.CreatedAt = now
+	if i.ID, err = uc.instrumentRepo.Create(ctx, i); err != nil {
+		return instrument.Instrument{}, err
+	}
+
+	uc.logger.WithContext(ctx).Info("Payment instrument saved", map[string]interface{}{
+		"instrument_id": i.ID,
+		"user_id":       i.UserID,
+		"type":          i.Type.String(),
+	})
+	return i, nil
+}
+
+// List returns the instruments of a user
+func (uc *InstrumentUsecaseImpl) List(ctx context.Context, userID uint) ([]instrument.Instrument, error) {
+	return uc.instrumentRepo.FindByUser(ctx, userID)
+}
+
+// Delete removes an instrument of a user unless an auto top-up rule still charges it
+func (uc *InstrumentUsecaseImpl) Delete(ctx context.Context, userID, instrumentID uint) error {
+	rules, err := uc.ruleRepo.FindAll(ctx, &autotopup.RuleFilter{UserID: &userID})
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.InstrumentID == instrumentID {
+			return errs.ErrInstrumentInUse
+		}
+	}
+	if err := uc.instrumentRepo.Delete(ctx, instrumentID, userID); err != nil {
+		return err
+	}
+
+	uc.logger.WithContext(ctx).Info("Payment instrument deleted", map[string]interface{}{
+		"instrument_id": instrumentID,
+		"user_id":       userID,
+	})
+	return nil
+}
+
+// newInstrument validates spec into an instrument that is not yet saved
+func newInstrument(spec instrument.Spec, now time.Time) (instrument.Instrument, error) {
+	invalid := func(problem string) (instrument.Instrument, error) {
+		return instrument.Instrument{}, fmt.Errorf("%w: %s", errs.ErrInvalidInstrument, problem)
+	}
+	instrumentType, err := instrument.NewType(spec.Type)
+	if err != nil {
+		return invalid("type must be card or bank_account")
+	}
+	token := strings.TrimSpace(spec.Token)
+	if token == "" || len(token) > 255 {
+		return invalid("token is required and must be at most 255 characters")
+	}
+	if !isLast4(spec.Last4) {
+		return invalid("last4 must be the last 4 digits")
+	}
+
+	i := instrument.Instrument{UserID: spec.UserID, Type: instrumentType, Token: token}
+	switch instrumentType {
+	case instrument.TypeCard:
+		card := instrument.Card{
+			Brand:    strings.ToLower(strings.TrimSpace(spec.Brand)),
+			Last4:    spec.Last4,
+			ExpMonth: spec.ExpMonth,
+			ExpYear:  spec.ExpYear,
+		}
+		switch {
+		case card.Brand == "" || len(card.Brand) > 30:
+			return invalid("brand is required and must be at most 30 characters")
+		case card.ExpMonth < 1 || card.ExpMonth > 12:
+			return invalid("exp_month must be between 1 and 12")
+		case card.ExpYear < 2000 || card.ExpYear > 9999:
+			return invalid("exp_year must be a four-digit year")
+		case card.ExpiredAt(now):
+			return instrument.Instrument{}, errs.ErrInstrumentExpired
+		}
+		i.Card = &card
+	case instrument.TypeBankAccount:
+		account := instrument.BankAccount{
+			BankName:      strings.TrimSpace(spec.BankName),
+			AccountHolder: strings.TrimSpace(spec.AccountHolder),
+			Last4:         spec.Last4,
+		}
+		if account.BankName == "" || len(account.BankName) > 100 || account.AccountHolder == "" || len(account.AccountHolder) > 100 {
+			return invalid("bank_name and account_holder are required and must be at most 100 characters")
+		}
+		i.BankAccount = &account
+	}
+	return i, nil
+}
+
+func isLast4(s string) bool {
+	if len(s) != 4 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
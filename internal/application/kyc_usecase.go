@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/storage"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+type KYCUsecase interface {
+	SubmitDocument(ctx context.Context, userID uint, documentType string, filename string, content io.Reader) (kyc.Document, error)
+	GetStatus(ctx context.Context, userID uint) (user.User, []kyc.Document, error)
+	ListPending(ctx context.Context) ([]kyc.Document, error)
+	Approve(ctx context.Context, documentID uint, tier string, note string) (user.User, error)
+	Reject(ctx context.Context, documentID uint, note string) (user.User, error)
+}
+
+// KYCUsecaseImpl handles identity document submission and admin review
+type KYCUsecaseImpl struct {
+	userRepo user.Repository
+	kycRepo  kyc.Repository
+	storage  storage.BlobStorage
+	tx       domain.TxManager
+	logger   logger.Logger
+}
+
+// NewKYCUsecase creates a new instance of KYCUsecase
+func NewKYCUsecase(
+	userRepo user.Repository,
+	kycRepo kyc.Repository,
+	storage storage.BlobStorage,
+	tx domain.TxManager,
+	logger logger.Logger,
+) KYCUsecase {
+	return &KYCUsecaseImpl{
+		userRepo: userRepo,
+		kycRepo:  kycRepo,
+		storage:  storage,
+		tx:       tx,
+		logger:   logger,
+	}
+}
+
+// SubmitDocument stores an identity document and moves the user into "pending" KYC status
+func (uc *KYCUsecaseImpl) SubmitDocument(ctx context.Context, userID uint, documentType string, filename string, content io.Reader) (kyc.Document, error) {
+	docType, err := vo.NewDocumentType(documentType)
+	if err != nil {
+		return kyc.Document{}, err
+	}
+	u, err := uc.userRepo.FindById(ctx, userID)
+	if err != nil {
+		return kyc.Document{}, err
+	}
+	if u.KYCStatus == vo.KYCStatusPending {
+		return kyc.Document{}, errs.ErrKYCAlreadyPending
+	}
+
+	storageKey := getKYCDocumentStorageKey(userID, filename)
+	if err := uc.storage.Put(ctx, storageKey, content); err != nil {
+		return kyc.Document{}, err
+	}
+
+	document := kyc.Document{
+		UserID:       userID,
+		DocumentType: docType,
+		StorageKey:   storageKey,
+		Status:       vo.KYCStatusPending,
+		CreatedAt:    time.Now(),
+	}
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		// The check above only saves an upload; this conditional update is what stops two
+		// concurrent submissions from both becoming pending
+		if err := uc.userRepo.MarkKYCPending(txCtx, userID); err != nil {
+			return err
+		}
+		id, err := uc.kycRepo.Create(txCtx, document)
+		if err != nil {
+			return err
+		}
+		document.ID = id
+		return nil
+	})
+	if err != nil {
+		// The blob is useless without its database record
+		if delErr := uc.storage.Delete(context.Background(), storageKey); delErr != nil {
+			uc.logger.WithContext(ctx).Warn("Failed to remove orphaned KYC document", map[string]interface{}{"key": storageKey, "error": delErr.Error()})
+		}
+		return kyc.Document{}, err
+	}
+
+	uc.logger.WithContext(ctx).Info("KYC document submitted", map[string]interface{}{
+		"user_id":       userID,
+		"document_id":   document.ID,
+		"document_type": docType.String(),
+	})
+	return document, nil
+}
+
+// GetStatus returns the user's current KYC status together with their submitted documents
+func (uc *KYCUsecaseImpl) GetStatus(ctx context.Context, userID uint) (user.User, []kyc.Document, error) {
+	u, err := uc.userRepo.FindById(ctx, userID)
+	if err != nil {
+		return user.User{}, nil, err
+	}
+	documents, err := uc.kycRepo.FindAll(&kyc.DocumentFilter{UserID: &userID})
+	if err != nil {
+		return user.User{}, nil, err
+	}
+	return u, documents, nil
+}
+
+// ListPending returns every document waiting for review
+func (uc *KYCUsecaseImpl) ListPending(ctx context.Context) ([]kyc.Document, error) {
+	status := vo.KYCStatusPending
+	return uc.kycRepo.FindAll(&kyc.DocumentFilter{Status: &status})
+}
+
+// Approve marks a pending document as approved and promotes the user to the given tier
+func (uc *KYCUsecaseImpl) Approve(ctx context.Context, documentID uint, tier string, note string) (user.User, error) {
+	kycTier, err := vo.NewKYCTier(tier)
+	if err != nil {
+		return user.User{}, err
+	}
+	return uc.review(ctx, documentID, vo.KYCStatusApproved, kycTier, note)
+}
+
+// Reject marks a pending document as rejected; the user keeps their current tier
+func (uc *KYCUsecaseImpl) Reject(ctx context.Context, documentID uint, note string) (user.User, error) {
+	return uc.review(ctx, documentID, vo.KYCStatusRejected, "", note)
+}
+
+func (uc *KYCUsecaseImpl) review(ctx context.Context, documentID uint, status vo.KYCStatus, tier vo.KYCTier, note string) (user.User, error) {
+	document, err := uc.kycRepo.FindById(documentID)
+	if err != nil {
+		return user.User{}, err
+	}
+	if document.Status != vo.KYCStatusPending {
+		return user.User{}, errs.ErrKYCNotPending
+	}
+
+	now := time.Now()
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		// Filtering on status makes a concurrent second review fail instead of overwriting
+		pending := vo.KYCStatusPending
+		err := uc.kycRepo.Update(txCtx, &kyc.DocumentFilter{ID: &documentID, Status: &pending}, kyc.Document{
+			Status:     status,
+			ReviewNote: note,
+			ReviewedAt: &now,
+		})
+		if err != nil {
+			if err == errs.ErrNotFound {
+				return errs.ErrKYCNotPending
+			}
+			return err
+		}
+		return uc.userRepo.Update(txCtx, user.User{ID: document.UserID, KYCStatus: status, KYCTier: tier})
+	})
+	if err != nil {
+		return user.User{}, err
+	}
+
+	uc.logger.WithContext(ctx).Info("KYC document reviewed", map[string]interface{}{
+		"user_id":     document.UserID,
+		"document_id": documentID,
+		"status":      status.String(),
+		"tier":        tier.String(),
+	})
+	return uc.userRepo.FindById(ctx, document.UserID)
+}
+
+// parsePaymentMethods converts configured payment method names, ignoring unknown ones
+func parsePaymentMethods(methods []string) []vo.PaymentMethod {
+	result := make([]vo.PaymentMethod, 0, len(methods))
+	for _, m := range methods {
+		if pm, err := vo.NewPaymentMethod(m); err == nil {
+			result = append(result, pm)
+		}
+	}
+	return result
+}
+
+// Helper function to generate storage key for a KYC document
+func getKYCDocumentStorageKey(userID uint, filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return fmt.Sprintf("kyc/%d/%d%s", userID, time.Now().UnixNano(), ext)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kycUserRepo serves reads from a snapshot taken before any submission, like two requests that
+// both read the user before either has written, while MarkKYCPending sees the current status
+type kycUserRepo struct {
+	user.Repository
+	mu       sync.Mutex
+	snapshot user.User
+	status   vo.KYCStatus
+}
+
+func (r *kycUserRepo) FindById(ctx context.Context, id uint) (user.User, error) {
+	if id != r.snapshot.ID {
+		return user.User{}, errs.ErrNotFound
+	}
+	return r.snapshot, nil
+}
+
+func (r *kycUserRepo) MarkKYCPending(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status == vo.KYCStatusPending {
+		return errs.ErrKYCAlreadyPending
+	}
+	r.status = vo.KYCStatusPending
+	return nil
+}
+
+type fakeKYCRepo struct {
+	kyc.Repository
+	mu        sync.Mutex
+	documents []kyc.Document
+}
+
+func (r *fakeKYCRepo) Create(ctx context.Context, document kyc.Document) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.documents = append(r.documents, document)
+	return uint(len(r.documents)), nil
+}
+
+type memoryBlobStorage struct {
+	mu    sync.Mutex
+	blobs map[string]string
+}
+
+func (s *memoryBlobStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = string(data)
+	return nil
+}
+
+func (s *memoryBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(data)), nil
+}
+
+func (s *memoryBlobStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+func newKYCFixture() (KYCUsecase, *kycUserRepo, *fakeKYCRepo, *memoryBlobStorage) {
+	users := &kycUserRepo{snapshot: user.User{ID: 1, KYCStatus: vo.KYCStatusNone}, status: vo.KYCStatusNone}
+	documents := &fakeKYCRepo{}
+	blobs := &memoryBlobStorage{blobs: map[string]string{}}
+	return NewKYCUsecase(users, documents, blobs, nopTxManager{}, nopLogger{}), users, documents, blobs
+}
+
+func TestKYCSubmitDocumentMarksTheUserPending(t *testing.T) {
+	uc, users, documents, blobs := newKYCFixture()
+
+	document, err := uc.SubmitDocument(context.Background(), 1, "passport", "scan.PNG", strings.NewReader("image"))
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), document.ID)
+	assert.Equal(t, vo.KYCStatusPending, document.Status)
+	assert.True(t, strings.HasSuffix(document.StorageKey, ".png"))
+	assert.Equal(t, vo.KYCStatusPending, users.status)
+	assert.Len(t, documents.documents, 1)
+	assert.Equal(t, map[string]string{document.StorageKey: "image"}, blobs.blobs)
+}
+
+func TestKYCSubmitDocumentRejectsASecondPendingSubmission(t *testing.T) {
+	uc, _, documents, blobs := newKYCFixture()
+
+	// Both submissions read the user as not pending; only one may move it into review
+	_, err := uc.SubmitDocument(context.Background(), 1, "passport", "first.png", strings.NewReader("first"))
+	require.NoError(t, err)
+	_, err = uc.SubmitDocument(context.Background(), 1, "national_id", "second.png", strings.NewReader("second"))
+	assert.ErrorIs(t, err, errs.ErrKYCAlreadyPending)
+
+	assert.Len(t, documents.documents, 1)
+	assert.Len(t, blobs.blobs, 1, "the rejected submission's upload is removed")
+}
+
+func TestKYCSubmitDocumentConcurrentSubmissions(t *testing.T) {
+	uc, _, documents, _ := newKYCFixture()
+
+	const submissions = 10
+	var wg sync.WaitGroup
+	results := make(chan error, submissions)
+	for i := 0; i < submissions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := uc.SubmitDocument(context.Background(), 1, "passport", "scan.png", strings.NewReader("image"))
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var accepted int
+	for err := range results {
+		if err == nil {
+			accepted++
+			continue
+		}
+		assert.ErrorIs(t, err, errs.ErrKYCAlreadyPending)
+	}
+	assert.Equal(t, 1, accepted)
+	assert.Len(t, documents.documents, 1)
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+)
+
+// OutboxRelay moves events from the outbox table to an EventPublisher.
+// Delivery is at-least-once: a crash between Publish and MarkPublished republishes the event.
+type OutboxRelay struct {
+	outboxRepo outbox.Repository
+	publisher  event.EventPublisher
+	tx         domain.TxManager
+	logger     logger.Logger
+	cfg        config.OutboxConfig
+}
+
+// NewOutboxRelay creates a new instance of OutboxRelay
+func NewOutboxRelay(
+	outboxRepo outbox.Repository,
+	publisher event.EventPublisher,
+	tx domain.TxManager,
+	logger logger.Logger,
+	cfg config.OutboxConfig,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		tx:         tx,
+		logger:     logger,
+		cfg:        cfg,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(r.cfg.PollInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	r.logger.Info("Outbox relay started", nil)
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped", nil)
+			return
+		case <-ticker.C:
+			// Keep draining while full batches come back
+			for {
+				n, err := r.RelayOnce(ctx)
+				if err != nil {
+					r.logger.Error("Outbox relay failed", map[string]interface{}{"error": err.Error()})
+					break
+				}
+				if n < r.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due messages and returns how many were processed
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	var processed int
+	err := runInTx(ctx, r.tx, func(txCtx context.Context) error {
+		messages, err := r.outboxRepo.FetchPending(txCtx, r.cfg.BatchSize, time.Now())
+		if err != nil {
+			return err
+		}
+		for _, m := range messages {
+			if err := r.deliver(ctx, txCtx, m); err != nil {
+				return err
+			}
+			processed++
+		}
+		return nil
+	})
+	return processed, err
+}
+
+// deliver publishes a single message and records the outcome inside the relay transaction
+func (r *OutboxRelay) deliver(ctx, txCtx context.Context, m outbox.Message) error {
+	pubErr := r.publisher.Publish(ctx, m.ToEvent())
+	if pubErr == nil {
+		return r.outboxRepo.MarkPublished(txCtx, m.ID, time.Now())
+	}
+
+	attempts := m.Attempts + 1
+	if attempts >= r.cfg.MaxAttempts {
+		r.logger.Error("Outbox message dead-lettered", map[string]interface{}{
+			"message_id": m.ID,
+			"event_type": m.EventType.String(),
+			"attempts":   attempts,
+			"error":      pubErr.Error(),
+		})
+		return r.outboxRepo.MarkDead(txCtx, m.ID, attempts, pubErr.Error())
+	}
+
+	r.logger.Warn("Failed to publish outbox message", map[string]interface{}{
+		"message_id": m.ID,
+		"event_type": m.EventType.String(),
+		"attempts":   attempts,
+		"error":      pubErr.Error(),
+	})
+	delay := backoffWithJitter(attempts, time.Duration(r.cfg.BaseBackoff)*time.Second, time.Duration(r.cfg.MaxBackoff)*time.Second)
+	return r.outboxRepo.MarkFailed(txCtx, m.ID, attempts, time.Now().Add(delay), pubErr.Error())
+}
+
+// backoffWithJitter returns an exponential delay with full jitter, capped at maxDelay
+func backoffWithJitter(attempts int, base, maxDelay time.Duration) time.Duration {
+	delay := time.Duration(float64(base) * math.Pow(2, float64(attempts-1)))
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+)
+
+type OutboxUsecase interface {
+	ListDeadLetters(ctx context.Context) ([]outbox.Message, error)
+	Requeue(ctx context.Context, messageID uint) error
+}
+
+// OutboxUsecaseImpl exposes dead-lettered outbox messages to operators
+type OutboxUsecaseImpl struct {
+	outboxRepo outbox.Repository
+	logger     logger.Logger
+}
+
+// NewOutboxUsecase creates a new instance of OutboxUsecase
+func NewOutboxUsecase(outboxRepo outbox.Repository, logger logger.Logger) OutboxUsecase {
+	return &OutboxUsecaseImpl{
+		outboxRepo: outboxRepo,
+		logger:     logger,
+	}
+}
+
+// ListDeadLetters returns messages the relay gave up on
+func (uc *OutboxUsecaseImpl) ListDeadLetters(ctx context.Context) ([]outbox.Message, error) {
+	status := outbox.StatusDead
+	return uc.outboxRepo.FindAll(&outbox.MessageFilter{Status: &status})
+}
+
+// Requeue puts a dead-lettered message back in the queue with a fresh attempt budget
+func (uc *OutboxUsecaseImpl) Requeue(ctx context.Context, messageID uint) error {
+	if err := uc.outboxRepo.Requeue(ctx, messageID); err != nil {
+		return err
+	}
+	uc.logger.WithContext(ctx).Info("Outbox message requeued", map[string]interface{}{"message_id": messageID})
+	return nil
+}
+
+// newOutboxMessage encodes payload into a pending outbox message
+func newOutboxMessage(eventType event.Type, aggregateType string, aggregateID uint, payload interface{}) (outbox.Message, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return outbox.Message{}, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	now := time.Now()
+	return outbox.Message{
+		AggregateType: aggregateType,
+		AggregateID:   strconv.FormatUint(uint64(aggregateID), 10),
+		EventType:     eventType,
+		Payload:       data,
+		Status:        outbox.StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+func newTopupPayload(tx transaction.Transaction) event.TopupPayload {
+	return event.TopupPayload{
+		TransactionID: tx.ID,
+		UserID:        tx.UserID,
+		Amount:        tx.Amount.Amount(),
+		Fee:           tx.Fee.Amount(),
+		Bonus:         tx.Bonus.Amount(),
+		NetAmount:     tx.NetAmount().Amount(),
+		Campaign:      tx.Campaign,
+		PaymentMethod: tx.PaymentMethod.String(),
+		InstrumentID:  tx.InstrumentID,
+		Status:        tx.Status.String(),
+	}
+}
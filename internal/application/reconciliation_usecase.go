@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// referenceLookupChunk bounds how many provider references are looked up per query
+const referenceLookupChunk = 1000
+
+// ReconciliationUsecase compares payment provider settlement files with our top-ups
+type ReconciliationUsecase interface {
+	Reconcile(ctx context.Context, spec reconciliation.Spec, file io.Reader) (reconciliation.Report, error)
+	List(ctx context.Context, filter *reconciliation.ReportFilter) ([]reconciliation.Report, error)
+	// Get returns a report with its items, only those with status when it is not empty
+	Get(ctx context.Context, id uint, status string) (reconciliation.Report, error)
+}
+
+type ReconciliationUsecaseImpl struct {
+	reportRepo      reconciliation.Repository
+	transactionRepo transaction.Repository
+	tx              domain.TxManager
+	logger          logger.Logger
+	provider        string
+}
+
+// NewReconciliationUsecase creates a new instance of ReconciliationUsecase. Settlement files are
+// attributed to provider unless the spec names another.
+func NewReconciliationUsecase(
+	reportRepo reconciliation.Repository,
+	transactionRepo transaction.Repository,
+	tx domain.TxManager,
+	logger logger.Logger,
+	provider string,
+) ReconciliationUsecase {
+	return &ReconciliationUsecaseImpl{
+		reportRepo:      reportRepo,
+		transactionRepo: transactionRepo,
+		tx:              tx,
+		logger:          logger,
+		provider:        provider,
+	}
+}
+
+// Reconcile parses a settlement file, matches its records to transactions by provider reference
+// and amount, and stores the report. Top-ups completed on the settlement date that the
+// file does not mention are reported as missing in theirs.
+func (uc *ReconciliationUsecaseImpl) Reconcile(ctx context.Context, spec reconciliation.Spec, file io.Reader) (reconciliation.Report, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationUsecase.Reconcile", trace.WithAttributes(
+		attribute.String("reconciliation.file", spec.FileName),
+	))
+	report, err := uc.reconcile(ctx, spec, file)
+	endSpan(span, err)
+	return report, err
+}
+
+func (uc *ReconciliationUsecaseImpl) reconcile(ctx context.Context, spec reconciliation.Spec, file io.Reader) (reconciliation.Report, error) {
+	report, err := uc.newReport(spec)
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	ctx = logger.ContextWithFields(ctx, uc.logger, map[string]interface{}{
+		"provider":        report.Provider,
+		"settlement_date": report.SettlementDate.Format(time.DateOnly),
+	})
+	parser, err := reconciliation.NewParser(report.Format)
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	records, err := parser.Parse(file)
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	report.Records = len(records)
+
+	settled, byReference, err := uc.loadTransactions(report.SettlementDate, records)
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	report.Items = matchSettlement(records, settled, byReference)
+	for _, item := range report.Items {
+		report.Count(item.Status)
+	}
+
+	// Store the report and its items together, so a failed run leaves nothing behind
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		id, err := uc.reportRepo.Create(txCtx, report)
+		if err != nil {
+			return err
+		}
+		report.ID = id
+		for i := range report.Items {
+			report.Items[i].ReportID = id
+		}
+		return uc.reportRepo.CreateItems(txCtx, report.Items)
+	})
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+
+	fields := map[string]interface{}{
+		"report_id":         report.ID,
+		"records":           report.Records,
+		"matched":           report.Matched,
+		"missing_in_ours":   report.MissingInOurs,
+		"missing_in_theirs": report.MissingInTheirs,
+		"amount_mismatch":   report.AmountMismatch,
+	}
+	if report.Discrepancies() > 0 {
+		uc.logger.WithContext(ctx).Warn("Settlement does not reconcile", fields)
+	} else {
+		uc.logger.WithContext(ctx).Info("Settlement reconciled", fields)
+	}
+	return report, nil
+}
+
+// List returns stored reports, newest first
+func (uc *ReconciliationUsecaseImpl) List(ctx context.Context, filter *reconciliation.ReportFilter) ([]reconciliation.Report, error) {
+	return uc.reportRepo.FindAll(ctx, filter)
+}
+
+func (uc *ReconciliationUsecaseImpl) Get(ctx context.Context, id uint, status string) (reconciliation.Report, error) {
+	var statusFilter *reconciliation.Status
+	if status != "" {
+		s, err := reconciliation.NewStatus(status)
+		if err != nil {
+			return reconciliation.Report{}, fmt.Errorf("%w: unknown status %q", errs.ErrInvalidReconciliation, status)
+		}
+		statusFilter = &s
+	}
+	report, err := uc.reportRepo.FindByID(ctx, id)
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	if report.Items, err = uc.reportRepo.FindItems(ctx, id, statusFilter); err != nil {
+		return reconciliation.Report{}, err
+	}
+	return *report, nil
+}
+
+func (uc *ReconciliationUsecaseImpl) newReport(spec reconciliation.Spec) (reconciliation.Report, error) {
+	provider := strings.TrimSpace(spec.Provider)
+	if provider == "" {
+		provider = uc.provider
+	}
+	var problem string
+	switch {
+	case len(provider) > 50:
+		problem = "provider must be at most 50 characters"
+	case spec.SettlementDate.IsZero():
+		problem = "settlement date is required"
+	case len(spec.FileName) > 255:
+		problem = "file name must be at most 255 characters"
+	case strings.TrimSpace(spec.Actor) == "":
+		problem = "actor is required"
+	}
+	if problem != "" {
+		return reconciliation.Report{}, fmt.Errorf("%w: %s", errs.ErrInvalidReconciliation, problem)
+	}
+	format, err := reconciliation.NewFormat(spec.Format, spec.FileName)
+	if err != nil {
+		return reconciliation.Report{}, err
+	}
+	return reconciliation.Report{
+		Provider:       provider,
+		SettlementDate: utcDate(spec.SettlementDate),
+		FileName:       spec.FileName,
+		Format:         format,
+		CreatedBy:      strings.TrimSpace(spec.Actor),
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// loadTransactions returns the provider top-ups completed on the settlement date, and
+// every transaction, of any date or status, carrying a provider reference from the records
+func (uc *ReconciliationUsecaseImpl) loadTransactions(date time.Time, records []reconciliation.Record) ([]transaction.Transaction, map[string]transaction.Transaction, error) {
+	completed := vo.StatusCompleted
+	dayEnd := date.AddDate(0, 0, 1)
+	settled, err := uc.transactionRepo.FindAll(&transaction.TransactionFilter{
+		Status:               &completed,
+		CompletedAfter:       &date,
+		CompletedBefore:      &dayEnd,
+		HasProviderReference: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(settled, func(i, j int) bool { return settled[i].ID < settled[j].ID })
+
+	byReference := make(map[string]transaction.Transaction, len(records))
+	for _, t := range settled {
+		byReference[t.ProviderReference] = t
+	}
+	var lookup []string
+	for _, r := range records {
+		if _, ok := byReference[r.ProviderReference]; !ok {
+			lookup = append(lookup, r.ProviderReference)
+		}
+	}
+	for start := 0; start < len(lookup); start += referenceLookupChunk {
+		end := min(start+referenceLookupChunk, len(lookup))
+		found, err := uc.transactionRepo.FindAll(&transaction.TransactionFilter{ProviderReferences: lookup[start:end]})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range found {
+			byReference[t.ProviderReference] = t
+		}
+	}
+	return settled, byReference, nil
+}
+
+// matchSettlement classifies each record, in file order, followed by the settled transactions
+// the file does not mention. A record whose transaction never completed, or that repeats a
+// reference already seen, is money the provider holds that no wallet was credited with, so it
+// counts as missing in ours.
+func matchSettlement(records []reconciliation.Record, settled []transaction.Transaction, byReference map[string]transaction.Transaction) []reconciliation.Item {
+	items := make([]reconciliation.Item, 0, len(records))
+	seen := make(map[string]bool, len(records))
+	for _, r := range records {
+		item := reconciliation.Item{
+			Status:            reconciliation.StatusMissingInOurs,
+			ProviderReference: r.ProviderReference,
+			ProviderAmount:    r.Amount,
+		}
+		t, ok := byReference[r.ProviderReference]
+		if ok {
+			item.TransactionID = t.ID
+		}
+		if ok && t.Status == vo.StatusCompleted && !seen[r.ProviderReference] {
+			item.TransactionAmount = t.Amount
+			item.Status = reconciliation.StatusMatched
+			if !sameAmount(r.Amount, t.Amount) {
+				item.Status = reconciliation.StatusAmountMismatch
+			}
+		}
+		seen[r.ProviderReference] = true
+		items = append(items, item)
+	}
+	for _, t := range settled {
+		if seen[t.ProviderReference] {
+			continue
+		}
+		items = append(items, reconciliation.Item{
+			Status:            reconciliation.StatusMissingInTheirs,
+			ProviderReference: t.ProviderReference,
+			TransactionID:     t.ID,
+			TransactionAmount: t.Amount,
+		})
+	}
+	return items
+}
+
+// sameAmount compares amounts to the cent
+func sameAmount(a, b vo.Money) bool {
+	return math.Round(a.Amount()*100) == math.Round(b.Amount()*100)
+}
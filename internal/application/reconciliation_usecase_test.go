@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func money(t *testing.T, amount float64) vo.Money {
+	t.Helper()
+	m, err := vo.NewMoney(amount)
+	require.NoError(t, err)
+	return m
+}
+
+func TestMatchSettlement(t *testing.T) {
+	record := func(reference string, amount float64) reconciliation.Record {
+		return reconciliation.Record{ProviderReference: reference, Amount: money(t, amount)}
+	}
+	topup := func(id uint, reference string, amount float64, status vo.TransactionStatus) transaction.Transaction {
+		return transaction.Transaction{ID: id, ProviderReference: reference, Amount: money(t, amount), Status: status}
+	}
+	completed := vo.StatusCompleted
+
+	tests := []struct {
+		name    string
+		records []reconciliation.Record
+		settled []transaction.Transaction
+		// others carry a reference from the file but were not completed on the settlement date
+		others []transaction.Transaction
+		want   []reconciliation.Item
+	}{
+		{
+			name:    "matched",
+			records: []reconciliation.Record{record("ref-1", 100)},
+			settled: []transaction.Transaction{topup(1, "ref-1", 100, completed)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMatched, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 100), TransactionAmount: money(t, 100)},
+			},
+		},
+		{
+			name:    "amounts equal to the cent match",
+			records: []reconciliation.Record{record("ref-1", 100.004), record("ref-2", 0.1+0.2)},
+			settled: []transaction.Transaction{topup(1, "ref-1", 100, completed), topup(2, "ref-2", 0.3, completed)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMatched, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 100.004), TransactionAmount: money(t, 100)},
+				{Status: reconciliation.StatusMatched, ProviderReference: "ref-2", TransactionID: 2, ProviderAmount: money(t, 0.1+0.2), TransactionAmount: money(t, 0.3)},
+			},
+		},
+		{
+			name:    "a cent apart is an amount mismatch",
+			records: []reconciliation.Record{record("ref-1", 100.01)},
+			settled: []transaction.Transaction{topup(1, "ref-1", 100, completed)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusAmountMismatch, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 100.01), TransactionAmount: money(t, 100)},
+			},
+		},
+		{
+			name:    "unknown reference is missing in ours",
+			records: []reconciliation.Record{record("ref-9", 20)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMissingInOurs, ProviderReference: "ref-9", ProviderAmount: money(t, 20)},
+			},
+		},
+		{
+			name:    "transaction that never completed is missing in ours",
+			records: []reconciliation.Record{record("ref-1", 50), record("ref-2", 60)},
+			others:  []transaction.Transaction{topup(1, "ref-1", 50, vo.StatusVerified), topup(2, "ref-2", 60, vo.StatusExpired)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMissingInOurs, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 50)},
+				{Status: reconciliation.StatusMissingInOurs, ProviderReference: "ref-2", TransactionID: 2, ProviderAmount: money(t, 60)},
+			},
+		},
+		{
+			name:    "completed on another day still matches",
+			records: []reconciliation.Record{record("ref-1", 50)},
+			others:  []transaction.Transaction{topup(1, "ref-1", 50, completed)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMatched, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 50), TransactionAmount: money(t, 50)},
+			},
+		},
+		{
+			name:    "duplicate reference counts once",
+			records: []reconciliation.Record{record("ref-1", 100), record("ref-1", 100)},
+			settled: []transaction.Transaction{topup(1, "ref-1", 100, completed)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMatched, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 100), TransactionAmount: money(t, 100)},
+				{Status: reconciliation.StatusMissingInOurs, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 100)},
+			},
+		},
+		{
+			name:    "settled top-up absent from the file is missing in theirs",
+			records: []reconciliation.Record{record("ref-1", 100)},
+			settled: []transaction.Transaction{topup(1, "ref-1", 100, completed), topup(2, "ref-2", 75, completed)},
+			want: []reconciliation.Item{
+				{Status: reconciliation.StatusMatched, ProviderReference: "ref-1", TransactionID: 1, ProviderAmount: money(t, 100), TransactionAmount: money(t, 100)},
+				{Status: reconciliation.StatusMissingInTheirs, ProviderReference: "ref-2", TransactionID: 2, TransactionAmount: money(t, 75)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byReference := make(map[string]transaction.Transaction)
+			for _, tx := range append(tt.settled, tt.others...) {
+				byReference[tx.ProviderReference] = tx
+			}
+			assert.Equal(t, tt.want, matchSettlement(tt.records, tt.settled, byReference))
+		})
+	}
+}
+
+// ledgerTransactionRepo answers FindAll with the filters settlement reconciliation uses
+type ledgerTransactionRepo struct {
+	transaction.Repository
+	transactions []transaction.Transaction
+}
+
+func (r ledgerTransactionRepo) FindAll(filter *transaction.TransactionFilter) ([]transaction.Transaction, error) {
+	var result []transaction.Transaction
+	for _, tx := range r.transactions {
+		switch {
+		case filter.Status != nil && tx.Status != *filter.Status,
+			filter.HasProviderReference && tx.ProviderReference == "",
+			filter.ProviderReferences != nil && !slices.Contains(filter.ProviderReferences, tx.ProviderReference),
+			filter.CompletedAfter != nil && (tx.CompletedAt == nil || tx.CompletedAt.Before(*filter.CompletedAfter)),
+			filter.CompletedBefore != nil && (tx.CompletedAt == nil || !tx.CompletedAt.Before(*filter.CompletedBefore)),
+			filter.CreatedAfter != nil && tx.CreatedAt.Before(*filter.CreatedAfter),
+			filter.CreatedBefore != nil && !tx.CreatedAt.Before(*filter.CreatedBefore):
+			continue
+		}
+		result = append(result, tx)
+	}
+	return result, nil
+}
+
+func TestLoadTransactionsSettlesTopupsOnTheirCompletionDay(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		ts := time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	topup := func(id uint, reference string, created, completed *time.Time) transaction.Transaction {
+		return transaction.Transaction{ID: id, ProviderReference: reference, Amount: money(t, 100), Status: vo.StatusCompleted,
+			CreatedAt: *created, CompletedAt: completed}
+	}
+	repo := ledgerTransactionRepo{transactions: []transaction.Transaction{
+		topup(1, "ref-1", at(17, 23), at(18, 0)),  // verified the day before, confirmed after midnight
+		topup(2, "ref-2", at(18, 12), at(18, 12)), // same day
+		topup(3, "ref-3", at(18, 23), at(19, 1)),  // belongs to the next settlement
+	}}
+	uc := &ReconciliationUsecaseImpl{transactionRepo: repo}
+
+	settled, byReference, err := uc.loadTransactions(date, []reconciliation.Record{{ProviderReference: "ref-3", Amount: money(t, 100)}})
+	require.NoError(t, err)
+	ids := make([]uint, len(settled))
+	for i, tx := range settled {
+		ids[i] = tx.ID
+	}
+	assert.Equal(t, []uint{1, 2}, ids)
+	assert.Equal(t, uint(3), byReference["ref-3"].ID, "a record is still matched to a top-up completed on another day")
+}
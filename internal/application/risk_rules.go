@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/risk"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// VelocityRule fires when a user verifies too many top-ups in a short window
+type VelocityRule struct {
+	transactionRepo transaction.Repository
+	Window          time.Duration
+	MaxCount        int64
+	Score           int
+}
+
+func NewVelocityRule(transactionRepo transaction.Repository, window time.Duration, maxCount int64, score int) *VelocityRule {
+	return &VelocityRule{transactionRepo: transactionRepo, Window: window, MaxCount: maxCount, Score: score}
+}
+
+func (r *VelocityRule) Name() string { return "velocity" }
+
+func (r *VelocityRule) Evaluate(ctx context.Context, in risk.Input) (risk.Result, error) {
+	since := in.Now.Add(-r.Window)
+	// Count every provider-paid top-up together, leaving out voucher redemptions
+	count, err := r.transactionRepo.Count(ctx, &transaction.TransactionFilter{
+		UserID:             &in.User.ID,
+		PaymentMethodNotIn: []vo.PaymentMethod{vo.PaymentMethodVoucher},
+		CreatedAfter:       &since,
+	})
+	if err != nil {
+		return risk.Result{}, err
+	}
+	if count < r.MaxCount {
+		return risk.Result{}, nil
+	}
+	return risk.Result{
+		Score:  r.Score,
+		Reason: fmt.Sprintf("%d top-ups verified in the last %s", count, r.Window),
+	}, nil
+}
+
+// NewAccountRule fires when a recently created account tops up a large amount
+type NewAccountRule struct {
+	MinAccountAge time.Duration
+	LargeAmount   float64
+	Score         int
+}
+
+func NewNewAccountRule(minAccountAge time.Duration, largeAmount float64, score int) *NewAccountRule {
+	return &NewAccountRule{MinAccountAge: minAccountAge, LargeAmount: largeAmount, Score: score}
+}
+
+func (r *NewAccountRule) Name() string { return "new_account_large_amount" }
+
+func (r *NewAccountRule) Evaluate(ctx context.Context, in risk.Input) (risk.Result, error) {
+	if in.Now.Sub(in.User.CreatedAt) >= r.MinAccountAge || in.Amount.Amount() < r.LargeAmount {
+		return risk.Result{}, nil
+	}
+	return risk.Result{
+		Score:  r.Score,
+		Reason: fmt.Sprintf("account younger than %s topping up %s", r.MinAccountAge, in.Amount),
+	}, nil
+}
+
+// FailedHistoryRule fires when a user has repeatedly failed or let top-ups expire
+type FailedHistoryRule struct {
+	transactionRepo transaction.Repository
+	Window          time.Duration
+	MaxCount        int64
+	Score           int
+}
+
+func NewFailedHistoryRule(transactionRepo transaction.Repository, window time.Duration, maxCount int64, score int) *FailedHistoryRule {
+	return &FailedHistoryRule{transactionRepo: transactionRepo, Window: window, MaxCount: maxCount, Score: score}
+}
+
+func (r *FailedHistoryRule) Name() string { return "failed_history" }
+
+func (r *FailedHistoryRule) Evaluate(ctx context.Context, in risk.Input) (risk.Result, error) {
+	since := in.Now.Add(-r.Window)
+	count, err := r.transactionRepo.Count(ctx, &transaction.TransactionFilter{
+		UserID:       &in.User.ID,
+		Statuses:     []vo.TransactionStatus{vo.StatusFailed, vo.StatusExpired},
+		CreatedAfter: &since,
+	})
+	if err != nil {
+		return risk.Result{}, err
+	}
+	if count < r.MaxCount {
+		return risk.Result{}, nil
+	}
+	return risk.Result{
+		Score:  r.Score,
+		Reason: fmt.Sprintf("%d failed or expired top-ups in the last %s", count, r.Window),
+	}, nil
+}
+
+// NearLimitRule fires when the amount sits just under the user's limit
+type NearLimitRule struct {
+	Ratio float64 // fraction of the limit at which the rule fires, e.g. 0.95
+	Score int
+}
+
+func NewNearLimitRule(ratio float64, score int) *NearLimitRule {
+	return &NearLimitRule{Ratio: ratio, Score: score}
+}
+
+func (r *NearLimitRule) Name() string { return "near_limit" }
+
+func (r *NearLimitRule) Evaluate(ctx context.Context, in risk.Input) (risk.Result, error) {
+	if in.Limit <= 0 || in.Amount.Amount() < in.Limit*r.Ratio {
+		return risk.Result{}, nil
+	}
+	return risk.Result{
+		Score:  r.Score,
+		Reason: fmt.Sprintf("amount %s is within %.0f%% of the %.2f limit", in.Amount, (1-r.Ratio)*100, in.Limit),
+	}, nil
+}
+
+// NewRiskEngine builds the default rule set from configuration
+func NewRiskEngine(cfg config.RiskConfig, transactionRepo transaction.Repository) *risk.Engine {
+	return risk.NewEngine(cfg.ChallengeScore, cfg.RejectScore,
+		NewVelocityRule(transactionRepo, time.Duration(cfg.VelocityWindow)*time.Minute, int64(cfg.VelocityMaxCount), cfg.VelocityScore),
+		NewNewAccountRule(time.Duration(cfg.NewAccountAge)*time.Hour, cfg.NewAccountLargeAmount, cfg.NewAccountScore),
+		NewFailedHistoryRule(transactionRepo, time.Duration(cfg.FailedWindow)*time.Hour, int64(cfg.FailedMaxCount), cfg.FailedScore),
+		NewNearLimitRule(cfg.NearLimitRatio, cfg.NearLimitScore),
+	)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+type RiskReviewUsecase interface {
+	ListFlagged(ctx context.Context, decision string) ([]transaction.Transaction, error)
+	ResolveChallenge(ctx context.Context, transactionID uint, approve bool) (transaction.Transaction, error)
+}
+
+// RiskReviewUsecaseImpl lets reviewers inspect risk decisions and resolve step-up challenges
+type RiskReviewUsecaseImpl struct {
+	transactionRepo transaction.Repository
+	cache           cache.CacheService
+	logger          logger.Logger
+}
+
+// NewRiskReviewUsecase creates a new instance of RiskReviewUsecase
+func NewRiskReviewUsecase(
+	transactionRepo transaction.Repository,
+	cache cache.CacheService,
+	logger logger.Logger,
+) RiskReviewUsecase {
+	return &RiskReviewUsecaseImpl{
+		transactionRepo: transactionRepo,
+		cache:           cache,
+		logger:          logger,
+	}
+}
+
+// ListFlagged returns transactions with the given risk decision
+func (uc *RiskReviewUsecaseImpl) ListFlagged(ctx context.Context, decision string) ([]transaction.Transaction, error) {
+	riskDecision, err := vo.NewRiskDecision(decision)
+	if err != nil {
+		return nil, err
+	}
+	return uc.transactionRepo.FindAll(&transaction.TransactionFilter{RiskDecision: &riskDecision})
+}
+
+// ResolveChallenge either clears a challenged transaction for confirmation or fails it
+func (uc *RiskReviewUsecaseImpl) ResolveChallenge(ctx context.Context, transactionID uint, approve bool) (transaction.Transaction, error) {
+	update := transaction.Transaction{RiskDecision: vo.RiskDecisionAllow}
+	if !approve {
+		update = transaction.Transaction{Status: vo.StatusFailed, RiskDecision: vo.RiskDecisionReject}
+	}
+
+	status := vo.StatusVerified
+	challenge := vo.RiskDecisionChallenge
+	err := uc.transactionRepo.Update(ctx, &transaction.TransactionFilter{ID: &transactionID, Status: &status, RiskDecision: &challenge}, update)
+	if err != nil {
+		if err == errs.ErrNotFound {
+			return transaction.Transaction{}, errs.ErrTransactionNotChallenged
+		}
+		return transaction.Transaction{}, err
+	}
+	// The cached copy still says "challenge"
+	_ = uc.cache.Delete(context.WithoutCancel(ctx), getTransactionCacheKey(transactionID))
+
+	uc.logger.WithContext(ctx).Info("Risk challenge resolved", map[string]interface{}{
+		"transaction_id": transactionID,
+		"approved":       approve,
+	})
+	tx, err := uc.transactionRepo.FindById(ctx, transactionID)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	return *tx, nil
+}
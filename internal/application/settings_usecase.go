@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/pricing"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// settingsHistoryLimit caps the audit trail returned when no limit is given
+const settingsHistoryLimit = 100
+
+// SettingsUsecase manages business settings that can change without a restart
+type SettingsUsecase interface {
+	setting.Provider
+	List(ctx context.Context) ([]setting.Entry, error)
+	Update(ctx context.Context, key, value, actor, reason string) (setting.Entry, error)
+	Reset(ctx context.Context, key, actor, reason string) (setting.Entry, error)
+	History(ctx context.Context, key string, limit int) ([]setting.Change, error)
+}
+
+// SettingsUsecaseImpl layers stored settings over the configured defaults and caches the result.
+// Each process reloads after the cache TTL, so a change reaches every replica within that time.
+type SettingsUsecaseImpl struct {
+	settingRepo setting.Repository
+	tx          domain.TxManager
+	logger      logger.Logger
+	defaults    setting.Values
+	cacheTTL    time.Duration
+
+	mu       sync.Mutex
+	current  *setting.Values
+	loadedAt time.Time
+}
+
+// NewSettingsUsecase creates a new instance of SettingsUsecase
+func NewSettingsUsecase(
+	settingRepo setting.Repository,
+	tx domain.TxManager,
+	logger logger.Logger,
+	defaults setting.Values,
+	cfg config.SettingsConfig,
+) SettingsUsecase {
+	return &SettingsUsecaseImpl{
+		settingRepo: settingRepo,
+		tx:          tx,
+		logger:      logger,
+		defaults:    defaults,
+		cacheTTL:    time.Duration(cfg.CacheTTL) * time.Second,
+	}
+}
+
+// DefaultSettings builds the settings used until an operator stores an override.
+// The pricing rules were already checked when the configuration was loaded.
+func DefaultSettings(app config.AppConfig, kycCfg config.KYCConfig, pricingCfg config.PricingConfig) setting.Values {
+	fees, _ := pricing.ParseFees(pricingCfg.Fees)
+	bonuses, _ := pricing.ParseBonuses(pricingCfg.Bonuses)
+	return setting.Values{
+		VerificationTTL:   time.Duration(app.VerificationTTL) * time.Second,
+		MaxAcceptedAmount: app.MaxAcceptedAmount,
+		PaymentMethods:    parsePaymentMethods(app.PaymentMethods),
+		TierPolicies: kyc.TierPolicies{
+			vo.KYCTier0: {MaxAmount: kycCfg.Tier0MaxAmount, PaymentMethods: parsePaymentMethods(kycCfg.Tier0PaymentMethods)},
+			vo.KYCTier1: {MaxAmount: kycCfg.Tier1MaxAmount, PaymentMethods: parsePaymentMethods(kycCfg.Tier1PaymentMethods)},
+			vo.KYCTier2: {MaxAmount: kycCfg.Tier2MaxAmount, PaymentMethods: parsePaymentMethods(kycCfg.Tier2PaymentMethods)},
+		},
+		Pricing: pricing.Rules{Fees: fees, Bonuses: bonuses},
+	}
+}
+
+// Current returns the settings in effect, reloading them once the cache TTL has passed.
+// If a reload fails the previous values are kept until the next attempt.
+func (uc *SettingsUsecaseImpl) Current(ctx context.Context) (setting.Values, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.current != nil && time.Since(uc.loadedAt) < uc.cacheTTL {
+		return *uc.current, nil
+	}
+
+	values, err := uc.load(ctx)
+	if err != nil {
+		if uc.current == nil {
+			return setting.Values{}, err
+		}
+		uc.logger.WithContext(ctx).Warn("Failed to reload settings, keeping previous values", map[string]interface{}{"error": err.Error()})
+		uc.loadedAt = time.Now()
+		return *uc.current, nil
+	}
+	uc.current = &values
+	uc.loadedAt = time.Now()
+	return values, nil
+}
+
+// List returns every setting with its value in effect, its default and the stored override if any
+func (uc *SettingsUsecaseImpl) List(ctx context.Context) ([]setting.Entry, error) {
+	stored, err := uc.settingRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[setting.Key]setting.Setting, len(stored))
+	for _, s := range stored {
+		byKey[s.Key] = s
+	}
+
+	entries := make([]setting.Entry, 0, len(setting.Keys()))
+	for _, key := range setting.Keys() {
+		var s *setting.Setting
+		if found, ok := byKey[key]; ok {
+			s = &found
+		}
+		entry, err := uc.entry(key, s)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Update stores a new value for key and records the change in the audit trail
+func (uc *SettingsUsecaseImpl) Update(ctx context.Context, key, value, actor, reason string) (setting.Entry, error) {
+	k, err := setting.NewKey(key)
+	if err != nil {
+		return setting.Entry{}, err
+	}
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return setting.Entry{}, errs.ErrSettingActorRequired
+	}
+	// Validate against a scratch copy and store the normalized form
+	values := uc.defaults.Clone()
+	if err := values.Set(k, value); err != nil {
+		return setting.Entry{}, err
+	}
+	normalized, err := values.Get(k)
+	if err != nil {
+		return setting.Entry{}, err
+	}
+
+	s := setting.Setting{Key: k, Value: normalized, UpdatedBy: actor, UpdatedAt: time.Now()}
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		previous, err := uc.stored(txCtx, k)
+		if err != nil {
+			return err
+		}
+		if err := uc.settingRepo.Save(txCtx, s); err != nil {
+			return err
+		}
+		return uc.recordChange(txCtx, k, previous, &normalized, actor, reason)
+	})
+	if err != nil {
+		return setting.Entry{}, err
+	}
+	uc.changed(ctx, k, normalized, actor)
+	return uc.entry(k, &s)
+}
+
+// Reset removes the stored value for key so the configured default applies again
+func (uc *SettingsUsecaseImpl) Reset(ctx context.Context, key, actor, reason string) (setting.Entry, error) {
+	k, err := setting.NewKey(key)
+	if err != nil {
+		return setting.Entry{}, err
+	}
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return setting.Entry{}, errs.ErrSettingActorRequired
+	}
+
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		previous, err := uc.stored(txCtx, k)
+		if err != nil || previous == nil {
+			return err
+		}
+		if err := uc.settingRepo.Delete(txCtx, k); err != nil {
+			return err
+		}
+		return uc.recordChange(txCtx, k, previous, nil, actor, reason)
+	})
+	if err != nil {
+		return setting.Entry{}, err
+	}
+	entry, err := uc.entry(k, nil)
+	if err != nil {
+		return setting.Entry{}, err
+	}
+	uc.changed(ctx, k, entry.Value, actor)
+	return entry, nil
+}
+
+// History returns the audit trail, newest first, optionally for a single key
+func (uc *SettingsUsecaseImpl) History(ctx context.Context, key string, limit int) ([]setting.Change, error) {
+	filter := &setting.ChangeFilter{Limit: limit}
+	if filter.Limit <= 0 || filter.Limit > settingsHistoryLimit {
+		filter.Limit = settingsHistoryLimit
+	}
+	if key != "" {
+		k, err := setting.NewKey(key)
+		if err != nil {
+			return nil, err
+		}
+		filter.Key = &k
+	}
+	return uc.settingRepo.FindChanges(ctx, filter)
+}
+
+// load applies every stored value over the defaults. A value that no longer parses, for
+// example a payment method that was removed, is skipped so the default applies.
+func (uc *SettingsUsecaseImpl) load(ctx context.Context) (setting.Values, error) {
+	stored, err := uc.settingRepo.FindAll(ctx)
+	if err != nil {
+		return setting.Values{}, err
+	}
+	values := uc.defaults.Clone()
+	for _, s := range stored {
+		if err := values.Set(s.Key, s.Value); err != nil {
+			uc.logger.WithContext(ctx).Warn("Ignoring invalid stored setting", map[string]interface{}{
+				"key":   s.Key.String(),
+				"error": err.Error(),
+			})
+		}
+	}
+	return values, nil
+}
+
+// stored returns the stored value for key, or nil when the default is in effect
+func (uc *SettingsUsecaseImpl) stored(ctx context.Context, key setting.Key) (*setting.Setting, error) {
+	s, err := uc.settingRepo.FindByKey(ctx, key)
+	if errors.Is(err, errs.ErrNotFound) {
+		return nil, nil
+	}
+	return s, err
+}
+
+func (uc *SettingsUsecaseImpl) recordChange(ctx context.Context, key setting.Key, previous *setting.Setting, newValue *string, actor, reason string) error {
+	var oldValue *string
+	if previous != nil {
+		oldValue = &previous.Value
+	}
+	_, err := uc.settingRepo.CreateChange(ctx, setting.Change{
+		Key:       key,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Actor:     actor,
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: time.Now(),
+	})
+	return err
+}
+
+// changed drops the cached values so this process applies the change immediately
+func (uc *SettingsUsecaseImpl) changed(ctx context.Context, key setting.Key, value, actor string) {
+	uc.mu.Lock()
+	uc.current = nil
+	uc.mu.Unlock()
+	uc.logger.WithContext(ctx).Info("Setting changed", map[string]interface{}{
+		"key":   key.String(),
+		"value": value,
+		"actor": actor,
+	})
+}
+
+func (uc *SettingsUsecaseImpl) entry(key setting.Key, stored *setting.Setting) (setting.Entry, error) {
+	defaultValue, err := uc.defaults.Get(key)
+	if err != nil {
+		return setting.Entry{}, err
+	}
+	entry := setting.Entry{Key: key, Value: defaultValue, Default: defaultValue}
+	if stored != nil {
+		values := uc.defaults.Clone()
+		if values.Set(key, stored.Value) == nil {
+			entry.Value = stored.Value
+			entry.Stored = stored
+		}
+	}
+	return entry, nil
+}
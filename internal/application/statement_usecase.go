@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StatementUsecase produces wallet statements. A statement is opened first, so an invalid
+// request is reported before any output is written, and then streamed to its destination.
+type StatementUsecase interface {
+	// Open validates the request and works out the opening balance
+	Open(ctx context.Context, spec statement.Spec) (statement.Statement, error)
+	// Write streams the entries of an opened statement to w with their running balance, and
+	// returns the statement with its closing balance
+	Write(ctx context.Context, s statement.Statement, w io.Writer) (statement.Statement, error)
+}
+
+// maxPDFStatementDays bounds the period of a PDF statement. The PDF is assembled in memory
+// before it is sent, unlike a CSV statement, which is streamed row by row.
+const maxPDFStatementDays = 93
+
+type StatementUsecaseImpl struct {
+	statementRepo statement.Repository
+	userRepo      user.Repository
+	renderers     map[statement.Format]statement.RendererFactory
+	logger        logger.Logger
+}
+
+// NewStatementUsecase creates a new instance of StatementUsecase
+func NewStatementUsecase(
+	statementRepo statement.Repository,
+	userRepo user.Repository,
+	renderers map[statement.Format]statement.RendererFactory,
+	logger logger.Logger,
+) StatementUsecase {
+	return &StatementUsecaseImpl{
+		statementRepo: statementRepo,
+		userRepo:      userRepo,
+		renderers:     renderers,
+		logger:        logger,
+	}
+}
+
+func (uc *StatementUsecaseImpl) Open(ctx context.Context, spec statement.Spec) (statement.Statement, error) {
+	s, err := uc.newStatement(spec)
+	if err != nil {
+		return statement.Statement{}, err
+	}
+	// Wallets share their owner's ID
+	if _, err := uc.userRepo.FindById(ctx, s.WalletID); err != nil {
+		return statement.Statement{}, err
+	}
+	if s.OpeningBalance, err = uc.statementRepo.OpeningBalance(ctx, s.WalletID, s.From); err != nil {
+		return statement.Statement{}, err
+	}
+	return s, nil
+}
+
+func (uc *StatementUsecaseImpl) Write(ctx context.Context, s statement.Statement, w io.Writer) (statement.Statement, error) {
+	ctx, span := tracer.Start(ctx, "StatementUsecase.Write", trace.WithAttributes(
+		attribute.Int64("wallet.id", int64(s.WalletID)),
+		attribute.String("statement.format", s.Format.String()),
+	))
+	s, err := uc.write(ctx, s, w)
+	if err != nil {
+		// Part of the statement may already have been sent, so the caller can no longer report this
+		uc.logger.WithContext(ctx).Error("Statement export failed", map[string]interface{}{
+			"wallet_id": s.WalletID,
+			"format":    s.Format.String(),
+			"entries":   s.Entries,
+			"error":     err.Error(),
+		})
+	}
+	endSpan(span, err)
+	return s, err
+}
+
+func (uc *StatementUsecaseImpl) write(ctx context.Context, s statement.Statement, w io.Writer) (statement.Statement, error) {
+	newRenderer, ok := uc.renderers[s.Format]
+	if !ok {
+		return s, fmt.Errorf("%w: format %s is not available", errs.ErrInvalidStatement, s.Format)
+	}
+	renderer := newRenderer(w)
+	if err := renderer.Begin(s); err != nil {
+		return s, err
+	}
+	s.ClosingBalance = s.OpeningBalance
+	err := uc.statementRepo.StreamEntries(ctx, s.WalletID, s.From, s.Until(), func(e statement.Entry) error {
+		s.ClosingBalance += e.Net
+		s.Entries++
+		e.Balance = s.ClosingBalance
+		return renderer.Entry(e)
+	})
+	if err != nil {
+		return s, errors.Join(err, renderer.Abort(s))
+	}
+	if err := renderer.End(s); err != nil {
+		return s, err
+	}
+
+	uc.logger.WithContext(ctx).Info("Statement exported", map[string]interface{}{
+		"wallet_id":       s.WalletID,
+		"from":            s.From.Format(time.DateOnly),
+		"to":              s.To.Format(time.DateOnly),
+		"format":          s.Format.String(),
+		"entries":         s.Entries,
+		"opening_balance": s.OpeningBalance,
+		"closing_balance": s.ClosingBalance,
+	})
+	return s, nil
+}
+
+func (uc *StatementUsecaseImpl) newStatement(spec statement.Spec) (statement.Statement, error) {
+	var problem string
+	switch {
+	case spec.WalletID == 0:
+		problem = "wallet is required"
+	case spec.From.IsZero() || spec.To.IsZero():
+		problem = "from and to dates are required"
+	case spec.To.Before(spec.From):
+		problem = "to must not be before from"
+	}
+	if problem != "" {
+		return statement.Statement{}, fmt.Errorf("%w: %s", errs.ErrInvalidStatement, problem)
+	}
+	format, err := statement.NewFormat(spec.Format)
+	if err != nil {
+		return statement.Statement{}, err
+	}
+	from, to := utcDate(spec.From), utcDate(spec.To)
+	if format == statement.FormatPDF && to.Sub(from) >= maxPDFStatementDays*24*time.Hour {
+		return statement.Statement{}, fmt.Errorf("%w: a pdf statement covers at most %d days, use csv for longer periods", errs.ErrInvalidStatement, maxPDFStatementDays)
+	}
+	return statement.Statement{
+		WalletID:    spec.WalletID,
+		From:        from,
+		To:          to,
+		Format:      format,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// utcDate returns midnight UTC of the calendar date of t
+func utcDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
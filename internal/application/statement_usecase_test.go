@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatementRepo struct {
+	opening float64
+	entries []statement.Entry
+	// openingBefore and streamed record the period the usecase asked for
+	openingBefore time.Time
+	streamed      [2]time.Time
+	// failAfter makes StreamEntries fail once that many entries have been streamed
+	failAfter int
+}
+
+func (r *fakeStatementRepo) OpeningBalance(ctx context.Context, walletID uint, before time.Time) (float64, error) {
+	r.openingBefore = before
+	return r.opening, nil
+}
+
+func (r *fakeStatementRepo) StreamEntries(ctx context.Context, walletID uint, from, until time.Time, fn func(statement.Entry) error) error {
+	r.streamed = [2]time.Time{from, until}
+	for i, e := range r.entries {
+		if r.failAfter > 0 && i == r.failAfter {
+			return errors.New("connection reset")
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeUserRepo struct {
+	user.Repository
+	users map[uint]user.User
+}
+
+func (r fakeUserRepo) FindById(ctx context.Context, id uint) (user.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return user.User{}, errs.ErrNotFound
+	}
+	return u, nil
+}
+
+// recordingRenderer keeps what it was asked to render
+type recordingRenderer struct {
+	begun   *statement.Statement
+	entries []statement.Entry
+	ended   *statement.Statement
+	aborted *statement.Statement
+}
+
+func (r *recordingRenderer) Begin(s statement.Statement) error {
+	r.begun = &s
+	return nil
+}
+
+func (r *recordingRenderer) Entry(e statement.Entry) error {
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+func (r *recordingRenderer) End(s statement.Statement) error {
+	r.ended = &s
+	return nil
+}
+
+func (r *recordingRenderer) Abort(s statement.Statement) error {
+	r.aborted = &s
+	return nil
+}
+
+func newStatementFixture(repo *fakeStatementRepo) (StatementUsecase, *recordingRenderer) {
+	renderer := &recordingRenderer{}
+	factory := func(io.Writer) statement.Renderer { return renderer }
+	uc := NewStatementUsecase(repo, fakeUserRepo{users: map[uint]user.User{1: {ID: 1}}}, map[statement.Format]statement.RendererFactory{
+		statement.FormatCSV: factory,
+		statement.FormatPDF: factory,
+	}, nopLogger{})
+	return uc, renderer
+}
+
+func TestStatementRunningAndClosingBalance(t *testing.T) {
+	repo := &fakeStatementRepo{
+		opening: 100,
+		entries: []statement.Entry{
+			{Type: statement.EntryTopup, ReferenceID: 7, Amount: 50, Fee: 1.5, Bonus: 5, Net: 53.5},
+			{Type: statement.EntryAdjustment, ReferenceID: 2, Amount: -20, Net: -20},
+			{Type: statement.EntryTopup, ReferenceID: 9, Amount: 0.1, Net: 0.1},
+			{Type: statement.EntryTopup, ReferenceID: 10, Amount: 0.2, Net: 0.2},
+		},
+	}
+	uc, renderer := newStatementFixture(repo)
+
+	s, err := uc.Open(context.Background(), statement.Spec{
+		WalletID: 1,
+		From:     time.Date(2026, 9, 1, 15, 30, 0, 0, time.UTC),
+		To:       time.Date(2026, 9, 30, 8, 0, 0, 0, time.UTC),
+		Format:   "CSV",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), s.From)
+	assert.Equal(t, time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC), s.To)
+	assert.Equal(t, statement.FormatCSV, s.Format)
+	assert.Equal(t, s.From, repo.openingBefore, "the opening balance covers everything before the first day")
+	assert.Equal(t, 100.0, s.OpeningBalance)
+
+	s, err = uc.Write(context.Background(), s, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, [2]time.Time{s.From, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}, repo.streamed, "the last day is included")
+
+	require.NotNil(t, renderer.begun)
+	assert.Equal(t, 100.0, renderer.begun.OpeningBalance)
+	balances := make([]float64, len(renderer.entries))
+	for i, e := range renderer.entries {
+		balances[i] = e.Balance
+	}
+	assert.InDeltaSlice(t, []float64{153.5, 133.5, 133.6, 133.8}, balances, 1e-9)
+
+	require.NotNil(t, renderer.ended)
+	assert.Equal(t, 4, renderer.ended.Entries)
+	assert.InDelta(t, 133.8, renderer.ended.ClosingBalance, 1e-9)
+	assert.Equal(t, *renderer.ended, s)
+}
+
+func TestStatementWithoutEntriesClosesAtOpeningBalance(t *testing.T) {
+	uc, renderer := newStatementFixture(&fakeStatementRepo{opening: 42.5})
+
+	s, err := uc.Open(context.Background(), statement.Spec{WalletID: 1, From: time.Now(), To: time.Now(), Format: "pdf"})
+	require.NoError(t, err)
+	s, err = uc.Write(context.Background(), s, io.Discard)
+	require.NoError(t, err)
+
+	assert.Empty(t, renderer.entries)
+	assert.Zero(t, s.Entries)
+	assert.Equal(t, 42.5, s.ClosingBalance)
+}
+
+func TestStatementFailureAbortsTheRenderer(t *testing.T) {
+	repo := &fakeStatementRepo{
+		opening: 10,
+		entries: []statement.Entry{
+			{Type: statement.EntryTopup, ReferenceID: 1, Amount: 5, Net: 5},
+			{Type: statement.EntryTopup, ReferenceID: 2, Amount: 5, Net: 5},
+			{Type: statement.EntryTopup, ReferenceID: 3, Amount: 5, Net: 5},
+		},
+		failAfter: 2,
+	}
+	uc, renderer := newStatementFixture(repo)
+
+	s, err := uc.Open(context.Background(), statement.Spec{WalletID: 1, From: time.Now(), To: time.Now(), Format: "csv"})
+	require.NoError(t, err)
+	_, err = uc.Write(context.Background(), s, io.Discard)
+	assert.Error(t, err)
+
+	assert.Len(t, renderer.entries, 2)
+	assert.Nil(t, renderer.ended, "an incomplete statement has no closing balance")
+	require.NotNil(t, renderer.aborted)
+	assert.Equal(t, 2, renderer.aborted.Entries)
+}
+
+func TestStatementOpenRejectsInvalidRequests(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		spec statement.Spec
+		want error
+	}{
+		{"missing wallet", statement.Spec{From: day(9, 1), To: day(9, 30), Format: "csv"}, errs.ErrInvalidStatement},
+		{"missing dates", statement.Spec{WalletID: 1, Format: "csv"}, errs.ErrInvalidStatement},
+		{"to before from", statement.Spec{WalletID: 1, From: day(9, 30), To: day(9, 1), Format: "csv"}, errs.ErrInvalidStatement},
+		{"unknown format", statement.Spec{WalletID: 1, From: day(9, 1), To: day(9, 30), Format: "xlsx"}, errs.ErrInvalidStatement},
+		{"pdf longer than the limit", statement.Spec{WalletID: 1, From: day(1, 1), To: day(4, 4), Format: "pdf"}, errs.ErrInvalidStatement},
+		{"unknown wallet", statement.Spec{WalletID: 2, From: day(9, 1), To: day(9, 30), Format: "csv"}, errs.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newStatementFixture(&fakeStatementRepo{})
+			_, err := uc.Open(context.Background(), tt.spec)
+			assert.True(t, errors.Is(err, tt.want), "got %v", err)
+		})
+	}
+}
+
+func TestStatementPDFLimitAllowsAQuarter(t *testing.T) {
+	uc, _ := newStatementFixture(&fakeStatementRepo{})
+
+	// 1 January to 4 April 2026 is 94 days; 1 January to 3 April is the limit
+	_, err := uc.Open(context.Background(), statement.Spec{
+		WalletID: 1,
+		From:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2026, 4, 3, 23, 0, 0, 0, time.UTC),
+		Format:   "pdf",
+	})
+	assert.NoError(t, err)
+
+	// CSV statements are streamed and have no limit
+	_, err = uc.Open(context.Background(), statement.Spec{
+		WalletID: 1,
+		From:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC),
+		Format:   "csv",
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/metrics"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// voucherBatchLimit caps how many codes a single generate request may create
+const voucherBatchLimit = 10000
+
+// VoucherUsecase redeems promo codes into wallets and manages code batches
+type VoucherUsecase interface {
+	Redeem(ctx context.Context, userID uint, code string) (transaction.Transaction, wallet.Wallet, error)
+	Generate(ctx context.Context, spec voucher.GenerateSpec) ([]voucher.Voucher, error)
+	List(ctx context.Context, batch string) ([]voucher.Voucher, error)
+}
+
+type VoucherUsecaseImpl struct {
+	voucherRepo     voucher.Repository
+	userRepo        user.Repository
+	transactionRepo transaction.Repository
+	walletRepo      wallet.Repository
+	outboxRepo      outbox.Repository
+	tx              domain.TxManager
+	logger          logger.Logger
+	metrics         metrics.Recorder
+}
+
+// NewVoucherUsecase creates a new instance of VoucherUsecase
+func NewVoucherUsecase(
+	voucherRepo voucher.Repository,
+	userRepo user.Repository,
+	transactionRepo transaction.Repository,
+	walletRepo wallet.Repository,
+	outboxRepo outbox.Repository,
+	tx domain.TxManager,
+	logger logger.Logger,
+	metrics metrics.Recorder,
+) VoucherUsecase {
+	return &VoucherUsecaseImpl{
+		voucherRepo:     voucherRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		walletRepo:      walletRepo,
+		outboxRepo:      outboxRepo,
+		tx:              tx,
+		logger:          logger,
+		metrics:         metrics,
+	}
+}
+
+// Redeem credits the voucher amount to the user's wallet as a completed voucher transaction
+func (uc *VoucherUsecaseImpl) Redeem(ctx context.Context, userID uint, code string) (transaction.Transaction, wallet.Wallet, error) {
+	ctx, span := tracer.Start(ctx, "VoucherUsecase.Redeem", trace.WithAttributes(
+		attribute.Int64("user.id", int64(userID)),
+	))
+	tx, w, err := uc.redeem(ctx, userID, code)
+	endSpan(span, err)
+	return tx, w, err
+}
+
+func (uc *VoucherUsecaseImpl) redeem(ctx context.Context, userID uint, code string) (transaction.Transaction, wallet.Wallet, error) {
+	ctx = logger.ContextWithFields(ctx, uc.logger, map[string]interface{}{"user_id": userID})
+	code = voucher.NormalizeCode(code)
+	if code == "" {
+		return transaction.Transaction{}, wallet.Wallet{}, fmt.Errorf("%w: code is required", errs.ErrInvalidVoucher)
+	}
+	if _, err := uc.userRepo.FindById(ctx, userID); err != nil {
+		return transaction.Transaction{}, wallet.Wallet{}, err
+	}
+
+	now := time.Now()
+	var redeemed transaction.Transaction
+	var updated *wallet.Wallet
+	var v *voucher.Voucher
+	err := runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		// The row lock is held until commit, so concurrent redemptions of one code see each
+		// other's counts; the conditional increment and a check constraint back it up
+		var err error
+		v, err = uc.voucherRepo.FindByCode(txCtx, code)
+		if err != nil {
+			return err
+		}
+		if !v.ActiveAt(now) {
+			return errs.ErrVoucherNotActive
+		}
+		if v.Exhausted() {
+			return errs.ErrVoucherExhausted
+		}
+		if v.PerUserLimit > 0 {
+			count, err := uc.voucherRepo.CountRedemptions(txCtx, v.ID, userID)
+			if err != nil {
+				return err
+			}
+			if count >= int64(v.PerUserLimit) {
+				return errs.ErrVoucherLimitReached
+			}
+		}
+		if err := uc.voucherRepo.IncrementRedemptions(txCtx, v.ID); err != nil {
+			return err
+		}
+
+		redeemed, err = transaction.NewTransaction(userID, v.Amount.Amount(), vo.PaymentMethodVoucher.String(), vo.StatusCompleted.String(), now)
+		if err != nil {
+			return err
+		}
+		redeemed.RiskDecision = vo.RiskDecisionAllow
+		redeemed.CompletedAt = &now
+		if redeemed.ID, err = uc.transactionRepo.Create(txCtx, redeemed); err != nil {
+			return err
+		}
+		// Wallets share their owner's ID
+		postings := topupPostings(redeemed, userID)
+		if updated, err = uc.walletRepo.AddBalance(txCtx, userID, redeemed.NetAmount().Amount()); err != nil {
+			return err
+		}
+		if err := uc.walletRepo.CreatePostings(txCtx, postings); err != nil {
+			return err
+		}
+		_, err = uc.voucherRepo.CreateRedemption(txCtx, voucher.Redemption{
+			VoucherID:     v.ID,
+			UserID:        userID,
+			TransactionID: redeemed.ID,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return err
+		}
+		message, err := newOutboxMessage(event.TypeTopupCompleted, event.AggregateTransaction, redeemed.ID, newTopupPayload(redeemed))
+		if err != nil {
+			return err
+		}
+		if _, err := uc.outboxRepo.Create(txCtx, message); err != nil {
+			return err
+		}
+		return addPostingEvents(txCtx, uc.outboxRepo, postings, updated.Balance.Amount()-redeemed.NetAmount().Amount())
+	})
+	if err != nil {
+		return transaction.Transaction{}, wallet.Wallet{}, err
+	}
+
+	uc.metrics.TopupCompleted(redeemed.PaymentMethod.String(), redeemed.Amount.Amount())
+	uc.logger.WithContext(ctx).Info("Voucher redeemed", map[string]interface{}{
+		"voucher_id":     v.ID,
+		"batch":          v.Batch,
+		"transaction_id": redeemed.ID,
+		"amount":         redeemed.Amount,
+	})
+	return redeemed, *updated, nil
+}
+
+// Generate creates a batch of random codes that share the spec's amount, limits and validity window
+func (uc *VoucherUsecaseImpl) Generate(ctx context.Context, spec voucher.GenerateSpec) ([]voucher.Voucher, error) {
+	if err := validateGenerateSpec(spec); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	batch := strings.TrimSpace(spec.Batch)
+	if batch == "" {
+		batch = "batch-" + now.UTC().Format("20060102-150405")
+	}
+	amount, err := vo.NewMoney(spec.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers := make([]voucher.Voucher, 0, spec.Count)
+	seen := make(map[string]bool, spec.Count)
+	for len(vouchers) < spec.Count {
+		code, err := voucher.GenerateCode(spec.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		vouchers = append(vouchers, voucher.Voucher{
+			Code:           code,
+			Batch:          batch,
+			Amount:         amount,
+			MaxRedemptions: spec.MaxRedemptions,
+			PerUserLimit:   spec.PerUserLimit,
+			ValidFrom:      spec.ValidFrom,
+			ValidUntil:     spec.ValidUntil,
+			CreatedBy:      strings.TrimSpace(spec.Actor),
+			CreatedAt:      now,
+		})
+	}
+	// Insert the whole batch or nothing
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		return uc.voucherRepo.CreateMany(txCtx, vouchers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.WithContext(ctx).Info("Vouchers generated", map[string]interface{}{
+		"batch":  batch,
+		"count":  len(vouchers),
+		"amount": spec.Amount,
+		"actor":  spec.Actor,
+	})
+	return vouchers, nil
+}
+
+// List returns the vouchers of a batch, or every voucher when batch is empty
+func (uc *VoucherUsecaseImpl) List(ctx context.Context, batch string) ([]voucher.Voucher, error) {
+	filter := &voucher.VoucherFilter{}
+	if batch = strings.TrimSpace(batch); batch != "" {
+		filter.Batch = &batch
+	}
+	return uc.voucherRepo.FindAll(ctx, filter)
+}
+
+func validateGenerateSpec(spec voucher.GenerateSpec) error {
+	var problem string
+	switch {
+	case spec.Count <= 0 || spec.Count > voucherBatchLimit:
+		problem = fmt.Sprintf("count must be between 1 and %d", voucherBatchLimit)
+	case spec.Amount <= 0:
+		problem = "amount must be positive"
+	case spec.MaxRedemptions < 0 || spec.PerUserLimit < 0:
+		problem = "redemption limits must not be negative"
+	case len(spec.Prefix) > 20 || strings.ContainsFunc(voucher.NormalizeCode(spec.Prefix), func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	}):
+		problem = "prefix must be at most 20 letters and digits"
+	case len(strings.TrimSpace(spec.Batch)) > 100:
+		problem = "batch must be at most 100 characters"
+	case spec.ValidFrom != nil && spec.ValidUntil != nil && !spec.ValidUntil.After(*spec.ValidFrom):
+		problem = "valid_until must be after valid_from"
+	case strings.TrimSpace(spec.Actor) == "":
+		problem = "actor is required"
+	default:
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errs.ErrInvalidVoucher, problem)
+}
@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/metrics"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// voucherLedger stands in for the database behind a redemption. Writes made inside a transaction
+// are applied on commit and dropped on rollback, and FindByCode holds a lock until the transaction
+// ends, like the row lock it takes in Postgres.
+type voucherLedger struct {
+	rowLock sync.Mutex
+
+	mu           sync.Mutex
+	vouchers     map[string]*voucher.Voucher
+	redemptions  []voucher.Redemption
+	transactions []transaction.Transaction
+	balances     map[uint]float64
+	events       []event.Type
+	commits      int
+	rollbacks    int
+	// exhaustOnIncrement makes IncrementRedemptions fail as if the voucher ran out meanwhile
+	exhaustOnIncrement bool
+}
+
+type ledgerTxKey struct{}
+
+// ledgerTx is an open transaction and the writes it will apply on commit
+type ledgerTx struct {
+	locked bool
+	writes []func()
+}
+
+func newVoucherLedger(vouchers ...voucher.Voucher) *voucherLedger {
+	l := &voucherLedger{vouchers: map[string]*voucher.Voucher{}, balances: map[uint]float64{}}
+	for i := range vouchers {
+		v := vouchers[i]
+		l.vouchers[v.Code] = &v
+	}
+	return l
+}
+
+// write applies fn now, or on commit when ctx carries a transaction
+func (l *voucherLedger) write(ctx context.Context, fn func()) {
+	if tx, ok := ctx.Value(ledgerTxKey{}).(*ledgerTx); ok {
+		tx.writes = append(tx.writes, fn)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn()
+}
+
+func (l *voucherLedger) BeginTx(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, ledgerTxKey{}, &ledgerTx{}), nil
+}
+
+func (l *voucherLedger) CommitTx(ctx context.Context) error {
+	tx := ctx.Value(ledgerTxKey{}).(*ledgerTx)
+	l.mu.Lock()
+	for _, fn := range tx.writes {
+		fn()
+	}
+	l.commits++
+	l.mu.Unlock()
+	l.release(tx)
+	return nil
+}
+
+func (l *voucherLedger) RollbackTx(ctx context.Context) error {
+	tx := ctx.Value(ledgerTxKey{}).(*ledgerTx)
+	l.mu.Lock()
+	l.rollbacks++
+	l.mu.Unlock()
+	l.release(tx)
+	return nil
+}
+
+func (l *voucherLedger) release(tx *ledgerTx) {
+	if tx.locked {
+		l.rowLock.Unlock()
+	}
+}
+
+type fakeVoucherRepo struct {
+	voucher.Repository
+	*voucherLedger
+}
+
+func (r fakeVoucherRepo) FindByCode(ctx context.Context, code string) (*voucher.Voucher, error) {
+	if tx, ok := ctx.Value(ledgerTxKey{}).(*ledgerTx); ok && !tx.locked {
+		r.rowLock.Lock()
+		tx.locked = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.vouchers[code]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	copied := *v
+	return &copied, nil
+}
+
+func (r fakeVoucherRepo) IncrementRedemptions(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	var found *voucher.Voucher
+	for _, v := range r.vouchers {
+		if v.ID == id {
+			found = v
+		}
+	}
+	exhausted := found != nil && (r.exhaustOnIncrement || found.Exhausted())
+	r.mu.Unlock()
+	switch {
+	case found == nil:
+		return errs.ErrNotFound
+	case exhausted:
+		return errs.ErrVoucherExhausted
+	}
+	r.write(ctx, func() { found.Redemptions++ })
+	return nil
+}
+
+func (r fakeVoucherRepo) CountRedemptions(ctx context.Context, voucherID, userID uint) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, redemption := range r.redemptions {
+		if redemption.VoucherID == voucherID && redemption.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r fakeVoucherRepo) CreateRedemption(ctx context.Context, redemption voucher.Redemption) (uint, error) {
+	r.write(ctx, func() { r.redemptions = append(r.redemptions, redemption) })
+	return 1, nil
+}
+
+type ledgerTransactions struct {
+	transaction.Repository
+	*voucherLedger
+}
+
+func (r ledgerTransactions) Create(ctx context.Context, t transaction.Transaction) (uint, error) {
+	r.mu.Lock()
+	t.ID = uint(len(r.transactions) + 1)
+	r.mu.Unlock()
+	r.write(ctx, func() { r.transactions = append(r.transactions, t) })
+	return t.ID, nil
+}
+
+type ledgerWallets struct {
+	wallet.Repository
+	*voucherLedger
+}
+
+func (r ledgerWallets) AddBalance(ctx context.Context, id uint, delta float64) (*wallet.Wallet, error) {
+	r.mu.Lock()
+	balance, err := vo.NewMoney(r.balances[id] + delta)
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	r.write(ctx, func() { r.balances[id] += delta })
+	return &wallet.Wallet{ID: id, Balance: balance}, nil
+}
+
+func (r ledgerWallets) CreatePostings(ctx context.Context, postings []wallet.Posting) error {
+	return nil
+}
+
+type ledgerOutbox struct {
+	outbox.Repository
+	*voucherLedger
+}
+
+func (r ledgerOutbox) Create(ctx context.Context, message outbox.Message) (uint, error) {
+	r.write(ctx, func() { r.events = append(r.events, message.EventType) })
+	return 1, nil
+}
+
+func newVoucherFixture(t *testing.T, users int, vouchers ...voucher.Voucher) (VoucherUsecase, *voucherLedger) {
+	t.Helper()
+	ledger := newVoucherLedger(vouchers...)
+	userRepo := fakeUserRepo{users: map[uint]user.User{}}
+	for id := uint(1); id <= uint(users); id++ {
+		userRepo.users[id] = user.User{ID: id}
+	}
+	uc := NewVoucherUsecase(fakeVoucherRepo{voucherLedger: ledger}, userRepo, ledgerTransactions{voucherLedger: ledger},
+		ledgerWallets{voucherLedger: ledger}, ledgerOutbox{voucherLedger: ledger}, ledger, nopLogger{}, metrics.Nop{})
+	return uc, ledger
+}
+
+func TestVoucherRedeemCreditsWallet(t *testing.T) {
+	uc, ledger := newVoucherFixture(t, 1, voucher.Voucher{ID: 1, Code: "SPRING-ABCD", Amount: money(t, 25), MaxRedemptions: 10})
+
+	redeemed, w, err := uc.Redeem(context.Background(), 1, "  spring-abcd ")
+	require.NoError(t, err)
+
+	assert.Equal(t, vo.PaymentMethodVoucher, redeemed.PaymentMethod)
+	assert.Equal(t, vo.StatusCompleted, redeemed.Status)
+	assert.NotNil(t, redeemed.CompletedAt)
+	assert.Equal(t, 25.0, w.Balance.Amount())
+	assert.Equal(t, 25.0, ledger.balances[1])
+	assert.Equal(t, 1, ledger.vouchers["SPRING-ABCD"].Redemptions)
+	require.Len(t, ledger.redemptions, 1)
+	assert.Equal(t, redeemed.ID, ledger.redemptions[0].TransactionID)
+	assert.Equal(t, []event.Type{event.TypeTopupCompleted, event.TypeWalletBalanceChanged}, ledger.events)
+	assert.Equal(t, 1, ledger.commits)
+}
+
+func TestVoucherRedeemRejects(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		voucher voucher.Voucher
+		// redeemedBy lists users who redeemed the voucher before
+		redeemedBy []uint
+		want       error
+	}{
+		{
+			name:    "exhausted voucher",
+			voucher: voucher.Voucher{MaxRedemptions: 2, Redemptions: 2},
+			want:    errs.ErrVoucherExhausted,
+		},
+		{
+			name:       "per-user limit reached",
+			voucher:    voucher.Voucher{PerUserLimit: 1, Redemptions: 1},
+			redeemedBy: []uint{1},
+			want:       errs.ErrVoucherLimitReached,
+		},
+		{
+			name:    "not valid yet",
+			voucher: voucher.Voucher{ValidFrom: &future},
+			want:    errs.ErrVoucherNotActive,
+		},
+		{
+			name:    "expired",
+			voucher: voucher.Voucher{ValidUntil: &past},
+			want:    errs.ErrVoucherNotActive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.voucher
+			v.ID, v.Code, v.Amount = 1, "CODE", money(t, 10)
+			uc, ledger := newVoucherFixture(t, 2, v)
+			for _, userID := range tt.redeemedBy {
+				ledger.redemptions = append(ledger.redemptions, voucher.Redemption{VoucherID: 1, UserID: userID})
+			}
+
+			_, _, err := uc.Redeem(context.Background(), 1, "CODE")
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, ledger.transactions)
+			assert.Zero(t, ledger.balances[1])
+			assert.Empty(t, ledger.events)
+			assert.Equal(t, 1, ledger.rollbacks)
+			assert.Zero(t, ledger.commits)
+		})
+	}
+}
+
+func TestVoucherPerUserLimitAllowsOtherUsers(t *testing.T) {
+	uc, ledger := newVoucherFixture(t, 2, voucher.Voucher{ID: 1, Code: "CODE", Amount: money(t, 10), PerUserLimit: 1})
+
+	_, _, err := uc.Redeem(context.Background(), 1, "CODE")
+	require.NoError(t, err)
+	_, _, err = uc.Redeem(context.Background(), 1, "CODE")
+	assert.ErrorIs(t, err, errs.ErrVoucherLimitReached)
+	_, _, err = uc.Redeem(context.Background(), 2, "CODE")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[uint]float64{1: 10, 2: 10}, ledger.balances)
+	assert.Equal(t, 2, ledger.vouchers["CODE"].Redemptions)
+}
+
+func TestVoucherFailedIncrementRollsBackRedemption(t *testing.T) {
+	uc, ledger := newVoucherFixture(t, 1, voucher.Voucher{ID: 1, Code: "CODE", Amount: money(t, 10), MaxRedemptions: 5})
+	ledger.exhaustOnIncrement = true
+
+	_, _, err := uc.Redeem(context.Background(), 1, "CODE")
+	assert.ErrorIs(t, err, errs.ErrVoucherExhausted)
+
+	assert.Equal(t, 1, ledger.rollbacks)
+	assert.Zero(t, ledger.commits)
+	assert.Empty(t, ledger.transactions)
+	assert.Empty(t, ledger.redemptions)
+	assert.Empty(t, ledger.events)
+	assert.Zero(t, ledger.balances[1])
+	assert.Zero(t, ledger.vouchers["CODE"].Redemptions)
+}
+
+func TestVoucherConcurrentRedemptionsStopAtMaximum(t *testing.T) {
+	const users, maxRedemptions = 20, 5
+	uc, ledger := newVoucherFixture(t, users, voucher.Voucher{ID: 1, Code: "CODE", Amount: money(t, 10), MaxRedemptions: maxRedemptions})
+
+	var wg sync.WaitGroup
+	results := make([]error, users)
+	for i := range results {
+		wg.Add(1)
+		go func(userID uint) {
+			defer wg.Done()
+			_, _, results[userID-1] = uc.Redeem(context.Background(), userID, "CODE")
+		}(uint(i + 1))
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range results {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, errs.ErrVoucherExhausted)
+	}
+	assert.Equal(t, maxRedemptions, succeeded)
+	assert.Equal(t, maxRedemptions, ledger.vouchers["CODE"].Redemptions)
+	assert.Len(t, ledger.transactions, maxRedemptions)
+	assert.Len(t, ledger.redemptions, maxRedemptions)
+	total := 0.0
+	for _, balance := range ledger.balances {
+		total += balance
+	}
+	assert.Equal(t, float64(maxRedemptions*10), total)
+}
@@ -2,24 +2,36 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hydr0g3nz/wallet_topup_system/config"
-	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/repository"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
 	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/lock"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/metrics"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/risk"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type WalletUsecase interface {
-	VerifyTopup(ctx context.Context, userID uint, amount float64, paymentMethod string) (transaction.Transaction, error)
-	ConfirmTopup(ctx context.Context, transactionID uint) (transaction.Transaction, wallet.Wallet, error)
+	VerifyTopup(ctx context.Context, userID uint, amount float64, instrumentID uint) (transaction.Transaction, error)
+	// ConfirmTopup credits a verified top-up. providerReference, when set, is the payment
+	// provider's reference for the charge and is recorded with the completed status.
+	ConfirmTopup(ctx context.Context, transactionID uint, providerReference string) (transaction.Transaction, wallet.Wallet, error)
 }
 
 // WalletUsecase handles the business logic for wallet top-up operations
@@ -27,11 +39,16 @@ type WalletUsecaseImpl struct {
 	userRepo        user.Repository
 	transactionRepo transaction.Repository
 	walletRepo      wallet.Repository
+	instrumentRepo  instrument.Repository
 	cache           cache.CacheService
 	tx              domain.TxManager // atomic transaction
-	repoTx          domain.Repository
 	logger          logger.Logger
-	cfg             config.Config
+	lockCfg         config.LockConfig
+	settings        setting.Provider
+	risk            risk.Assessor
+	outboxRepo      outbox.Repository
+	locker          lock.Locker
+	metrics         metrics.Recorder
 }
 
 // NewWalletUsecase creates a new instance of WalletUsecase
@@ -39,143 +56,412 @@ func NewWalletUsecase(
 	userRepo user.Repository,
 	transactionRepo transaction.Repository,
 	walletRepo wallet.Repository,
+	instrumentRepo instrument.Repository,
 	cache cache.CacheService,
 	tx domain.TxManager,
 	logger logger.Logger,
-	config config.Config,
-
+	lockCfg config.LockConfig,
+	settings setting.Provider,
+	riskAssessor risk.Assessor,
+	outboxRepo outbox.Repository,
+	locker lock.Locker,
+	metrics metrics.Recorder,
 ) WalletUsecase {
-	repoTransaction := repository.NewRepositoryTransaction(transactionRepo, walletRepo, userRepo)
 	return &WalletUsecaseImpl{
 		userRepo:        userRepo,
 		transactionRepo: transactionRepo,
 		walletRepo:      walletRepo,
+		instrumentRepo:  instrumentRepo,
 		cache:           cache,
 		tx:              tx,
-		repoTx:          repoTransaction,
 		logger:          logger,
-		cfg:             config,
+		lockCfg:         lockCfg,
+		settings:        settings,
+		risk:            riskAssessor,
+		outboxRepo:      outboxRepo,
+		locker:          locker,
+		metrics:         metrics,
 	}
 }
 
-// VerifyTopup verifies a top-up request and creates a transaction with "verified" status
-func (uc *WalletUsecaseImpl) VerifyTopup(ctx context.Context, userID uint, amount float64, paymentMethod string) (transaction.Transaction, error) {
-	// Check if user exists
-	if amount > uc.cfg.App.MaxAcceptedAmount {
+// VerifyTopup verifies a top-up paid with one of the user's saved instruments and creates a
+// transaction with "verified" status
+func (uc *WalletUsecaseImpl) VerifyTopup(ctx context.Context, userID uint, amount float64, instrumentID uint) (transaction.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "WalletUsecase.VerifyTopup", trace.WithAttributes(
+		attribute.Int64("user.id", int64(userID)),
+		attribute.Int64("instrument.id", int64(instrumentID)),
+	))
+	tx, err := uc.verifyTopup(ctx, userID, amount, instrumentID)
+	endSpan(span, err)
+	return tx, err
+}
+
+func (uc *WalletUsecaseImpl) verifyTopup(ctx context.Context, userID uint, amount float64, instrumentID uint) (transaction.Transaction, error) {
+	ctx = logger.ContextWithFields(ctx, uc.logger, map[string]interface{}{"user_id": userID})
+	// Limits and payment methods can change at runtime, so read them for every request
+	settings, err := uc.settings.Current(ctx)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	if amount > settings.MaxAcceptedAmount {
 		return transaction.Transaction{}, errs.ErrAmountExceedsLimit
 	}
-	_, err := uc.userRepo.FindById(userID)
+	u, err := uc.userRepo.FindById(ctx, userID)
 	if err != nil {
 		return transaction.Transaction{}, err
 	}
-	newTransaction, err := transaction.NewTransaction(userID, amount, paymentMethod, string(vo.StatusVerified), time.Now().Add(15*time.Minute))
+	// Another user's instrument is reported as missing
+	paymentInstrument, err := uc.instrumentRepo.FindByID(ctx, instrumentID)
 	if err != nil {
 		return transaction.Transaction{}, err
 	}
-	// Save transaction
-	id, err := uc.transactionRepo.Create(ctx, newTransaction)
+	if paymentInstrument.UserID != userID {
+		return transaction.Transaction{}, errs.ErrNotFound
+	}
+	now := time.Now()
+	if paymentInstrument.Expired(now) {
+		return transaction.Transaction{}, errs.ErrInstrumentExpired
+	}
+	// Check the user's KYC tier allows this amount and payment method
+	method := paymentInstrument.PaymentMethod()
+	if !settings.PaymentMethodEnabled(method) {
+		return transaction.Transaction{}, errs.ErrPaymentMethodDisabled
+	}
+	policy := settings.TierPolicies.For(u.KYCTier)
+	if amount > policy.MaxAmount {
+		return transaction.Transaction{}, errs.ErrTierLimitExceeded
+	}
+	if !policy.AllowsPaymentMethod(method) {
+		return transaction.Transaction{}, errs.ErrPaymentMethodNotAllowed
+	}
+	newTransaction, err := transaction.NewTransaction(userID, amount, method.String(), string(vo.StatusVerified), now.Add(settings.VerificationTTL))
 	if err != nil {
 		return transaction.Transaction{}, err
 	}
-	newTransaction.ID = id
+	newTransaction.InstrumentID = paymentInstrument.ID
+	// Fee and bonus are fixed now, so the confirm credits exactly what the user was quoted
+	quote, err := settings.Pricing.Quote(amount, method, now)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	if newTransaction.Fee, err = vo.NewMoney(quote.Fee); err != nil {
+		return transaction.Transaction{}, err
+	}
+	if newTransaction.Bonus, err = vo.NewMoney(quote.Bonus); err != nil {
+		return transaction.Transaction{}, err
+	}
+	newTransaction.Campaign = quote.Campaign
+	// Score the top-up; rejected attempts are still recorded as failed for later review
+	assessment, err := uc.risk.Assess(ctx, risk.Input{
+		User:          u,
+		Amount:        newTransaction.Amount,
+		PaymentMethod: method,
+		Limit:         min(policy.MaxAmount, settings.MaxAcceptedAmount),
+		Now:           now,
+	})
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	newTransaction.RiskScore = assessment.Score
+	newTransaction.RiskDecision = assessment.Decision
+	newTransaction.RiskReasons = assessment.Reasons()
+	if assessment.Decision == vo.RiskDecisionReject {
+		newTransaction.Status = vo.StatusFailed
+	}
+	// Save transaction together with its topup.verified event
+	err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+		id, err := uc.transactionRepo.Create(txCtx, newTransaction)
+		if err != nil {
+			return err
+		}
+		newTransaction.ID = id
+		if newTransaction.Status != vo.StatusVerified {
+			return nil
+		}
+		return uc.addEvent(txCtx, event.TypeTopupVerified, event.AggregateTransaction, id, newTopupPayload(newTransaction))
+	})
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	id := newTransaction.ID
+	ctx = logger.ContextWithFields(ctx, uc.logger, map[string]interface{}{"transaction_id": id})
 
-	// Store in cache (using transaction ID as key)
+	if assessment.Decision != vo.RiskDecisionAllow {
+		uc.logger.WithContext(ctx).Warn("Top-up flagged by risk checks", map[string]interface{}{
+			"score":    assessment.Score,
+			"decision": assessment.Decision.String(),
+			"reasons":  newTransaction.RiskReasons,
+		})
+	}
+	if assessment.Decision == vo.RiskDecisionReject {
+		uc.metrics.TopupRejected(newTransaction.PaymentMethod.String(), newTransaction.Amount.Amount())
+		return transaction.Transaction{}, errs.ErrTopupRejected
+	}
+	uc.metrics.TopupVerified(newTransaction.PaymentMethod.String(), newTransaction.Amount.Amount())
+
+	// Store in cache (using transaction ID as key) until the transaction expires
 	cacheKey := getTransactionCacheKey(id)
-	err = uc.cache.Set(context.Background(), cacheKey, newTransaction, 15*time.Minute)
+	err = uc.cache.Set(context.WithoutCancel(ctx), cacheKey, newTransaction, settings.VerificationTTL)
 	if err != nil {
-		uc.logger.Error("Failed to set transaction in cache", map[string]interface{}{"error": err})
+		uc.logger.WithContext(ctx).Warn("Failed to set transaction in cache", map[string]interface{}{"error": err.Error()})
 	}
 
 	return newTransaction, nil
 }
 
 // ConfirmTopup confirms a previously verified transaction and updates the wallet balance
-func (uc *WalletUsecaseImpl) ConfirmTopup(ctx context.Context, transactionID uint) (transaction.Transaction, wallet.Wallet, error) {
-	txCtx, err := uc.tx.BeginTx(ctx)
-	if err != nil {
-		return transaction.Transaction{}, wallet.Wallet{}, err
+func (uc *WalletUsecaseImpl) ConfirmTopup(ctx context.Context, transactionID uint, providerReference string) (transaction.Transaction, wallet.Wallet, error) {
+	ctx, span := tracer.Start(ctx, "WalletUsecase.ConfirmTopup", trace.WithAttributes(
+		attribute.Int64("transaction.id", int64(transactionID)),
+	))
+	tx, w, err := uc.confirmTopup(ctx, transactionID, strings.TrimSpace(providerReference))
+	endSpan(span, err)
+	return tx, w, err
+}
+
+func (uc *WalletUsecaseImpl) confirmTopup(ctx context.Context, transactionID uint, providerReference string) (transaction.Transaction, wallet.Wallet, error) {
+	ctx = logger.ContextWithFields(ctx, uc.logger, map[string]interface{}{"transaction_id": transactionID})
+	if len(providerReference) > maxProviderReferenceLength {
+		return transaction.Transaction{}, wallet.Wallet{}, fmt.Errorf("%w: must be at most %d characters", errs.ErrInvalidProviderReference, maxProviderReferenceLength)
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			_ = uc.tx.RollbackTx(txCtx)
-			panic(r)
-		}
-	}()
+	// Serialize confirms of the same transaction; a waiting confirm then finds it completed.
+	// Confirms of different transactions for one wallet need no lock: the balance is added atomically.
+	lockCtx, cancel := context.WithTimeout(ctx, time.Duration(uc.lockCfg.Wait)*time.Millisecond)
+	lockCtx, lockSpan := tracer.Start(lockCtx, "lock.acquire")
+	txLock, err := uc.locker.Acquire(lockCtx, getTransactionLockKey(transactionID), time.Duration(uc.lockCfg.TTL)*time.Second)
+	endSpan(lockSpan, err)
+	cancel()
+	switch {
+	case errors.Is(err, errs.ErrLockNotAcquired):
+		return transaction.Transaction{}, wallet.Wallet{}, errs.ErrConfirmInProgress
+	case err != nil:
+		// The conditional status update below still stops a double credit
+		uc.logger.WithContext(ctx).Warn("Lock service unavailable, confirming without lock", map[string]interface{}{
+			"error": err.Error(),
+		})
+	default:
+		defer func() {
+			if err := txLock.Release(context.WithoutCancel(ctx)); err != nil {
+				uc.logger.WithContext(ctx).Warn("Failed to release transaction lock", map[string]interface{}{"error": err.Error()})
+			}
+		}()
+	}
+
 	// Try to get transaction from cache first
 	cacheKey := getTransactionCacheKey(transactionID)
 	tx := &transaction.Transaction{}
 	err = uc.cache.Get(ctx, cacheKey, tx)
 	if err != nil {
-		uc.logger.Error("Failed to get transaction from cache", map[string]interface{}{"error": err})
+		if errors.Is(err, cache.ErrCacheMiss) {
+			uc.logger.WithContext(ctx).Debug("Transaction not in cache", nil)
+		} else {
+			uc.logger.WithContext(ctx).Warn("Cache unavailable, reading transaction from database", map[string]interface{}{"error": err.Error()})
+		}
 		// Get transaction from database if not found in cache
-		tx, err = uc.transactionRepo.FindById(transactionID)
+		tx, err = uc.transactionRepo.FindById(ctx, transactionID)
 		if err != nil {
 			return transaction.Transaction{}, wallet.Wallet{}, err
 		}
 	} else {
-		uc.logger.Info("Transaction found in cache", map[string]interface{}{"transaction": tx})
-		uc.logger.Info("Transaction", map[string]interface{}{"transaction": tx})
+		uc.logger.WithContext(ctx).Debug("Transaction found in cache", nil)
 	}
+	ctx = logger.ContextWithFields(ctx, uc.logger, map[string]interface{}{"user_id": tx.UserID})
 
 	// Check if transaction is verified and not expired
 	if tx.Status != vo.StatusVerified {
 		return transaction.Transaction{}, wallet.Wallet{}, errs.ErrTransactionNotVerified
 	}
 
+	if tx.RiskDecision == vo.RiskDecisionChallenge {
+		return transaction.Transaction{}, wallet.Wallet{}, errs.ErrChallengeRequired
+	}
+
 	if time.Now().After(tx.ExpiresAt) {
-		// Update status to expired
-		status := vo.StatusVerified
-		err = uc.transactionRepo.Update(ctx, &transaction.TransactionFilter{ID: &tx.ID, Status: &status}, transaction.Transaction{
-			Status: vo.StatusExpired,
+		// Update status to expired and record the event atomically
+		err = runInTx(ctx, uc.tx, func(txCtx context.Context) error {
+			return expireTopup(txCtx, uc.transactionRepo, uc.outboxRepo, tx)
 		})
 		if err != nil {
 			return transaction.Transaction{}, wallet.Wallet{}, err
 		}
+		uc.metrics.TopupExpired(tx.PaymentMethod.String(), tx.Amount.Amount())
+		_ = uc.cache.Delete(context.WithoutCancel(ctx), cacheKey)
 		return transaction.Transaction{}, wallet.Wallet{}, errs.ErrExpiredTransaction
 	}
 
-	// Get user's wallet
-	userWallet, err := uc.walletRepo.FindById(tx.UserID)
-	if err != nil {
+	if err := uc.checkProviderReference(ctx, *tx, providerReference); err != nil {
 		return transaction.Transaction{}, wallet.Wallet{}, err
 	}
-	//update value
-	userWallet.Balance = userWallet.Balance.Add(tx.Amount)
-	tx.Status = vo.StatusCompleted
-	// Update transaction status to completed
-	err = uc.walletRepo.Update(txCtx, *userWallet)
+
+	txCtx, err := uc.tx.BeginTx(ctx)
 	if err != nil {
-		_ = uc.tx.RollbackTx(txCtx)
-		uc.logger.Error("Failed to update wallet", map[string]interface{}{"error": err})
 		return transaction.Transaction{}, wallet.Wallet{}, err
 	}
-	// Update transaction status to completed
+	defer func() {
+		if r := recover(); r != nil {
+			_ = uc.tx.RollbackTx(txCtx)
+			panic(r)
+		}
+	}()
+	// Update transaction status to completed, only if it is still verified, recording the
+	// provider reference that settlement reconciliation matches on
+	completedAt := time.Now()
+	tx.Status = vo.StatusCompleted
+	tx.CompletedAt = &completedAt
+	if providerReference != "" {
+		tx.ProviderReference = providerReference
+	}
 	status := vo.StatusVerified
 	err = uc.transactionRepo.Update(txCtx, &transaction.TransactionFilter{ID: &tx.ID,
 		Status: &status,
 	},
 		transaction.Transaction{
-			Status: tx.Status,
+			Status:            tx.Status,
+			ProviderReference: providerReference,
+			CompletedAt:       tx.CompletedAt,
 		})
 
 	if err != nil {
 		_ = uc.tx.RollbackTx(txCtx)
-		uc.logger.Error("Failed to update transaction", map[string]interface{}{"error": err})
+		uc.logger.WithContext(ctx).Error("Failed to update transaction", map[string]interface{}{"error": err})
 		return transaction.Transaction{}, wallet.Wallet{}, err
 	}
-	_ = uc.tx.CommitTx(txCtx)
-	uc.logger.Info("Top-up confirmed", map[string]interface{}{
-		"transaction_id": tx.ID,
-		"user_id":        tx.UserID,
-		"amount":         tx.Amount,
+	// Add to the balance atomically, so credits from adjustments and vouchers landing meanwhile are kept
+	userWallet, err := uc.walletRepo.AddBalance(txCtx, tx.UserID, tx.NetAmount().Amount())
+	if err != nil {
+		_ = uc.tx.RollbackTx(txCtx)
+		uc.logger.WithContext(ctx).Error("Failed to update wallet", map[string]interface{}{"error": err})
+		return transaction.Transaction{}, wallet.Wallet{}, err
+	}
+	// Post the gross amount, fee and bonus separately; the balance moves by the net amount
+	postings := topupPostings(*tx, userWallet.ID)
+	balanceBefore := userWallet.Balance.Amount() - tx.NetAmount().Amount()
+	if err = uc.walletRepo.CreatePostings(txCtx, postings); err != nil {
+		_ = uc.tx.RollbackTx(txCtx)
+		uc.logger.WithContext(ctx).Error("Failed to record wallet postings", map[string]interface{}{"error": err})
+		return transaction.Transaction{}, wallet.Wallet{}, err
+	}
+	// Record events in the same transaction so they are published only if the top-up commits
+	err = uc.addEvent(txCtx, event.TypeTopupCompleted, event.AggregateTransaction, tx.ID, newTopupPayload(*tx))
+	if err == nil {
+		err = addPostingEvents(txCtx, uc.outboxRepo, postings, balanceBefore)
+	}
+	if err != nil {
+		_ = uc.tx.RollbackTx(txCtx)
+		uc.logger.WithContext(ctx).Error("Failed to write outbox events", map[string]interface{}{"error": err})
+		return transaction.Transaction{}, wallet.Wallet{}, err
+	}
+	if err := uc.tx.CommitTx(txCtx); err != nil {
+		uc.logger.WithContext(ctx).Error("Failed to commit top-up", map[string]interface{}{"error": err})
+		return transaction.Transaction{}, wallet.Wallet{}, err
+	}
+	uc.metrics.TopupCompleted(tx.PaymentMethod.String(), tx.Amount.Amount())
+	uc.logger.WithContext(ctx).Info("Top-up confirmed", map[string]interface{}{
+		"amount":     tx.Amount,
+		"fee":        tx.Fee,
+		"bonus":      tx.Bonus,
+		"net_amount": tx.NetAmount(),
 	})
 	// Remove from cache
-	_ = uc.cache.Delete(context.Background(), cacheKey)
+	_ = uc.cache.Delete(context.WithoutCancel(ctx), cacheKey)
 
 	return *tx, *userWallet, nil
 }
 
+// maxProviderReferenceLength matches the provider_reference column size
+const maxProviderReferenceLength = 100
+
+// checkProviderReference rejects a provider reference that is already recorded, on another top-up
+// or as a different reference on this one. The unique index still guards against a race.
+func (uc *WalletUsecaseImpl) checkProviderReference(ctx context.Context, tx transaction.Transaction, providerReference string) error {
+	if providerReference == "" || providerReference == tx.ProviderReference {
+		return nil
+	}
+	if tx.ProviderReference != "" {
+		return fmt.Errorf("%w: the top-up was charged under %s", errs.ErrInvalidProviderReference, tx.ProviderReference)
+	}
+	recorded, err := uc.transactionRepo.FindAll(&transaction.TransactionFilter{ProviderReferences: []string{providerReference}})
+	if err != nil {
+		return err
+	}
+	for _, t := range recorded {
+		if t.ID != tx.ID {
+			return errs.ErrProviderReferenceInUse
+		}
+	}
+	return nil
+}
+
+// topupPostings splits a top-up into the gross credit, the fee debit and the bonus credit
+func topupPostings(tx transaction.Transaction, walletID uint) []wallet.Posting {
+	postings := []wallet.Posting{{WalletID: walletID, TransactionID: tx.ID, Type: wallet.PostingTopup, Amount: tx.Amount.Amount()}}
+	if !tx.Fee.IsZero() {
+		postings = append(postings, wallet.Posting{WalletID: walletID, TransactionID: tx.ID, Type: wallet.PostingFee, Amount: -tx.Fee.Amount()})
+	}
+	if !tx.Bonus.IsZero() {
+		postings = append(postings, wallet.Posting{WalletID: walletID, TransactionID: tx.ID, Type: wallet.PostingBonus, Amount: tx.Bonus.Amount()})
+	}
+	return postings
+}
+
+// addPostingEvents records a wallet.balance_changed event for each posting, carrying the
+// running balance from balance, the balance before the first posting
+func addPostingEvents(ctx context.Context, outboxRepo outbox.Repository, postings []wallet.Posting, balance float64) error {
+	for _, posting := range postings {
+		balance += posting.Amount
+		message, err := newOutboxMessage(event.TypeWalletBalanceChanged, event.AggregateWallet, posting.WalletID, event.BalanceChangedPayload{
+			WalletID:      posting.WalletID,
+			TransactionID: posting.TransactionID,
+			Posting:       posting.Type.String(),
+			Delta:         posting.Amount,
+			Balance:       balance,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := outboxRepo.Create(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addEvent writes a domain event to the outbox using the transaction carried by ctx
+func (uc *WalletUsecaseImpl) addEvent(ctx context.Context, eventType event.Type, aggregateType string, aggregateID uint, payload interface{}) error {
+	message, err := newOutboxMessage(eventType, aggregateType, aggregateID, payload)
+	if err != nil {
+		return err
+	}
+	_, err = uc.outboxRepo.Create(ctx, message)
+	return err
+}
+
 // Helper function to generate cache key for transaction
 func getTransactionCacheKey(transactionID uint) string {
 	return "transaction:" + fmt.Sprintf("%d", transactionID)
 }
+
+// getTransactionLockKey returns the lock key that serializes work on a transaction. There is no
+// wallet-level key: every balance writer goes through walletRepo.AddBalance, which is atomic.
+func getTransactionLockKey(transactionID uint) string {
+	return fmt.Sprintf("transaction:%d", transactionID)
+}
+
+// runInTx runs fn inside a database transaction, rolling back if it fails
+func runInTx(ctx context.Context, tm domain.TxManager, fn func(txCtx context.Context) error) error {
+	txCtx, err := tm.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tm.RollbackTx(txCtx)
+			panic(r)
+		}
+	}()
+	if err := fn(txCtx); err != nil {
+		_ = tm.RollbackTx(txCtx)
+		return err
+	}
+	return tm.CommitTx(txCtx)
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+)
+
+// WebhookEventPublisher implements event.EventPublisher by queueing a delivery
+// for every active subscription interested in the event
+type WebhookEventPublisher struct {
+	subscriptionRepo webhook.SubscriptionRepository
+	deliveryRepo     webhook.DeliveryRepository
+}
+
+func NewWebhookEventPublisher(subscriptionRepo webhook.SubscriptionRepository, deliveryRepo webhook.DeliveryRepository) *WebhookEventPublisher {
+	return &WebhookEventPublisher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+func (p *WebhookEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	active := true
+	subscriptions, err := p.subscriptionRepo.FindAll(&webhook.SubscriptionFilter{Active: &active, EventType: &e.Type})
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event.NewEnvelope(e))
+	if err != nil {
+		return fmt.Errorf("failed to marshal event envelope: %w", err)
+	}
+	now := time.Now()
+	deliveries := make([]webhook.Delivery, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		deliveries = append(deliveries, webhook.Delivery{
+			SubscriptionID: s.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+			Status:         webhook.DeliveryStatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	return p.deliveryRepo.CreateMany(ctx, deliveries)
+}
+
+// WebhookDispatcher sends queued webhook deliveries, retrying failures with backoff
+type WebhookDispatcher struct {
+	subscriptionRepo webhook.SubscriptionRepository
+	deliveryRepo     webhook.DeliveryRepository
+	sender           webhook.Sender
+	logger           logger.Logger
+	cfg              config.WebhookConfig
+}
+
+// NewWebhookDispatcher creates a new instance of WebhookDispatcher
+func NewWebhookDispatcher(
+	subscriptionRepo webhook.SubscriptionRepository,
+	deliveryRepo webhook.DeliveryRepository,
+	sender webhook.Sender,
+	logger logger.Logger,
+	cfg config.WebhookConfig,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		sender:           sender,
+		logger:           logger,
+		cfg:              cfg,
+	}
+}
+
+// Run polls for due deliveries until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(d.cfg.PollInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	d.logger.Info("Webhook dispatcher started", nil)
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Webhook dispatcher stopped", nil)
+			return
+		case <-ticker.C:
+			if _, err := d.DispatchOnce(ctx); err != nil {
+				d.logger.Error("Webhook dispatch failed", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+}
+
+// DispatchOnce sends one batch of due deliveries and returns how many were attempted
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	timeout := time.Duration(d.cfg.Timeout) * time.Second
+	// Lease for as long as sending the whole batch can take so no other worker picks the same delivery up
+	claimedAt := time.Now()
+	leaseEnd := claimedAt.Add(d.cfg.Lease())
+	deliveries, err := d.deliveryRepo.ClaimDue(ctx, d.cfg.BatchSize, claimedAt, d.cfg.Lease())
+	if err != nil {
+		return 0, err
+	}
+	// The batch is already leased, so one bad delivery must not hold the rest back until the lease runs out
+	attempted := 0
+	for i, delivery := range deliveries {
+		if time.Until(leaseEnd) < timeout {
+			// Slow bookkeeping used up the lease; another worker may claim the rest once it ends
+			d.logger.Warn("Webhook batch lease ran out", map[string]interface{}{"skipped": len(deliveries) - i})
+			break
+		}
+		subscription, err := d.subscriptionRepo.FindById(delivery.SubscriptionID)
+		if err != nil {
+			d.logger.Error("Webhook subscription lookup failed", map[string]interface{}{
+				"delivery_id":     delivery.ID,
+				"subscription_id": delivery.SubscriptionID,
+				"error":           err.Error(),
+			})
+			continue
+		}
+		attempted++
+		if err := d.attempt(ctx, *subscription, delivery); err != nil {
+			d.logger.Error("Webhook delivery attempt failed", map[string]interface{}{
+				"delivery_id":     delivery.ID,
+				"subscription_id": delivery.SubscriptionID,
+				"error":           err.Error(),
+			})
+		}
+	}
+	return attempted, nil
+}
+
+// attempt sends a delivery once and records the outcome
+func (d *WebhookDispatcher) attempt(ctx context.Context, subscription webhook.Subscription, delivery webhook.Delivery) error {
+	now := time.Now()
+	req := webhook.Request{
+		URL: subscription.URL,
+		Headers: map[string]string{
+			webhook.SignatureHeader: webhook.Sign(subscription.Secret, now, delivery.Payload),
+			webhook.EventHeader:     delivery.EventType.String(),
+			webhook.DeliveryHeader:  strconv.FormatUint(uint64(delivery.ID), 10),
+		},
+		Body: delivery.Payload,
+	}
+	resp, sendErr := d.sender.Send(ctx, req)
+	duration := time.Since(now)
+
+	delivery.Attempts++
+	delivery.LastResponseCode = resp.StatusCode
+	delivery.LastError = ""
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+	} else if !resp.Succeeded() {
+		delivery.LastError = fmt.Sprintf("unexpected status %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	if err := d.deliveryRepo.CreateAttempt(ctx, webhook.Attempt{
+		DeliveryID:   delivery.ID,
+		Attempt:      delivery.Attempts,
+		ResponseCode: resp.StatusCode,
+		Error:        delivery.LastError,
+		Duration:     duration,
+		CreatedAt:    now,
+	}); err != nil {
+		return err
+	}
+
+	if delivery.LastError == "" {
+		deliveredAt := time.Now()
+		delivery.Status = webhook.DeliveryStatusSucceeded
+		delivery.DeliveredAt = &deliveredAt
+		if err := d.deliveryRepo.Update(ctx, delivery); err != nil {
+			return err
+		}
+		return d.subscriptionRepo.ResetFailures(ctx, subscription.ID)
+	}
+
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = webhook.DeliveryStatusFailed
+	} else {
+		delivery.NextAttemptAt = time.Now().Add(backoffWithJitter(delivery.Attempts,
+			time.Duration(d.cfg.BaseBackoff)*time.Second, time.Duration(d.cfg.MaxBackoff)*time.Second))
+	}
+	if err := d.deliveryRepo.Update(ctx, delivery); err != nil {
+		return err
+	}
+	d.logger.Warn("Webhook delivery failed", map[string]interface{}{
+		"delivery_id":     delivery.ID,
+		"subscription_id": subscription.ID,
+		"attempts":        delivery.Attempts,
+		"status_code":     resp.StatusCode,
+		"error":           delivery.LastError,
+	})
+
+	disabled, err := d.subscriptionRepo.RecordFailure(ctx, subscription.ID, d.cfg.DisableAfter)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		d.logger.Error("Webhook subscription disabled after repeated failures", map[string]interface{}{
+			"subscription_id": subscription.ID,
+			"url":             subscription.URL,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, map[string]interface{})        {}
+func (nopLogger) Info(string, map[string]interface{})         {}
+func (nopLogger) Warn(string, map[string]interface{})         {}
+func (nopLogger) Error(string, map[string]interface{})        {}
+func (nopLogger) Fatal(string, map[string]interface{})        {}
+func (l nopLogger) With(map[string]interface{}) logger.Logger { return l }
+func (l nopLogger) WithContext(context.Context) logger.Logger { return l }
+func (nopLogger) Sync() error                                 { return nil }
+
+type fakeSubscriptionRepo struct {
+	mu            sync.Mutex
+	subscriptions map[uint]*webhook.Subscription
+}
+
+func (r *fakeSubscriptionRepo) Create(ctx context.Context, s webhook.Subscription) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.ID = uint(len(r.subscriptions) + 1)
+	r.subscriptions[s.ID] = &s
+	return s.ID, nil
+}
+
+func (r *fakeSubscriptionRepo) FindById(id uint) (*webhook.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.subscriptions[id]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	copied := *s
+	return &copied, nil
+}
+
+func (r *fakeSubscriptionRepo) FindAll(filter *webhook.SubscriptionFilter) ([]webhook.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []webhook.Subscription
+	for _, s := range r.subscriptions {
+		if filter != nil && filter.Active != nil && s.Active != *filter.Active {
+			continue
+		}
+		if filter != nil && filter.EventType != nil && !s.Subscribes(*filter.EventType) {
+			continue
+		}
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
+func (r *fakeSubscriptionRepo) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) SetActive(ctx context.Context, id uint, active bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[id].Active = active
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) RecordFailure(ctx context.Context, id uint, disableAfter int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.subscriptions[id]
+	s.ConsecutiveFailures++
+	if s.Active && s.ConsecutiveFailures >= disableAfter {
+		now := time.Now()
+		s.Active = false
+		s.DisabledAt = &now
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *fakeSubscriptionRepo) ResetFailures(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[id].ConsecutiveFailures = 0
+	return nil
+}
+
+type fakeDeliveryRepo struct {
+	mu         sync.Mutex
+	deliveries map[uint]*webhook.Delivery
+	attempts   []webhook.Attempt
+	subs       *fakeSubscriptionRepo
+}
+
+func (r *fakeDeliveryRepo) CreateMany(ctx context.Context, deliveries []webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range deliveries {
+		d.ID = uint(len(r.deliveries) + 1)
+		r.deliveries[d.ID] = &d
+	}
+	return nil
+}
+
+func (r *fakeDeliveryRepo) ClaimDue(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []webhook.Delivery
+	for _, d := range r.deliveries {
+		s, _ := r.subs.FindById(d.SubscriptionID)
+		if d.Status != webhook.DeliveryStatusPending || d.NextAttemptAt.After(now) || s == nil || !s.Active {
+			continue
+		}
+		d.NextAttemptAt = now.Add(lease)
+		result = append(result, *d)
+	}
+	return result, nil
+}
+
+func (r *fakeDeliveryRepo) Update(ctx context.Context, delivery webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[delivery.ID] = &delivery
+	return nil
+}
+
+func (r *fakeDeliveryRepo) FindById(id uint) (*webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *fakeDeliveryRepo) FindAll(filter *webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	return nil, nil
+}
+
+func (r *fakeDeliveryRepo) CreateAttempt(ctx context.Context, attempt webhook.Attempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *fakeDeliveryRepo) FindAttempts(deliveryID uint) ([]webhook.Attempt, error) {
+	return nil, nil
+}
+
+func newWebhookFixture(t *testing.T, handler http.HandlerFunc, cfg config.WebhookConfig) (*fakeSubscriptionRepo, *fakeDeliveryRepo, *WebhookDispatcher, webhook.Subscription) {
+	t.Helper()
+	receiver := httptest.NewServer(handler)
+	t.Cleanup(receiver.Close)
+
+	subs := &fakeSubscriptionRepo{subscriptions: map[uint]*webhook.Subscription{}}
+	deliveries := &fakeDeliveryRepo{deliveries: map[uint]*webhook.Delivery{}, subs: subs}
+	uc := NewWebhookUsecase(subs, deliveries, nopLogger{})
+	subscription, err := uc.CreateSubscription(context.Background(), receiver.URL, []string{"topup.completed"}, "")
+	require.NoError(t, err)
+
+	publisher := NewWebhookEventPublisher(subs, deliveries)
+	require.NoError(t, publisher.Publish(context.Background(), event.Event{
+		ID:            7,
+		Type:          event.TypeTopupCompleted,
+		AggregateType: event.AggregateTransaction,
+		AggregateID:   "1",
+		Payload:       []byte(`{"transaction_id":1}`),
+		OccurredAt:    time.Now(),
+	}))
+	// Events the subscription did not ask for are not queued
+	require.NoError(t, publisher.Publish(context.Background(), event.Event{ID: 8, Type: event.TypeTopupVerified, Payload: []byte(`{}`)}))
+	require.Len(t, deliveries.deliveries, 1)
+
+	sender := infrastructure.NewHTTPWebhookSender(time.Second)
+	return subs, deliveries, NewWebhookDispatcher(subs, deliveries, sender, nopLogger{}, cfg), subscription
+}
+
+func TestWebhookDispatcherDeliversSignedRequest(t *testing.T) {
+	var (
+		gotSignature string
+		gotBody      []byte
+		gotEvent     string
+	)
+	_, deliveries, dispatcher, subscription := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(webhook.SignatureHeader)
+		gotEvent = r.Header.Get(webhook.EventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}, config.WebhookConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: 1, MaxBackoff: 1, Timeout: 1, DisableAfter: 5})
+	secret := subscription.Secret
+
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	assert.Equal(t, "topup.completed", gotEvent)
+	assert.Contains(t, string(gotBody), `"type":"topup.completed"`)
+	assert.True(t, webhook.VerifySignature(secret, gotSignature, gotBody, time.Minute, time.Now()))
+	assert.False(t, webhook.VerifySignature("wrong-secret", gotSignature, gotBody, time.Minute, time.Now()))
+
+	delivery := deliveries.deliveries[1]
+	assert.Equal(t, webhook.DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, http.StatusNoContent, delivery.LastResponseCode)
+	require.Len(t, deliveries.attempts, 1)
+	assert.Equal(t, http.StatusNoContent, deliveries.attempts[0].ResponseCode)
+}
+
+func TestWebhookDispatcherRetriesAndDisablesFailingEndpoint(t *testing.T) {
+	var calls int
+	subs, deliveries, dispatcher, subscription := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}, config.WebhookConfig{BatchSize: 10, MaxAttempts: 5, BaseBackoff: 1, MaxBackoff: 1, Timeout: 1, DisableAfter: 2})
+
+	_, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+
+	delivery := deliveries.deliveries[1]
+	assert.Equal(t, webhook.DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.LastResponseCode)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now()), "retry should be scheduled in the future")
+
+	// Make the retry due and fail again; the second consecutive failure disables the endpoint
+	delivery.NextAttemptAt = time.Now()
+	_, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+
+	s, _ := subs.FindById(subscription.ID)
+	assert.False(t, s.Active)
+	assert.NotNil(t, s.DisabledAt)
+	require.Len(t, deliveries.attempts, 2)
+
+	// Disabled endpoints are not called even when a delivery is due
+	deliveries.deliveries[1].NextAttemptAt = time.Now()
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWebhookRedeliverAfterFinalFailure(t *testing.T) {
+	fail := true
+	subs, deliveries, dispatcher, _ := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, config.WebhookConfig{BatchSize: 10, MaxAttempts: 1, BaseBackoff: 1, MaxBackoff: 1, Timeout: 1, DisableAfter: 10})
+
+	_, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryStatusFailed, deliveries.deliveries[1].Status)
+
+	fail = false
+	uc := NewWebhookUsecase(subs, deliveries, nopLogger{})
+	require.NoError(t, uc.Redeliver(context.Background(), 1))
+	_, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, webhook.DeliveryStatusSucceeded, deliveries.deliveries[1].Status)
+	assert.Len(t, deliveries.attempts, 2)
+}
+
+// staleClaimRepo hands out, ahead of the real batch, a delivery whose subscription was deleted after it was claimed
+type staleClaimRepo struct {
+	*fakeDeliveryRepo
+}
+
+func (r staleClaimRepo) ClaimDue(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]webhook.Delivery, error) {
+	due, err := r.fakeDeliveryRepo.ClaimDue(ctx, limit, now, lease)
+	stale := webhook.Delivery{ID: 99, SubscriptionID: 99, Status: webhook.DeliveryStatusPending}
+	return append([]webhook.Delivery{stale}, due...), err
+}
+
+func TestWebhookDispatcherSkipsDeliveryOfDeletedSubscription(t *testing.T) {
+	cfg := config.WebhookConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: 1, MaxBackoff: 1, Timeout: 1, DisableAfter: 5}
+	subs, deliveries, _, _ := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, cfg)
+	sender := infrastructure.NewHTTPWebhookSender(time.Second)
+	dispatcher := NewWebhookDispatcher(subs, staleClaimRepo{deliveries}, sender, nopLogger{}, cfg)
+
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, webhook.DeliveryStatusSucceeded, deliveries.deliveries[1].Status)
+}
+
+func TestWebhookRedeliverStartsAttemptsOver(t *testing.T) {
+	subs, deliveries, dispatcher, _ := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}, config.WebhookConfig{BatchSize: 10, MaxAttempts: 2, BaseBackoff: 60, MaxBackoff: 60, Timeout: 1, DisableAfter: 10})
+
+	for i := 0; i < 2; i++ {
+		deliveries.deliveries[1].NextAttemptAt = time.Now()
+		_, err := dispatcher.DispatchOnce(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, webhook.DeliveryStatusFailed, deliveries.deliveries[1].Status)
+
+	uc := NewWebhookUsecase(subs, deliveries, nopLogger{})
+	require.NoError(t, uc.Redeliver(context.Background(), 1))
+	assert.Zero(t, deliveries.deliveries[1].Attempts)
+	_, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+
+	delivery := deliveries.deliveries[1]
+	assert.Equal(t, webhook.DeliveryStatusPending, delivery.Status, "a failed redelivery is retried like a new one")
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now()), "the retry waits for its backoff")
+	assert.Len(t, deliveries.attempts, 3)
+}
+
+// leaseRecordingRepo remembers the lease each batch was claimed with
+type leaseRecordingRepo struct {
+	*fakeDeliveryRepo
+	lease time.Duration
+}
+
+func (r *leaseRecordingRepo) ClaimDue(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]webhook.Delivery, error) {
+	r.lease = lease
+	return r.fakeDeliveryRepo.ClaimDue(ctx, limit, now, lease)
+}
+
+func TestWebhookDispatcherLeasesBatchForEverySend(t *testing.T) {
+	cfg := config.WebhookConfig{BatchSize: 20, MaxAttempts: 3, BaseBackoff: 1, MaxBackoff: 1, Timeout: 10, DisableAfter: 5}
+	subs, deliveries, _, _ := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, cfg)
+	repo := &leaseRecordingRepo{fakeDeliveryRepo: deliveries}
+	dispatcher := NewWebhookDispatcher(subs, repo, infrastructure.NewHTTPWebhookSender(time.Second), nopLogger{}, cfg)
+
+	_, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 210*time.Second, repo.lease, "20 sends of up to 10s each, plus one timeout of margin")
+}
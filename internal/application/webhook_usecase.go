@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+)
+
+type WebhookUsecase interface {
+	CreateSubscription(ctx context.Context, endpoint string, eventTypes []string, secret string) (webhook.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]webhook.Subscription, error)
+	GetSubscription(ctx context.Context, id uint) (webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id uint) error
+	EnableSubscription(ctx context.Context, id uint) error
+	ListDeliveries(ctx context.Context, subscriptionID uint) ([]webhook.Delivery, error)
+	GetDelivery(ctx context.Context, deliveryID uint) (webhook.Delivery, []webhook.Attempt, error)
+	Redeliver(ctx context.Context, deliveryID uint) error
+}
+
+// WebhookUsecaseImpl manages partner webhook subscriptions and their delivery log
+type WebhookUsecaseImpl struct {
+	subscriptionRepo webhook.SubscriptionRepository
+	deliveryRepo     webhook.DeliveryRepository
+	logger           logger.Logger
+}
+
+// NewWebhookUsecase creates a new instance of WebhookUsecase
+func NewWebhookUsecase(
+	subscriptionRepo webhook.SubscriptionRepository,
+	deliveryRepo webhook.DeliveryRepository,
+	logger logger.Logger,
+) WebhookUsecase {
+	return &WebhookUsecaseImpl{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		logger:           logger,
+	}
+}
+
+// CreateSubscription registers an endpoint; a signing secret is generated when none is given
+func (uc *WebhookUsecaseImpl) CreateSubscription(ctx context.Context, endpoint string, eventTypes []string, secret string) (webhook.Subscription, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return webhook.Subscription{}, errs.ErrInvalidWebhookURL
+	}
+	if len(eventTypes) == 0 {
+		return webhook.Subscription{}, errs.ErrInvalidEventType
+	}
+	types := make([]event.Type, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		et, err := event.NewType(t)
+		if err != nil {
+			return webhook.Subscription{}, err
+		}
+		types = append(types, et)
+	}
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return webhook.Subscription{}, err
+		}
+	}
+
+	subscription := webhook.Subscription{
+		URL:        endpoint,
+		Secret:     secret,
+		EventTypes: types,
+		Active:     true,
+		CreatedAt:  time.Now(),
+	}
+	id, err := uc.subscriptionRepo.Create(ctx, subscription)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+	subscription.ID = id
+
+	uc.logger.WithContext(ctx).Info("Webhook subscription created", map[string]interface{}{"subscription_id": id, "url": endpoint})
+	return subscription, nil
+}
+
+func (uc *WebhookUsecaseImpl) ListSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	return uc.subscriptionRepo.FindAll(nil)
+}
+
+func (uc *WebhookUsecaseImpl) GetSubscription(ctx context.Context, id uint) (webhook.Subscription, error) {
+	subscription, err := uc.subscriptionRepo.FindById(id)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+	return *subscription, nil
+}
+
+func (uc *WebhookUsecaseImpl) DeleteSubscription(ctx context.Context, id uint) error {
+	return uc.subscriptionRepo.Delete(ctx, id)
+}
+
+// EnableSubscription re-activates a subscription that was disabled after repeated failures
+func (uc *WebhookUsecaseImpl) EnableSubscription(ctx context.Context, id uint) error {
+	if err := uc.subscriptionRepo.SetActive(ctx, id, true); err != nil {
+		return err
+	}
+	uc.logger.WithContext(ctx).Info("Webhook subscription enabled", map[string]interface{}{"subscription_id": id})
+	return nil
+}
+
+func (uc *WebhookUsecaseImpl) ListDeliveries(ctx context.Context, subscriptionID uint) ([]webhook.Delivery, error) {
+	if _, err := uc.subscriptionRepo.FindById(subscriptionID); err != nil {
+		return nil, err
+	}
+	return uc.deliveryRepo.FindAll(&webhook.DeliveryFilter{SubscriptionID: &subscriptionID})
+}
+
+// GetDelivery returns a delivery with its per-attempt log
+func (uc *WebhookUsecaseImpl) GetDelivery(ctx context.Context, deliveryID uint) (webhook.Delivery, []webhook.Attempt, error) {
+	delivery, err := uc.deliveryRepo.FindById(deliveryID)
+	if err != nil {
+		return webhook.Delivery{}, nil, err
+	}
+	attempts, err := uc.deliveryRepo.FindAttempts(deliveryID)
+	if err != nil {
+		return webhook.Delivery{}, nil, err
+	}
+	return *delivery, attempts, nil
+}
+
+// Redeliver queues a delivery to be sent again immediately, whatever its current status. It
+// starts over with the full number of attempts and backoff between them.
+func (uc *WebhookUsecaseImpl) Redeliver(ctx context.Context, deliveryID uint) error {
+	delivery, err := uc.deliveryRepo.FindById(deliveryID)
+	if err != nil {
+		return err
+	}
+	delivery.Status = webhook.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.DeliveredAt = nil
+	if err := uc.deliveryRepo.Update(ctx, *delivery); err != nil {
+		return err
+	}
+	uc.logger.WithContext(ctx).Info("Webhook delivery requeued", map[string]interface{}{"delivery_id": deliveryID})
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,97 @@
+package autotopup
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Trigger says when a rule tops up the wallet
+type Trigger string
+
+const (
+	// TriggerThreshold tops up when the balance falls below Threshold
+	TriggerThreshold Trigger = "threshold"
+	// TriggerSchedule tops up at the times given by a cron expression
+	TriggerSchedule Trigger = "schedule"
+)
+
+func (t Trigger) Valid() bool {
+	switch t {
+	case TriggerThreshold, TriggerSchedule:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewTrigger(trigger string) (Trigger, error) {
+	t := Trigger(strings.ToLower(strings.TrimSpace(trigger)))
+	if !t.Valid() {
+		return "", errs.ErrInvalidAutoTopup
+	}
+	return t, nil
+}
+
+func (t Trigger) String() string {
+	return string(t)
+}
+
+// Rule tops up a wallet automatically by charging a saved payment instrument
+type Rule struct {
+	ID           uint
+	UserID       uint // wallets share their owner's ID
+	Trigger      Trigger
+	Threshold    float64 // for TriggerThreshold
+	Schedule     string  // for TriggerSchedule, a five-field cron expression in UTC
+	Amount       vo.Money
+	InstrumentID uint
+	Enabled      bool
+	// NextRunAt is when the scheduler next runs the rule; nil while a threshold rule waits for
+	// the balance to fall
+	NextRunAt *time.Time
+	// Attempts counts failed attempts of the current run
+	Attempts int
+	// RunKey identifies the current run to the payment provider, so however often the run is
+	// retried, and whichever top-up it credits, the instrument is charged at most once
+	RunKey string
+	// RunTransactionID is the top-up verified for the current run, saved before it is charged
+	RunTransactionID uint
+	LastRunAt        *time.Time // when the last run finished, successfully or not
+	LastError        string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Completed resets the rule after a run finishes, successfully or after its last retry
+func (r *Rule) Completed(now time.Time, lastError string) {
+	r.Attempts = 0
+	r.RunKey = ""
+	r.RunTransactionID = 0
+	r.LastRunAt = &now
+	r.LastError = lastError
+	r.NextRunAt = nil
+	if r.Trigger == TriggerSchedule {
+		if schedule, err := ParseSchedule(r.Schedule); err == nil {
+			if next := schedule.Next(now); !next.IsZero() {
+				r.NextRunAt = &next
+			}
+		}
+	}
+}
+
+type RuleFilter struct {
+	UserID *uint
+}
+
+// RuleSpec describes a rule requested by a user
+type RuleSpec struct {
+	UserID       uint
+	Trigger      string
+	Threshold    float64
+	Schedule     string
+	Amount       float64
+	InstrumentID uint
+}
@@ -0,0 +1,19 @@
+package autotopup
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, rule Rule) (uint, error)
+	Update(ctx context.Context, rule Rule) error
+	Delete(ctx context.Context, id, userID uint) error
+	FindAll(ctx context.Context, filter *RuleFilter) ([]Rule, error)
+	// FindDue returns enabled rules whose next run is at or before now, locking them with
+	// SKIP LOCKED when ctx carries a database transaction
+	FindDue(ctx context.Context, now time.Time, limit int) ([]Rule, error)
+	// MarkThresholdDue schedules the enabled threshold rules of a user whose threshold is above
+	// balance and that have not run since cooldownSince. It returns how many rules became due.
+	MarkThresholdDue(ctx context.Context, userID uint, balance float64, now, cooldownSince time.Time) (int64, error)
+}
@@ -0,0 +1,81 @@
+package autotopup
+
+import (
+	"testing"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// A Monday
+	monday := time.Date(2026, 10, 19, 10, 7, 0, 0, time.UTC)
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", monday, at(2026, 10, 19, 10, 8)},
+		{"step", "*/15 * * * *", monday, at(2026, 10, 19, 10, 15)},
+		{"strictly after a match", "*/15 * * * *", at(2026, 10, 19, 10, 15), at(2026, 10, 19, 10, 30)},
+		{"seconds are ignored", "*/15 * * * *", monday.Add(59 * time.Second), at(2026, 10, 19, 10, 15)},
+		{"stepped range", "5-20/5 * * * *", monday, at(2026, 10, 19, 10, 10)},
+		{"stepped value runs to the end of the field", "50/5 * * * *", monday, at(2026, 10, 19, 10, 50)},
+		{"list", "0 8,20 * * *", monday, at(2026, 10, 19, 20, 0)},
+		{"list of ranges", "0 1-2,22-23 * * *", monday, at(2026, 10, 19, 22, 0)},
+		{"weekday range", "30 9 * * 1-5", monday, at(2026, 10, 20, 9, 30)},
+		{"sunday as 0", "0 0 * * 0", monday, at(2026, 10, 25, 0, 0)},
+		{"sunday as 7", "0 0 * * 7", monday, at(2026, 10, 25, 0, 0)},
+		{"weekend range ending in 7", "0 12 * * 6-7", monday, at(2026, 10, 24, 12, 0)},
+		{"both day fields: weekday first", "0 0 1 * 5", monday, at(2026, 10, 23, 0, 0)},
+		{"both day fields: day of month first", "0 0 20 * 5", monday, at(2026, 10, 20, 0, 0)},
+		{"a starred step is not a restriction, so both fields must match", "0 0 21 * */2", monday, at(2026, 11, 21, 0, 0)},
+		{"day of month", "0 9 1 * *", monday, at(2026, 11, 1, 9, 0)},
+		{"month rollover skips short months", "0 0 31 * *", at(2026, 11, 1, 0, 0), at(2026, 12, 31, 0, 0)},
+		{"year rollover", "0 0 1 1 *", monday, at(2027, 1, 1, 0, 0)},
+		{"last minute of the year", "* * * * *", at(2026, 12, 31, 23, 59), at(2027, 1, 1, 0, 0)},
+		{"leap day", "0 0 29 2 *", monday, at(2028, 2, 29, 0, 0)},
+		{"hours are UTC", "0 9 * * *", time.Date(2026, 10, 19, 15, 0, 0, 0, time.FixedZone("ICT", 7*60*60)), at(2026, 10, 19, 9, 0)},
+		{"never matches", "0 0 31 2 *", monday, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(tt.from))
+		})
+	}
+}
+
+func TestParseScheduleRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"1- * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseSchedule(expr)
+			assert.ErrorIs(t, err, errs.ErrInvalidAutoTopup)
+		})
+	}
+}
@@ -2,11 +2,24 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss is returned by Get when the key does not exist or has expired
+var ErrCacheMiss = errors.New("cache: key does not exist")
+
+// ErrCacheUnavailable is returned when the cache backend is unhealthy and calls are being skipped
+var ErrCacheUnavailable = errors.New("cache: backend unavailable")
+
 type CacheService interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string, dest interface{}) error
 	Delete(ctx context.Context, key string) error
 }
+
+// HealthChecker is implemented by cache backends that can report their health
+type HealthChecker interface {
+	// Health returns nil when the backend is usable
+	Health(ctx context.Context) error
+}
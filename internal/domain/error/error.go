@@ -10,3 +10,39 @@ var ErrTransactionNotVerified = errors.New("transaction is not in 'verified' sta
 var ErrAmountExceedsLimit = errors.New("amount exceeds maximum limit")
 var ErrInvalidPaymentMethod = errors.New("invalid payment method")
 var ErrInvalidTransactionStatus = errors.New("invalid transaction status")
+var ErrInvalidKYCStatus = errors.New("invalid kyc status")
+var ErrInvalidKYCTier = errors.New("invalid kyc tier")
+var ErrInvalidDocumentType = errors.New("invalid document type")
+var ErrKYCAlreadyPending = errors.New("kyc review is already pending")
+var ErrKYCNotPending = errors.New("kyc is not in 'pending' status")
+var ErrTierLimitExceeded = errors.New("amount exceeds kyc tier limit")
+var ErrPaymentMethodNotAllowed = errors.New("payment method not allowed for kyc tier")
+var ErrInvalidRiskDecision = errors.New("invalid risk decision")
+var ErrTopupRejected = errors.New("top-up rejected by risk checks")
+var ErrChallengeRequired = errors.New("transaction requires additional verification")
+var ErrTransactionNotChallenged = errors.New("transaction is not awaiting a risk challenge")
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+var ErrInvalidEventType = errors.New("invalid event type")
+var ErrLockNotAcquired = errors.New("lock is held by another process")
+var ErrLockLost = errors.New("lock is no longer held")
+var ErrConfirmInProgress = errors.New("transaction confirmation already in progress")
+var ErrInvalidLogLevel = errors.New("invalid log level")
+var ErrInvalidAdjustment = errors.New("adjustment amount must not be zero")
+var ErrAdjustmentReasonRequired = errors.New("adjustment reason is required")
+var ErrPaymentMethodDisabled = errors.New("payment method is disabled")
+var ErrUnknownSetting = errors.New("unknown setting")
+var ErrInvalidSettingValue = errors.New("invalid setting value")
+var ErrSettingActorRequired = errors.New("setting change actor is required")
+var ErrAmountBelowFee = errors.New("amount does not cover the top-up fee")
+var ErrInvalidVoucher = errors.New("invalid voucher")
+var ErrVoucherNotActive = errors.New("voucher is not active")
+var ErrVoucherExhausted = errors.New("voucher has been fully redeemed")
+var ErrVoucherLimitReached = errors.New("voucher redemption limit reached for this user")
+var ErrInvalidAutoTopup = errors.New("invalid auto top-up rule")
+var ErrInvalidInstrument = errors.New("invalid payment instrument")
+var ErrInstrumentExpired = errors.New("payment instrument has expired")
+var ErrInstrumentInUse = errors.New("payment instrument is used by an auto top-up rule")
+var ErrInvalidReconciliation = errors.New("invalid settlement reconciliation")
+var ErrInvalidStatement = errors.New("invalid statement request")
+var ErrInvalidProviderReference = errors.New("invalid provider reference")
+var ErrProviderReferenceInUse = errors.New("provider reference is already recorded on another top-up")
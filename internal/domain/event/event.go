@@ -0,0 +1,138 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+)
+
+type Type string
+
+const (
+	TypeTopupVerified        Type = "topup.verified"
+	TypeTopupCompleted       Type = "topup.completed"
+	TypeTopupExpired         Type = "topup.expired"
+	TypeWalletBalanceChanged Type = "wallet.balance_changed"
+	TypeAutoTopupSucceeded   Type = "autotopup.succeeded"
+	TypeAutoTopupFailed      Type = "autotopup.failed"
+)
+
+func (t Type) String() string {
+	return string(t)
+}
+
+const (
+	AggregateTransaction = "transaction"
+	AggregateWallet      = "wallet"
+	AggregateAutoTopup   = "auto_topup_rule"
+)
+
+// Event is a domain event ready to be handed to a publisher
+type Event struct {
+	ID            uint // outbox message ID, stable across redeliveries
+	Type          Type
+	AggregateType string
+	AggregateID   string
+	Payload       []byte // JSON encoded event data
+	OccurredAt    time.Time
+}
+
+// EventPublisher delivers events to downstream consumers.
+// Publish may be called more than once for the same event ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// TopupPayload is the data carried by topup.* events
+type TopupPayload struct {
+	TransactionID uint    `json:"transaction_id"`
+	UserID        uint    `json:"user_id"`
+	Amount        float64 `json:"amount"` // gross amount paid
+	Fee           float64 `json:"fee"`
+	Bonus         float64 `json:"bonus"`
+	NetAmount     float64 `json:"net_amount"` // amount credited to the wallet
+	Campaign      string  `json:"campaign,omitempty"`
+	PaymentMethod string  `json:"payment_method"`
+	InstrumentID  uint    `json:"instrument_id,omitempty"` // saved instrument charged, if any
+	Status        string  `json:"status"`
+}
+
+// BalanceChangedPayload is the data carried by wallet.balance_changed events
+type BalanceChangedPayload struct {
+	WalletID      uint    `json:"wallet_id"`
+	TransactionID uint    `json:"transaction_id"`
+	AdjustmentID  uint    `json:"adjustment_id,omitempty"` // set when an operator adjusted the balance
+	Posting       string  `json:"posting,omitempty"`       // part of a top-up: topup, fee or bonus
+	Delta         float64 `json:"delta"`
+	Balance       float64 `json:"balance"`
+}
+
+// AutoTopupPayload is the data carried by autotopup.* events
+type AutoTopupPayload struct {
+	RuleID        uint    `json:"rule_id"`
+	UserID        uint    `json:"user_id"`
+	Trigger       string  `json:"trigger"`
+	TransactionID uint    `json:"transaction_id,omitempty"` // unset when the top-up was not verified
+	Amount        float64 `json:"amount"`
+	Attempts      int     `json:"attempts"`
+	Error         string  `json:"error,omitempty"` // why the last attempt failed
+}
+
+// SchemaVersion is bumped whenever an envelope or payload field changes incompatibly
+const SchemaVersion = 1
+
+// Envelope is the versioned wire format used by external publishers
+type Envelope struct {
+	ID            uint            `json:"id"`
+	Type          Type            `json:"type"`
+	Version       int             `json:"version"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func NewEnvelope(e Event) Envelope {
+	return Envelope{
+		ID:            e.ID,
+		Type:          e.Type,
+		Version:       SchemaVersion,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		OccurredAt:    e.OccurredAt,
+		Data:          json.RawMessage(e.Payload),
+	}
+}
+
+func (t Type) Valid() bool {
+	switch t {
+	case TypeTopupVerified, TypeTopupCompleted, TypeTopupExpired, TypeWalletBalanceChanged,
+		TypeAutoTopupSucceeded, TypeAutoTopupFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewType(eventType string) (Type, error) {
+	t := Type(strings.ToLower(eventType))
+	if !t.Valid() {
+		return "", errs.ErrInvalidEventType
+	}
+	return t, nil
+}
+
+// MultiPublisher publishes every event to each of its publishers in turn
+type MultiPublisher []EventPublisher
+
+func (m MultiPublisher) Publish(ctx context.Context, e Event) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// Status values reported for a single check and for the overall report
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusReady        = "ready"
+	StatusDegraded     = "degraded"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Details carries check-specific information, such as pool statistics, into the report
+type Details map[string]interface{}
+
+// Check is a named dependency probe. A failing critical check makes the service not ready;
+// a failing non-critical check only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) (Details, error)
+}
+
+// Result is the outcome of running one Check
+type Result struct {
+	Status   string
+	Critical bool
+	Latency  time.Duration
+	Error    string
+	Details  Details
+}
+
+// Report aggregates the results of all checks
+type Report struct {
+	Status string
+	Checks map[string]Result
+}
+
+// Ready reports whether the service should receive traffic
+func (r Report) Ready() bool {
+	return r.Status == StatusReady || r.Status == StatusDegraded
+}
@@ -0,0 +1,109 @@
+package instrument
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Type is the kind of saved payment instrument
+type Type string
+
+const (
+	TypeCard        Type = "card"
+	TypeBankAccount Type = "bank_account"
+)
+
+func (t Type) Valid() bool {
+	switch t {
+	case TypeCard, TypeBankAccount:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewType(instrumentType string) (Type, error) {
+	t := Type(strings.ToLower(strings.TrimSpace(instrumentType)))
+	if !t.Valid() {
+		return "", errs.ErrInvalidInstrument
+	}
+	return t, nil
+}
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// PaymentMethod is the payment method of top-ups paid with an instrument of this type
+func (t Type) PaymentMethod() vo.PaymentMethod {
+	if t == TypeBankAccount {
+		return vo.PaymentMethodBankTransfer
+	}
+	return vo.PaymentMethodCreditCard
+}
+
+// Card holds the displayable details of a tokenized card; the card number never reaches us
+type Card struct {
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+}
+
+// ExpiredAt reports whether the card has expired by now. Cards are valid through the last day
+// of their expiry month.
+func (c Card) ExpiredAt(now time.Time) bool {
+	return !now.UTC().Before(time.Date(c.ExpYear, time.Month(c.ExpMonth)+1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// BankAccount holds the displayable details of a tokenized bank account
+type BankAccount struct {
+	BankName      string `json:"bank_name"`
+	AccountHolder string `json:"account_holder"`
+	Last4         string `json:"last4"`
+}
+
+// Instrument is a saved payment instrument. Token is the provider's reference to it and is only
+// used to charge it; the token and details are encrypted at rest.
+type Instrument struct {
+	ID          uint
+	UserID      uint
+	Type        Type
+	Token       string
+	Card        *Card        // set for TypeCard
+	BankAccount *BankAccount // set for TypeBankAccount
+	CreatedAt   time.Time
+}
+
+// PaymentMethod is the payment method of top-ups paid with the instrument
+func (i Instrument) PaymentMethod() vo.PaymentMethod {
+	return i.Type.PaymentMethod()
+}
+
+// Expired reports whether the instrument can no longer be charged
+func (i Instrument) Expired(now time.Time) bool {
+	return i.Card != nil && i.Card.ExpiredAt(now)
+}
+
+// Spec describes an instrument a user asks to save
+type Spec struct {
+	UserID        uint
+	Type          string
+	Token         string
+	Brand         string
+	Last4         string
+	ExpMonth      int
+	ExpYear       int
+	BankName      string
+	AccountHolder string
+}
+
+// Cipher encrypts instrument secrets at rest. Additional data is authenticated but not
+// encrypted, and must be the same to decrypt.
+type Cipher interface {
+	Encrypt(plaintext, additionalData []byte) ([]byte, error)
+	Decrypt(ciphertext, additionalData []byte) ([]byte, error)
+}
@@ -0,0 +1,10 @@
+package instrument
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, instrument Instrument) (uint, error)
+	FindByID(ctx context.Context, id uint) (*Instrument, error)
+	FindByUser(ctx context.Context, userID uint) ([]Instrument, error)
+	Delete(ctx context.Context, id, userID uint) error
+}
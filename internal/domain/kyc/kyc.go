@@ -0,0 +1,64 @@
+package kyc
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Document represents an identity document submitted for KYC review
+type Document struct {
+	ID           uint
+	UserID       uint
+	DocumentType vo.DocumentType
+	StorageKey   string
+	Status       vo.KYCStatus
+	ReviewNote   string
+	ReviewedAt   *time.Time
+	CreatedAt    time.Time
+}
+
+func (d Document) ToNotEmptyValueMap() map[string]interface{} {
+	result := make(map[string]interface{})
+	if d.Status != "" {
+		result["status"] = d.Status.String()
+	}
+	if d.ReviewNote != "" {
+		result["review_note"] = d.ReviewNote
+	}
+	if d.ReviewedAt != nil {
+		result["reviewed_at"] = *d.ReviewedAt
+	}
+	return result
+}
+
+type DocumentFilter struct {
+	ID     *uint
+	UserID *uint
+	Status *vo.KYCStatus
+}
+
+// TierPolicy describes what a user at a given KYC tier may do
+type TierPolicy struct {
+	MaxAmount      float64
+	PaymentMethods []vo.PaymentMethod
+}
+
+func (p TierPolicy) AllowsPaymentMethod(method vo.PaymentMethod) bool {
+	for _, m := range p.PaymentMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+type TierPolicies map[vo.KYCTier]TierPolicy
+
+// For returns the policy for a tier, falling back to tier 0 for unknown tiers
+func (p TierPolicies) For(tier vo.KYCTier) TierPolicy {
+	if policy, ok := p[tier]; ok {
+		return policy
+	}
+	return p[vo.KYCTier0]
+}
@@ -0,0 +1,10 @@
+package kyc
+
+import "context"
+
+type Repository interface {
+	FindAll(filter *DocumentFilter) ([]Document, error)
+	FindById(id uint) (*Document, error)
+	Create(ctx context.Context, document Document) (uint, error)
+	Update(ctx context.Context, filter *DocumentFilter, document Document) error
+}
@@ -0,0 +1,24 @@
+package lock
+
+import (
+	"context"
+	"time"
+)
+
+// Lock is a held lock. Only the holder that acquired it can extend or release it.
+type Lock interface {
+	Key() string
+	// Extend resets the lock's TTL; it fails with errs.ErrLockLost if the lock expired or was taken over
+	Extend(ctx context.Context, ttl time.Duration) error
+	// Release frees the lock; releasing a lock that is no longer held is not an error
+	Release(ctx context.Context) error
+}
+
+// Locker hands out mutually exclusive locks across replicas
+type Locker interface {
+	// Acquire blocks, retrying with backoff, until the lock is held or ctx is done.
+	// It returns errs.ErrLockNotAcquired when ctx ends first.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+	// TryAcquire makes a single attempt and returns errs.ErrLockNotAcquired if the lock is held elsewhere
+	TryAcquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
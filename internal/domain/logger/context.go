@@ -0,0 +1,26 @@
+package logger
+
+import "context"
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying l
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, if any
+func FromContext(ctx context.Context) (Logger, bool) {
+	l, ok := ctx.Value(ctxKey{}).(Logger)
+	return l, ok
+}
+
+// ContextWithFields adds fields to the logger carried by ctx, starting from fallback when ctx has none.
+// Later log calls made through Logger.WithContext include the fields.
+func ContextWithFields(ctx context.Context, fallback Logger, fields map[string]interface{}) context.Context {
+	l, ok := FromContext(ctx)
+	if !ok {
+		l = fallback
+	}
+	return NewContext(ctx, l.With(fields))
+}
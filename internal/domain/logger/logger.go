@@ -1,5 +1,7 @@
 package logger
 
+import "context"
+
 type Logger interface {
 	Debug(msg string, fields map[string]interface{})
 	Info(msg string, fields map[string]interface{})
@@ -10,8 +12,18 @@ type Logger interface {
 	// Creates a child logger with added fields
 	With(fields map[string]interface{}) Logger // Return the interface type
 
+	// Returns the logger carried by ctx (see NewContext), or this logger if there is none,
+	// with the trace and span IDs from ctx added
+	WithContext(ctx context.Context) Logger
+
 	// Sync flushes any buffered log entries.
 	Sync() error
 
 	// Close() error // Optional, if needed to close file handles etc.
 }
+
+// LevelController is implemented by loggers whose minimum level can change at runtime
+type LevelController interface {
+	Level() string
+	SetLevel(level string) error
+}
@@ -0,0 +1,17 @@
+package metrics
+
+// Recorder records business metrics without tying use cases to a metrics backend
+type Recorder interface {
+	TopupVerified(paymentMethod string, amount float64)
+	TopupCompleted(paymentMethod string, amount float64)
+	TopupExpired(paymentMethod string, amount float64)
+	TopupRejected(paymentMethod string, amount float64)
+}
+
+// Nop discards all metrics
+type Nop struct{}
+
+func (Nop) TopupVerified(paymentMethod string, amount float64)  {}
+func (Nop) TopupCompleted(paymentMethod string, amount float64) {}
+func (Nop) TopupExpired(paymentMethod string, amount float64)   {}
+func (Nop) TopupRejected(paymentMethod string, amount float64)  {}
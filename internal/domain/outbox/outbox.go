@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPublished Status = "published"
+	StatusDead      Status = "dead"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// Message is an event stored in the same database transaction as the state change that produced it
+type Message struct {
+	ID            uint
+	AggregateType string
+	AggregateID   string
+	EventType     event.Type
+	Payload       []byte
+	Status        Status
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+func (m Message) ToEvent() event.Event {
+	return event.Event{
+		ID:            m.ID,
+		Type:          m.EventType,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		Payload:       m.Payload,
+		OccurredAt:    m.CreatedAt,
+	}
+}
+
+type MessageFilter struct {
+	ID     *uint
+	Status *Status
+}
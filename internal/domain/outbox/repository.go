@@ -0,0 +1,18 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, message Message) (uint, error)
+	// FetchPending returns due messages that are the oldest pending message of their aggregate,
+	// locking them when ctx carries a database transaction.
+	FetchPending(ctx context.Context, limit int, now time.Time) ([]Message, error)
+	MarkPublished(ctx context.Context, id uint, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkDead(ctx context.Context, id uint, attempts int, lastError string) error
+	Requeue(ctx context.Context, id uint) error
+	FindAll(filter *MessageFilter) ([]Message, error)
+}
@@ -0,0 +1,30 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// ErrDeclined means the provider refused the charge; retrying later may succeed
+var ErrDeclined = errors.New("payment declined")
+
+// Charge asks the provider to collect an amount from a saved payment instrument
+type Charge struct {
+	Token         string // provider token of the saved instrument
+	PaymentMethod vo.PaymentMethod
+	Amount        float64
+	// Reference identifies the charge to the provider, which must not collect twice for one reference
+	Reference string
+}
+
+// Receipt is the provider's record of a successful charge
+type Receipt struct {
+	ProviderReference string
+}
+
+// Provider charges saved payment instruments
+type Provider interface {
+	Charge(ctx context.Context, charge Charge) (Receipt, error)
+}
@@ -0,0 +1,187 @@
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// FeeRule charges a fee on top-ups of a payment method within an amount tier
+type FeeRule struct {
+	PaymentMethod vo.PaymentMethod `json:"payment_method,omitempty"` // empty matches every method
+	MinAmount     float64          `json:"min_amount,omitempty"`
+	MaxAmount     float64          `json:"max_amount,omitempty"` // zero means no upper bound
+	Percent       float64          `json:"percent,omitempty"`
+	Fixed         float64          `json:"fixed,omitempty"`
+}
+
+// BonusRule credits extra funds on top-ups made during a campaign window
+type BonusRule struct {
+	Campaign      string           `json:"campaign"`
+	PaymentMethod vo.PaymentMethod `json:"payment_method,omitempty"` // empty matches every method
+	MinAmount     float64          `json:"min_amount,omitempty"`
+	MaxAmount     float64          `json:"max_amount,omitempty"` // zero means no upper bound
+	Percent       float64          `json:"percent,omitempty"`
+	Fixed         float64          `json:"fixed,omitempty"`
+	StartsAt      *time.Time       `json:"starts_at,omitempty"` // nil means already started
+	EndsAt        *time.Time       `json:"ends_at,omitempty"`   // nil means open-ended
+}
+
+// Rules are the fee and bonus rules in effect.
+// The first matching fee rule applies, so list specific tiers before catch-all rules.
+// Of the matching bonus rules, the one worth most to the user applies.
+type Rules struct {
+	Fees    []FeeRule
+	Bonuses []BonusRule
+}
+
+// Clone returns a copy whose rule lists can be replaced without affecting r
+func (r Rules) Clone() Rules {
+	return Rules{Fees: slices.Clone(r.Fees), Bonuses: slices.Clone(r.Bonuses)}
+}
+
+// Quote breaks a top-up into the amount paid, the fee kept and the bonus granted
+type Quote struct {
+	Gross    float64
+	Fee      float64
+	Bonus    float64
+	Campaign string // bonus campaign that applied, if any
+}
+
+// Net is the amount credited to the wallet
+func (q Quote) Net() float64 {
+	return round(q.Gross - q.Fee + q.Bonus)
+}
+
+// Quote prices a top-up of amount paid with method at time now.
+// It fails with ErrAmountBelowFee when the fee would consume the whole top-up.
+func (r Rules) Quote(amount float64, method vo.PaymentMethod, now time.Time) (Quote, error) {
+	quote := Quote{Gross: amount}
+	for _, rule := range r.Fees {
+		if matches(rule.PaymentMethod, rule.MinAmount, rule.MaxAmount, amount, method) {
+			quote.Fee = round(amount*rule.Percent/100 + rule.Fixed)
+			break
+		}
+	}
+	if quote.Fee >= amount {
+		return Quote{}, errs.ErrAmountBelowFee
+	}
+	for _, rule := range r.Bonuses {
+		if !matches(rule.PaymentMethod, rule.MinAmount, rule.MaxAmount, amount, method) || !rule.activeAt(now) {
+			continue
+		}
+		if bonus := round(amount*rule.Percent/100 + rule.Fixed); bonus > quote.Bonus {
+			quote.Bonus = bonus
+			quote.Campaign = rule.Campaign
+		}
+	}
+	return quote, nil
+}
+
+func (b BonusRule) activeAt(now time.Time) bool {
+	if b.StartsAt != nil && now.Before(*b.StartsAt) {
+		return false
+	}
+	return b.EndsAt == nil || now.Before(*b.EndsAt)
+}
+
+func matches(ruleMethod vo.PaymentMethod, minAmount, maxAmount, amount float64, method vo.PaymentMethod) bool {
+	if ruleMethod != "" && ruleMethod != method {
+		return false
+	}
+	return amount >= minAmount && (maxAmount == 0 || amount <= maxAmount)
+}
+
+// round rounds to whole cents
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// ParseFees reads fee rules from a JSON array; an empty string means no fees
+func ParseFees(raw string) ([]FeeRule, error) {
+	rules := []FeeRule{}
+	if err := unmarshal(raw, &rules); err != nil {
+		return nil, err
+	}
+	for i, rule := range rules {
+		if err := validate(rule.PaymentMethod, rule.MinAmount, rule.MaxAmount, rule.Percent, rule.Fixed); err != nil {
+			return nil, fmt.Errorf("fee rule %d: %w", i+1, err)
+		}
+	}
+	return rules, nil
+}
+
+// ParseBonuses reads bonus rules from a JSON array; an empty string means no campaigns
+func ParseBonuses(raw string) ([]BonusRule, error) {
+	rules := []BonusRule{}
+	if err := unmarshal(raw, &rules); err != nil {
+		return nil, err
+	}
+	for i, rule := range rules {
+		err := validate(rule.PaymentMethod, rule.MinAmount, rule.MaxAmount, rule.Percent, rule.Fixed)
+		switch {
+		case err != nil:
+		case rule.Campaign == "":
+			err = errors.New("campaign is required")
+		case rule.StartsAt != nil && rule.EndsAt != nil && !rule.EndsAt.After(*rule.StartsAt):
+			err = errors.New("ends_at must be after starts_at")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bonus rule %d: %w", i+1, err)
+		}
+	}
+	return rules, nil
+}
+
+// FormatFees returns rules in the form ParseFees accepts
+func FormatFees(rules []FeeRule) string {
+	return format(rules)
+}
+
+// FormatBonuses returns rules in the form ParseBonuses accepts
+func FormatBonuses(rules []BonusRule) string {
+	return format(rules)
+}
+
+func unmarshal(raw string, rules interface{}) error {
+	if raw == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), rules); err != nil {
+		return fmt.Errorf("expected a JSON array of rules: %w", err)
+	}
+	return nil
+}
+
+func format[T any](rules []T) string {
+	if len(rules) == 0 {
+		return "[]"
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func validate(method vo.PaymentMethod, minAmount, maxAmount, percent, fixed float64) error {
+	switch {
+	case method != "" && !method.Valid():
+		return fmt.Errorf("unknown payment method %q", method)
+	case minAmount < 0 || maxAmount < 0:
+		return errors.New("amounts must not be negative")
+	case maxAmount != 0 && maxAmount < minAmount:
+		return errors.New("max_amount must not be below min_amount")
+	case percent < 0 || percent > 100:
+		return errors.New("percent must be between 0 and 100")
+	case fixed < 0:
+		return errors.New("fixed must not be negative")
+	}
+	return nil
+}
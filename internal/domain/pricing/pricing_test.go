@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesQuote(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	card, bank := vo.PaymentMethodCreditCard, vo.PaymentMethodBankTransfer
+
+	tests := []struct {
+		name    string
+		rules   Rules
+		amount  float64
+		method  vo.PaymentMethod
+		want    Quote
+		wantNet float64
+		wantErr error
+	}{
+		{
+			name:    "no rules",
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100},
+			wantNet: 100,
+		},
+		{
+			name:    "fee only",
+			rules:   Rules{Fees: []FeeRule{{Percent: 2, Fixed: 0.5}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Fee: 2.5},
+			wantNet: 97.5,
+		},
+		{
+			name:    "bonus only",
+			rules:   Rules{Bonuses: []BonusRule{{Campaign: "summer", Percent: 10}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Bonus: 10, Campaign: "summer"},
+			wantNet: 110,
+		},
+		{
+			name: "first matching fee rule applies",
+			rules: Rules{Fees: []FeeRule{
+				{PaymentMethod: bank, Fixed: 1},
+				{MinAmount: 500, Percent: 1},
+				{Percent: 3},
+			}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Fee: 3},
+			wantNet: 97,
+		},
+		{
+			name:    "fee equal to amount",
+			rules:   Rules{Fees: []FeeRule{{Fixed: 5}}},
+			amount:  5,
+			method:  card,
+			wantErr: errs.ErrAmountBelowFee,
+		},
+		{
+			name:    "fee above amount",
+			rules:   Rules{Fees: []FeeRule{{Fixed: 5}}},
+			amount:  4.99,
+			method:  card,
+			wantErr: errs.ErrAmountBelowFee,
+		},
+		{
+			name: "highest-value bonus wins",
+			rules: Rules{Bonuses: []BonusRule{
+				{Campaign: "percent", Percent: 5},
+				{Campaign: "fixed", Fixed: 8},
+				{Campaign: "small", Fixed: 1},
+			}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Bonus: 8, Campaign: "fixed"},
+			wantNet: 108,
+		},
+		{
+			name: "equal bonuses keep the first",
+			rules: Rules{Bonuses: []BonusRule{
+				{Campaign: "first", Fixed: 5},
+				{Campaign: "second", Percent: 5},
+			}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Bonus: 5, Campaign: "first"},
+			wantNet: 105,
+		},
+		{
+			name:    "bonus for another payment method",
+			rules:   Rules{Bonuses: []BonusRule{{Campaign: "bank", PaymentMethod: bank, Fixed: 5}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100},
+			wantNet: 100,
+		},
+		{
+			name:    "bonus starting now applies",
+			rules:   Rules{Bonuses: []BonusRule{{Campaign: "launch", Fixed: 5, StartsAt: at(0)}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Bonus: 5, Campaign: "launch"},
+			wantNet: 105,
+		},
+		{
+			name:    "bonus not yet started",
+			rules:   Rules{Bonuses: []BonusRule{{Campaign: "launch", Fixed: 5, StartsAt: at(time.Second)}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100},
+			wantNet: 100,
+		},
+		{
+			name:    "bonus ending now no longer applies",
+			rules:   Rules{Bonuses: []BonusRule{{Campaign: "launch", Fixed: 5, StartsAt: at(-time.Hour), EndsAt: at(0)}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100},
+			wantNet: 100,
+		},
+		{
+			name:    "bonus ending later applies",
+			rules:   Rules{Bonuses: []BonusRule{{Campaign: "launch", Fixed: 5, EndsAt: at(time.Second)}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Bonus: 5, Campaign: "launch"},
+			wantNet: 105,
+		},
+		{
+			name:    "zero max amount has no upper bound",
+			rules:   Rules{Fees: []FeeRule{{MinAmount: 10, Fixed: 1}}},
+			amount:  1_000_000,
+			method:  card,
+			want:    Quote{Gross: 1_000_000, Fee: 1},
+			wantNet: 999_999,
+		},
+		{
+			name:    "max amount is inclusive",
+			rules:   Rules{Fees: []FeeRule{{MaxAmount: 100, Fixed: 1}, {Fixed: 2}}},
+			amount:  100,
+			method:  card,
+			want:    Quote{Gross: 100, Fee: 1},
+			wantNet: 99,
+		},
+		{
+			name:    "above max amount falls through",
+			rules:   Rules{Fees: []FeeRule{{MaxAmount: 100, Fixed: 1}, {Fixed: 2}}},
+			amount:  100.01,
+			method:  card,
+			want:    Quote{Gross: 100.01, Fee: 2},
+			wantNet: 98.01,
+		},
+		{
+			name: "fee and bonus rounded to cents",
+			rules: Rules{
+				Fees:    []FeeRule{{Percent: 2.9, Fixed: 0.3}},
+				Bonuses: []BonusRule{{Campaign: "odd", Percent: 1.5}},
+			},
+			amount:  33.33,
+			method:  card,
+			want:    Quote{Gross: 33.33, Fee: 1.27, Bonus: 0.5, Campaign: "odd"},
+			wantNet: 32.56,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := tt.rules.Quote(tt.amount, tt.method, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, quote)
+			assert.Equal(t, tt.wantNet, quote.Net())
+		})
+	}
+}
+
+func TestQuoteNetRoundsToCents(t *testing.T) {
+	// 0.1 + 0.2 is not exactly 0.3 in floating point
+	assert.Equal(t, 0.3, Quote{Gross: 0.1, Bonus: 0.2}.Net())
+	assert.Equal(t, 10.01, Quote{Gross: 10.015, Fee: 0.005}.Net())
+}
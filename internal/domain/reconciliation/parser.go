@@ -0,0 +1,170 @@
+package reconciliation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Format is the encoding of a settlement file
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+func (f Format) Valid() bool {
+	switch f {
+	case FormatCSV, FormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewFormat parses format, falling back to the extension of fileName when format is empty
+func NewFormat(format, fileName string) (Format, error) {
+	if format = strings.TrimSpace(format); format == "" {
+		format = strings.TrimPrefix(filepath.Ext(fileName), ".")
+	}
+	f := Format(strings.ToLower(format))
+	if !f.Valid() {
+		return "", fmt.Errorf("%w: format must be csv or json", errs.ErrInvalidReconciliation)
+	}
+	return f, nil
+}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// Parser reads the records of a settlement file. Errors wrap ErrInvalidReconciliation and say
+// which record is at fault.
+type Parser interface {
+	Parse(r io.Reader) ([]Record, error)
+}
+
+// NewParser returns the parser for format
+func NewParser(format Format) (Parser, error) {
+	switch format {
+	case FormatCSV:
+		return CSVParser{}, nil
+	case FormatJSON:
+		return JSONParser{}, nil
+	default:
+		return nil, fmt.Errorf("%w: format must be csv or json", errs.ErrInvalidReconciliation)
+	}
+}
+
+// Settlement files name their columns and fields after these keys; anything else is ignored
+const (
+	fieldProviderReference = "provider_reference"
+	fieldAmount            = "amount"
+)
+
+// CSVParser reads a CSV file whose header row names the provider_reference and amount columns
+type CSVParser struct{}
+
+func (CSVParser) Parse(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: missing header row", errs.ErrInvalidReconciliation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidReconciliation, err)
+	}
+	referenceColumn, amountColumn := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case fieldProviderReference:
+			referenceColumn = i
+		case fieldAmount:
+			amountColumn = i
+		}
+	}
+	if referenceColumn < 0 || amountColumn < 0 {
+		return nil, fmt.Errorf("%w: header must have %s and %s columns", errs.ErrInvalidReconciliation, fieldProviderReference, fieldAmount)
+	}
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errs.ErrInvalidReconciliation, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if referenceColumn >= len(row) || amountColumn >= len(row) {
+			return nil, fmt.Errorf("%w: line %d: missing columns", errs.ErrInvalidReconciliation, line)
+		}
+		amount, err := strconv.ParseFloat(strings.TrimSpace(row[amountColumn]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid amount %q", errs.ErrInvalidReconciliation, line, row[amountColumn])
+		}
+		record, err := newRecord(row[referenceColumn], amount)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", errs.ErrInvalidReconciliation, line, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// JSONParser reads a JSON array of objects with provider_reference and amount fields
+type JSONParser struct{}
+
+func (JSONParser) Parse(r io.Reader) ([]Record, error) {
+	var lines []struct {
+		ProviderReference string   `json:"provider_reference"`
+		Amount            *float64 `json:"amount"`
+	}
+	if err := json.NewDecoder(r).Decode(&lines); err != nil {
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidReconciliation, err)
+	}
+	records := make([]Record, 0, len(lines))
+	for i, line := range lines {
+		if line.Amount == nil {
+			return nil, fmt.Errorf("%w: record %d: missing amount", errs.ErrInvalidReconciliation, i+1)
+		}
+		record, err := newRecord(line.ProviderReference, *line.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", errs.ErrInvalidReconciliation, i+1, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// maxProviderReferenceLength matches the provider_reference column size
+const maxProviderReferenceLength = 100
+
+func newRecord(reference string, amount float64) (Record, error) {
+	reference = strings.TrimSpace(reference)
+	switch {
+	case reference == "":
+		return Record{}, errors.New("missing provider reference")
+	case len(reference) > maxProviderReferenceLength:
+		return Record{}, fmt.Errorf("provider reference is longer than %d characters", maxProviderReferenceLength)
+	case !(amount > 0) || math.IsInf(amount, 0):
+		return Record{}, fmt.Errorf("amount must be positive, got %v", amount)
+	}
+	money, err := vo.NewMoney(amount)
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{ProviderReference: reference, Amount: money}, nil
+}
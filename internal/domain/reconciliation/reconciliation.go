@@ -0,0 +1,111 @@
+package reconciliation
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Status classifies how a settlement record compares with our transactions
+type Status string
+
+const (
+	// StatusMatched means the provider settled a completed top-up for the amount we charged
+	StatusMatched Status = "matched"
+	// StatusMissingInOurs means the provider settled money we have no completed top-up for
+	StatusMissingInOurs Status = "missing_in_ours"
+	// StatusMissingInTheirs means a completed top-up is absent from the settlement file
+	StatusMissingInTheirs Status = "missing_in_theirs"
+	// StatusAmountMismatch means both sides have the reference but disagree on the amount
+	StatusAmountMismatch Status = "amount_mismatch"
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusMatched, StatusMissingInOurs, StatusMissingInTheirs, StatusAmountMismatch:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewStatus(status string) (Status, error) {
+	s := Status(strings.ToLower(strings.TrimSpace(status)))
+	if !s.Valid() {
+		return "", errs.ErrInvalidReconciliation
+	}
+	return s, nil
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// Record is one line of a provider settlement file
+type Record struct {
+	ProviderReference string
+	Amount            vo.Money
+}
+
+// Item is the outcome of reconciling one settlement record or one of our transactions
+type Item struct {
+	ID                uint
+	ReportID          uint
+	Status            Status
+	ProviderReference string
+	TransactionID     uint     // zero when no transaction carries the reference
+	ProviderAmount    vo.Money // zero for StatusMissingInTheirs
+	TransactionAmount vo.Money // zero when there is no completed transaction
+}
+
+// Report summarises the reconciliation of one provider settlement file
+type Report struct {
+	ID              uint
+	Provider        string
+	SettlementDate  time.Time // UTC day covered by the file
+	FileName        string
+	Format          Format
+	Records         int // lines read from the file
+	Matched         int
+	MissingInOurs   int
+	MissingInTheirs int
+	AmountMismatch  int
+	CreatedBy       string
+	CreatedAt       time.Time
+	Items           []Item // only loaded when a single report is requested
+}
+
+// Count tallies an item under its status
+func (r *Report) Count(status Status) {
+	switch status {
+	case StatusMatched:
+		r.Matched++
+	case StatusMissingInOurs:
+		r.MissingInOurs++
+	case StatusMissingInTheirs:
+		r.MissingInTheirs++
+	case StatusAmountMismatch:
+		r.AmountMismatch++
+	}
+}
+
+// Discrepancies is the number of items that need a person to look at them
+func (r Report) Discrepancies() int {
+	return r.MissingInOurs + r.MissingInTheirs + r.AmountMismatch
+}
+
+type ReportFilter struct {
+	Provider       *string
+	SettlementDate *time.Time
+}
+
+// Spec describes a settlement file to reconcile
+type Spec struct {
+	Provider       string // the configured payment provider when empty
+	SettlementDate time.Time
+	FileName       string
+	Format         string // taken from the file name extension when empty
+	Actor          string
+}
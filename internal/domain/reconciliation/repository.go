@@ -0,0 +1,12 @@
+package reconciliation
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, report Report) (uint, error)
+	CreateItems(ctx context.Context, items []Item) error
+	FindByID(ctx context.Context, id uint) (*Report, error)
+	FindAll(ctx context.Context, filter *ReportFilter) ([]Report, error)
+	// FindItems returns the items of a report, only those with status when it is set
+	FindItems(ctx context.Context, reportID uint, status *Status) ([]Item, error)
+}
@@ -0,0 +1,92 @@
+package risk
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Input is everything a rule may look at when scoring a top-up
+type Input struct {
+	User          user.User
+	Amount        vo.Money
+	PaymentMethod vo.PaymentMethod
+	Limit         float64 // effective maximum amount for the user
+	Now           time.Time
+}
+
+// Result is a single rule's contribution to the overall score
+type Result struct {
+	Rule   string
+	Score  int
+	Reason string
+}
+
+// Rule scores one aspect of a top-up. A rule that does not fire returns a zero score.
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, in Input) (Result, error)
+}
+
+// Assessment is the combined outcome of all rules
+type Assessment struct {
+	Score    int
+	Decision vo.RiskDecision
+	Results  []Result
+}
+
+// Reasons returns the reasons of the rules that fired
+func (a Assessment) Reasons() []string {
+	reasons := make([]string, 0, len(a.Results))
+	for _, r := range a.Results {
+		if r.Score > 0 {
+			reasons = append(reasons, r.Reason)
+		}
+	}
+	return reasons
+}
+
+// Assessor decides whether a top-up may proceed
+type Assessor interface {
+	Assess(ctx context.Context, in Input) (Assessment, error)
+}
+
+// Engine sums rule scores and maps the total onto a decision
+type Engine struct {
+	rules              []Rule
+	challengeThreshold int
+	rejectThreshold    int
+}
+
+// NewEngine creates an Engine. A score at or above challengeThreshold requires step-up,
+// and at or above rejectThreshold the top-up is refused.
+func NewEngine(challengeThreshold, rejectThreshold int, rules ...Rule) *Engine {
+	return &Engine{
+		rules:              rules,
+		challengeThreshold: challengeThreshold,
+		rejectThreshold:    rejectThreshold,
+	}
+}
+
+func (e *Engine) Assess(ctx context.Context, in Input) (Assessment, error) {
+	assessment := Assessment{Decision: vo.RiskDecisionAllow}
+	for _, rule := range e.rules {
+		result, err := rule.Evaluate(ctx, in)
+		if err != nil {
+			return Assessment{}, err
+		}
+		result.Rule = rule.Name()
+		assessment.Score += result.Score
+		assessment.Results = append(assessment.Results, result)
+	}
+
+	switch {
+	case assessment.Score >= e.rejectThreshold:
+		assessment.Decision = vo.RiskDecisionReject
+	case assessment.Score >= e.challengeThreshold:
+		assessment.Decision = vo.RiskDecisionChallenge
+	}
+	return assessment, nil
+}
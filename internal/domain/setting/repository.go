@@ -0,0 +1,14 @@
+package setting
+
+import "context"
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]Setting, error)
+	FindByKey(ctx context.Context, key Key) (*Setting, error)
+	// Save inserts or replaces the stored value for the setting's key
+	Save(ctx context.Context, setting Setting) error
+	Delete(ctx context.Context, key Key) error
+	CreateChange(ctx context.Context, change Change) (uint, error)
+	// FindChanges returns the audit trail, newest first
+	FindChanges(ctx context.Context, filter *ChangeFilter) ([]Change, error)
+}
@@ -0,0 +1,261 @@
+package setting
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/pricing"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Key names a business setting that can be changed at runtime
+type Key string
+
+const (
+	KeyVerificationTTL     Key = "topup.verification_ttl" // in seconds
+	KeyMaxAcceptedAmount   Key = "topup.max_accepted_amount"
+	KeyPaymentMethods      Key = "topup.payment_methods"
+	KeyTier0MaxAmount      Key = "kyc.tier0.max_amount"
+	KeyTier1MaxAmount      Key = "kyc.tier1.max_amount"
+	KeyTier2MaxAmount      Key = "kyc.tier2.max_amount"
+	KeyTier0PaymentMethods Key = "kyc.tier0.payment_methods"
+	KeyTier1PaymentMethods Key = "kyc.tier1.payment_methods"
+	KeyTier2PaymentMethods Key = "kyc.tier2.payment_methods"
+	KeyPricingFees         Key = "pricing.fees"    // JSON array of fee rules
+	KeyPricingBonuses      Key = "pricing.bonuses" // JSON array of bonus campaigns
+)
+
+// Keys lists every setting in display order
+func Keys() []Key {
+	return []Key{
+		KeyVerificationTTL,
+		KeyMaxAcceptedAmount,
+		KeyPaymentMethods,
+		KeyTier0MaxAmount,
+		KeyTier1MaxAmount,
+		KeyTier2MaxAmount,
+		KeyTier0PaymentMethods,
+		KeyTier1PaymentMethods,
+		KeyTier2PaymentMethods,
+		KeyPricingFees,
+		KeyPricingBonuses,
+	}
+}
+
+func NewKey(key string) (Key, error) {
+	k := Key(strings.ToLower(strings.TrimSpace(key)))
+	if !slices.Contains(Keys(), k) {
+		return "", errs.ErrUnknownSetting
+	}
+	return k, nil
+}
+
+func (k Key) String() string {
+	return string(k)
+}
+
+// Setting is a stored value that overrides the configured default
+type Setting struct {
+	Key       Key
+	Value     string
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// Entry describes a setting for the admin API: the value in effect and where it comes from
+type Entry struct {
+	Key     Key
+	Value   string
+	Default string
+	Stored  *Setting // nil while the default is in effect
+}
+
+// Change is one entry of the settings audit trail. A nil value means the default was in effect.
+type Change struct {
+	ID        uint
+	Key       Key
+	OldValue  *string
+	NewValue  *string
+	Actor     string
+	Reason    string
+	CreatedAt time.Time
+}
+
+type ChangeFilter struct {
+	Key   *Key
+	Limit int
+}
+
+// Values are the business settings in effect. Treat them as read-only; use Clone before calling Set.
+type Values struct {
+	VerificationTTL   time.Duration
+	MaxAcceptedAmount float64
+	PaymentMethods    []vo.PaymentMethod
+	TierPolicies      kyc.TierPolicies
+	Pricing           pricing.Rules
+}
+
+// PaymentMethodEnabled reports whether method is enabled at all; the KYC tier may restrict it further
+func (v Values) PaymentMethodEnabled(method vo.PaymentMethod) bool {
+	return slices.Contains(v.PaymentMethods, method)
+}
+
+// Clone returns a deep copy that can be changed without affecting v
+func (v Values) Clone() Values {
+	clone := v
+	clone.PaymentMethods = slices.Clone(v.PaymentMethods)
+	clone.TierPolicies = make(kyc.TierPolicies, len(v.TierPolicies))
+	for tier, policy := range v.TierPolicies {
+		policy.PaymentMethods = slices.Clone(policy.PaymentMethods)
+		clone.TierPolicies[tier] = policy
+	}
+	clone.Pricing = v.Pricing.Clone()
+	return clone
+}
+
+// Get returns the value of key in the same form Set accepts
+func (v Values) Get(key Key) (string, error) {
+	switch key {
+	case KeyVerificationTTL:
+		return strconv.Itoa(int(v.VerificationTTL / time.Second)), nil
+	case KeyMaxAcceptedAmount:
+		return formatAmount(v.MaxAcceptedAmount), nil
+	case KeyPaymentMethods:
+		return formatPaymentMethods(v.PaymentMethods), nil
+	case KeyPricingFees:
+		return pricing.FormatFees(v.Pricing.Fees), nil
+	case KeyPricingBonuses:
+		return pricing.FormatBonuses(v.Pricing.Bonuses), nil
+	}
+	if tier, ok := tierMaxAmountKeys[key]; ok {
+		return formatAmount(v.TierPolicies[tier].MaxAmount), nil
+	}
+	if tier, ok := tierPaymentMethodKeys[key]; ok {
+		return formatPaymentMethods(v.TierPolicies[tier].PaymentMethods), nil
+	}
+	return "", errs.ErrUnknownSetting
+}
+
+// Set parses raw and applies it to key, rejecting malformed or out-of-range values
+func (v *Values) Set(key Key, raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch key {
+	case KeyVerificationTTL:
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds <= 0 {
+			return fmt.Errorf("%w: %s must be a positive number of seconds", errs.ErrInvalidSettingValue, key)
+		}
+		v.VerificationTTL = time.Duration(seconds) * time.Second
+		return nil
+	case KeyMaxAcceptedAmount:
+		amount, err := parseAmount(key, raw)
+		if err != nil {
+			return err
+		}
+		v.MaxAcceptedAmount = amount
+		return nil
+	case KeyPaymentMethods:
+		methods, err := parsePaymentMethods(key, raw)
+		if err != nil {
+			return err
+		}
+		v.PaymentMethods = methods
+		return nil
+	case KeyPricingFees:
+		fees, err := pricing.ParseFees(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", errs.ErrInvalidSettingValue, key, err)
+		}
+		v.Pricing.Fees = fees
+		return nil
+	case KeyPricingBonuses:
+		bonuses, err := pricing.ParseBonuses(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", errs.ErrInvalidSettingValue, key, err)
+		}
+		v.Pricing.Bonuses = bonuses
+		return nil
+	}
+	if tier, ok := tierMaxAmountKeys[key]; ok {
+		amount, err := parseAmount(key, raw)
+		if err != nil {
+			return err
+		}
+		policy := v.TierPolicies[tier]
+		policy.MaxAmount = amount
+		v.TierPolicies[tier] = policy
+		return nil
+	}
+	if tier, ok := tierPaymentMethodKeys[key]; ok {
+		methods, err := parsePaymentMethods(key, raw)
+		if err != nil {
+			return err
+		}
+		policy := v.TierPolicies[tier]
+		policy.PaymentMethods = methods
+		v.TierPolicies[tier] = policy
+		return nil
+	}
+	return errs.ErrUnknownSetting
+}
+
+var tierMaxAmountKeys = map[Key]vo.KYCTier{
+	KeyTier0MaxAmount: vo.KYCTier0,
+	KeyTier1MaxAmount: vo.KYCTier1,
+	KeyTier2MaxAmount: vo.KYCTier2,
+}
+
+var tierPaymentMethodKeys = map[Key]vo.KYCTier{
+	KeyTier0PaymentMethods: vo.KYCTier0,
+	KeyTier1PaymentMethods: vo.KYCTier1,
+	KeyTier2PaymentMethods: vo.KYCTier2,
+}
+
+func parseAmount(key Key, raw string) (float64, error) {
+	amount, err := strconv.ParseFloat(raw, 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("%w: %s must be a positive amount", errs.ErrInvalidSettingValue, key)
+	}
+	return amount, nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+// parsePaymentMethods reads a comma-separated list; an empty list disables every method
+func parsePaymentMethods(key Key, raw string) ([]vo.PaymentMethod, error) {
+	methods := []vo.PaymentMethod{}
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		method, err := vo.NewPaymentMethod(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s has unknown payment method %q", errs.ErrInvalidSettingValue, key, part)
+		}
+		if !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+	return methods, nil
+}
+
+func formatPaymentMethods(methods []vo.PaymentMethod) string {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = m.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// Provider returns the settings in effect, so long-running processes pick up changes without a restart
+type Provider interface {
+	Current(ctx context.Context) (Values, error)
+}
@@ -0,0 +1,14 @@
+package statement
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// OpeningBalance sums the completed top-ups and adjustments of a wallet before a time
+	OpeningBalance(ctx context.Context, walletID uint, before time.Time) (float64, error)
+	// StreamEntries calls fn for each completed top-up and adjustment of a wallet in [from, until),
+	// oldest first, reading rows as they are consumed. An error from fn stops the stream.
+	StreamEntries(ctx context.Context, walletID uint, from, until time.Time, fn func(Entry) error) error
+}
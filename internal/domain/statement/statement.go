@@ -0,0 +1,123 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+)
+
+// Format is the file format a statement is exported in
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatPDF Format = "pdf"
+)
+
+func (f Format) Valid() bool {
+	switch f {
+	case FormatCSV, FormatPDF:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewFormat(format string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(format)))
+	if !f.Valid() {
+		return "", fmt.Errorf("%w: format must be csv or pdf", errs.ErrInvalidStatement)
+	}
+	return f, nil
+}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// ContentType is the MIME type of a statement in this format
+func (f Format) ContentType() string {
+	if f == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv"
+}
+
+// EntryType says what moved the balance
+type EntryType string
+
+const (
+	// EntryTopup is a completed top-up, credited net of its fee and bonus
+	EntryTopup EntryType = "topup"
+	// EntryAdjustment is a balance correction made by an operator
+	EntryAdjustment EntryType = "adjustment"
+)
+
+func (t EntryType) String() string {
+	return string(t)
+}
+
+// Entry is one line of a statement
+type Entry struct {
+	Type        EntryType
+	ReferenceID uint   // transaction or adjustment ID
+	Description string // payment method of a top-up, reason of an adjustment
+	Amount      float64
+	Fee         float64
+	Bonus       float64
+	// Net is the signed change to the balance
+	Net float64
+	// Balance is the running balance after the entry, filled in while the statement is written
+	Balance float64
+	// PostedAt is when the entry moved the balance: when a top-up was credited or an
+	// adjustment made
+	PostedAt time.Time
+}
+
+// Statement covers the whole UTC days From to To, both inclusive
+type Statement struct {
+	WalletID       uint
+	From           time.Time
+	To             time.Time
+	Format         Format
+	OpeningBalance float64
+	// ClosingBalance and Entries are known once every entry has been written
+	ClosingBalance float64
+	Entries        int
+	GeneratedAt    time.Time
+}
+
+// Until is the exclusive end of the statement period
+func (s Statement) Until() time.Time {
+	return s.To.AddDate(0, 0, 1)
+}
+
+// FileName is the suggested download name, such as statement-1-2026-09-01-2026-09-30.pdf
+func (s Statement) FileName() string {
+	return fmt.Sprintf("statement-%d-%s-%s.%s", s.WalletID, s.From.Format(time.DateOnly), s.To.Format(time.DateOnly), s.Format)
+}
+
+// Spec describes the statement to produce
+type Spec struct {
+	WalletID uint
+	From     time.Time
+	To       time.Time
+	Format   string
+}
+
+// Renderer writes a statement in one format. Begin is called once before the entries, Entry
+// once per entry in order, and End once with the closing balance. When the entries cannot be
+// read to the end, Abort is called instead of End to mark the output as incomplete, since part
+// of it may already have been sent.
+type Renderer interface {
+	Begin(s Statement) error
+	Entry(e Entry) error
+	End(s Statement) error
+	Abort(s Statement) error
+}
+
+// RendererFactory creates a renderer writing to w
+type RendererFactory func(w io.Writer) Renderer
@@ -1,10 +1,17 @@
 package transaction
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Repository interface {
 	FindAll(filter *TransactionFilter) ([]Transaction, error)
-	FindById(id uint) (*Transaction, error)
+	FindById(ctx context.Context, id uint) (*Transaction, error)
 	Create(ctx context.Context, transaction Transaction) (uint, error)
 	Update(ctx context.Context, filter *TransactionFilter, transaction Transaction) error
+	Count(ctx context.Context, filter *TransactionFilter) (int64, error)
+	// FindExpired returns verified transactions whose expiry has passed, oldest first,
+	// locking them when ctx carries a database transaction.
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]Transaction, error)
 }
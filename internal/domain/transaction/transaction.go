@@ -8,12 +8,24 @@ import (
 
 // Transaction represents the transactions table
 type Transaction struct {
-	ID            uint                 `json:"id"`
-	UserID        uint                 `json:"user_id"`
-	Amount        vo.Money             `json:"amount"`
-	PaymentMethod vo.PaymentMethod     `json:"payment_method"`
-	Status        vo.TransactionStatus `json:"status"`
-	ExpiresAt     time.Time            `json:"expires_at"`
+	ID            uint             `json:"id"`
+	UserID        uint             `json:"user_id"`
+	Amount        vo.Money         `json:"amount"` // gross amount paid
+	Fee           vo.Money         `json:"fee"`
+	Bonus         vo.Money         `json:"bonus"`
+	Campaign      string           `json:"campaign,omitempty"` // bonus campaign that applied
+	PaymentMethod vo.PaymentMethod `json:"payment_method"`
+	InstrumentID  uint             `json:"instrument_id,omitempty"` // saved instrument charged, if any
+	// ProviderReference identifies the charge at the payment provider, for settlement reconciliation
+	ProviderReference string               `json:"provider_reference,omitempty"`
+	Status            vo.TransactionStatus `json:"status"`
+	ExpiresAt         time.Time            `json:"expires_at"`
+	RiskScore         int                  `json:"risk_score"`
+	RiskDecision      vo.RiskDecision      `json:"risk_decision,omitempty"`
+	RiskReasons       []string             `json:"risk_reasons,omitempty"`
+	// CompletedAt is when the top-up was credited, which dates it on wallet statements
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
 func NewTransaction(UserID uint, amount float64, paymentMethod string, status string, expiresAt time.Time) (Transaction, error) {
@@ -38,6 +50,14 @@ func NewTransaction(UserID uint, amount float64, paymentMethod string, status st
 		ExpiresAt:     expiresAt,
 	}, nil
 }
+
+// NetAmount is the amount credited to the wallet: the gross amount less the fee plus the bonus
+func (t Transaction) NetAmount() vo.Money {
+	// Pricing never lets the fee reach the gross amount
+	net, _ := t.Amount.Add(t.Bonus).Subtract(t.Fee)
+	return net
+}
+
 func (t Transaction) ToNotEmptyValueMap() map[string]interface{} {
 	result := make(map[string]interface{})
 	if !t.Amount.IsZero() {
@@ -52,13 +72,36 @@ func (t Transaction) ToNotEmptyValueMap() map[string]interface{} {
 	if !t.ExpiresAt.IsZero() {
 		result["expires_at"] = t.ExpiresAt
 	}
+	if t.RiskDecision != "" {
+		result["risk_decision"] = t.RiskDecision.String()
+	}
+	if t.ProviderReference != "" {
+		result["provider_reference"] = t.ProviderReference
+	}
+	if t.CompletedAt != nil {
+		result["completed_at"] = *t.CompletedAt
+	}
 	return result
 }
 
 type TransactionFilter struct {
 	ID            *uint
+	UserID        *uint
 	PaymentMethod *vo.PaymentMethod
-	Status        *vo.TransactionStatus
-	Amount        *vo.Money
-	ExpiredAt     *time.Time
+	// PaymentMethodNotIn leaves out transactions paid with any of these payment methods
+	PaymentMethodNotIn []vo.PaymentMethod
+	Status             *vo.TransactionStatus
+	Statuses           []vo.TransactionStatus
+	Amount             *vo.Money
+	ExpiredAt          *time.Time
+	CreatedAfter       *time.Time
+	CreatedBefore      *time.Time
+	// CompletedAfter and CompletedBefore bound when a transaction was credited, from inclusive to exclusive
+	CompletedAfter  *time.Time
+	CompletedBefore *time.Time
+	RiskDecision    *vo.RiskDecision
+	// ProviderReferences matches transactions charged under any of these provider references
+	ProviderReferences []string
+	// HasProviderReference matches only transactions charged through a payment provider
+	HasProviderReference bool
 }
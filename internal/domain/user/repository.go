@@ -1,7 +1,13 @@
 package user
 
+import "context"
+
 type Repository interface {
 	FindAll(*UserFilter) ([]User, error)
-	FindById(id uint) (User, error)
+	FindById(ctx context.Context, id uint) (User, error)
 	Create(User User) error
+	Update(ctx context.Context, user User) error
+	// MarkKYCPending moves a user into pending KYC review, failing with errs.ErrKYCAlreadyPending
+	// if a review is already pending
+	MarkKYCPending(ctx context.Context, id uint) error
 }
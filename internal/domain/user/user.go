@@ -1,5 +1,11 @@
 package user
 
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
 // User represents the users table
 type User struct {
 	ID        uint
@@ -8,6 +14,9 @@ type User struct {
 	Email     string
 	Password  string
 	Phone     string
+	KYCStatus vo.KYCStatus
+	KYCTier   vo.KYCTier
+	CreatedAt time.Time
 }
 
 func (u User) ToNotEmptyValueMap() map[string]interface{} {
@@ -24,6 +33,12 @@ func (u User) ToNotEmptyValueMap() map[string]interface{} {
 	if u.Phone != "" {
 		result["phone"] = u.Phone
 	}
+	if u.KYCStatus != "" {
+		result["kyc_status"] = u.KYCStatus.String()
+	}
+	if u.KYCTier != "" {
+		result["kyc_tier"] = u.KYCTier.String()
+	}
 	return result
 }
 
@@ -32,4 +47,5 @@ type UserFilter struct {
 	LastName  *string
 	Email     *string
 	Phone     *string
+	KYCStatus *vo.KYCStatus
 }
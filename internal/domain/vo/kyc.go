@@ -0,0 +1,97 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+)
+
+type KYCStatus string
+
+const (
+	KYCStatusNone     KYCStatus = "none"
+	KYCStatusPending  KYCStatus = "pending"
+	KYCStatusApproved KYCStatus = "approved"
+	KYCStatusRejected KYCStatus = "rejected"
+)
+
+func (s KYCStatus) Valid() bool {
+	switch s {
+	case KYCStatusNone, KYCStatusPending, KYCStatusApproved, KYCStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewKYCStatus(status string) (KYCStatus, error) {
+	s := KYCStatus(strings.ToLower(status))
+	if !s.Valid() {
+		return "", errs.ErrInvalidKYCStatus
+	}
+	return s, nil
+}
+
+func (s KYCStatus) String() string {
+	return string(s)
+}
+
+// KYCTier controls which limits and payment methods a user is allowed to use.
+// Users start at tier 0 and are promoted by an admin after document review.
+type KYCTier string
+
+const (
+	KYCTier0 KYCTier = "tier_0"
+	KYCTier1 KYCTier = "tier_1"
+	KYCTier2 KYCTier = "tier_2"
+)
+
+func (t KYCTier) Valid() bool {
+	switch t {
+	case KYCTier0, KYCTier1, KYCTier2:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewKYCTier(tier string) (KYCTier, error) {
+	t := KYCTier(strings.ToLower(tier))
+	if !t.Valid() {
+		return "", errs.ErrInvalidKYCTier
+	}
+	return t, nil
+}
+
+func (t KYCTier) String() string {
+	return string(t)
+}
+
+type DocumentType string
+
+const (
+	DocumentTypeNationalID     DocumentType = "national_id"
+	DocumentTypePassport       DocumentType = "passport"
+	DocumentTypeDrivingLicense DocumentType = "driving_license"
+)
+
+func (d DocumentType) Valid() bool {
+	switch d {
+	case DocumentTypeNationalID, DocumentTypePassport, DocumentTypeDrivingLicense:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewDocumentType(docType string) (DocumentType, error) {
+	d := DocumentType(strings.ToLower(docType))
+	if !d.Valid() {
+		return "", errs.ErrInvalidDocumentType
+	}
+	return d, nil
+}
+
+func (d DocumentType) String() string {
+	return string(d)
+}
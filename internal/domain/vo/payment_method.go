@@ -9,12 +9,15 @@ import (
 type PaymentMethod string
 
 const (
-	PaymentMethodCreditCard PaymentMethod = "credit_card"
+	PaymentMethodCreditCard   PaymentMethod = "credit_card"
+	PaymentMethodBankTransfer PaymentMethod = "bank_transfer"
+	// PaymentMethodVoucher marks wallet credits from redeemed vouchers; it cannot be used to verify a top-up
+	PaymentMethodVoucher PaymentMethod = "voucher"
 )
 
 func (p PaymentMethod) Valid() bool {
 	switch p {
-	case PaymentMethodCreditCard:
+	case PaymentMethodCreditCard, PaymentMethodBankTransfer, PaymentMethodVoucher:
 		return true
 	default:
 		return false
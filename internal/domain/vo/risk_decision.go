@@ -0,0 +1,36 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+)
+
+type RiskDecision string
+
+const (
+	RiskDecisionAllow     RiskDecision = "allow"
+	RiskDecisionChallenge RiskDecision = "challenge"
+	RiskDecisionReject    RiskDecision = "reject"
+)
+
+func (d RiskDecision) Valid() bool {
+	switch d {
+	case RiskDecisionAllow, RiskDecisionChallenge, RiskDecisionReject:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewRiskDecision(decision string) (RiskDecision, error) {
+	d := RiskDecision(strings.ToLower(decision))
+	if !d.Valid() {
+		return "", errs.ErrInvalidRiskDecision
+	}
+	return d, nil
+}
+
+func (d RiskDecision) String() string {
+	return string(d)
+}
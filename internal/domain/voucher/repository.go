@@ -0,0 +1,16 @@
+package voucher
+
+import "context"
+
+type Repository interface {
+	CreateMany(ctx context.Context, vouchers []Voucher) error
+	// FindByCode locks the voucher row when called inside a transaction, so concurrent
+	// redemptions of the same code run one after another
+	FindByCode(ctx context.Context, code string) (*Voucher, error)
+	FindAll(ctx context.Context, filter *VoucherFilter) ([]Voucher, error)
+	// IncrementRedemptions counts one more redemption. It fails with ErrVoucherExhausted
+	// instead of exceeding MaxRedemptions.
+	IncrementRedemptions(ctx context.Context, id uint) error
+	CountRedemptions(ctx context.Context, voucherID, userID uint) (int64, error)
+	CreateRedemption(ctx context.Context, redemption Redemption) (uint, error)
+}
@@ -0,0 +1,97 @@
+package voucher
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
+
+// Voucher is a promo code that credits a fixed amount to the wallet of whoever redeems it
+type Voucher struct {
+	ID     uint
+	Code   string
+	Batch  string // groups codes generated together, for export
+	Amount vo.Money
+	// MaxRedemptions caps redemptions across all users; zero means unlimited
+	MaxRedemptions int
+	// PerUserLimit caps redemptions by one user; zero means unlimited
+	PerUserLimit int
+	Redemptions  int
+	ValidFrom    *time.Time // nil means valid immediately
+	ValidUntil   *time.Time // nil means no expiry
+	CreatedBy    string
+	CreatedAt    time.Time
+}
+
+// ActiveAt reports whether now falls inside the validity window
+func (v Voucher) ActiveAt(now time.Time) bool {
+	if v.ValidFrom != nil && now.Before(*v.ValidFrom) {
+		return false
+	}
+	return v.ValidUntil == nil || now.Before(*v.ValidUntil)
+}
+
+// Exhausted reports whether every allowed redemption has been used
+func (v Voucher) Exhausted() bool {
+	return v.MaxRedemptions > 0 && v.Redemptions >= v.MaxRedemptions
+}
+
+// Redemption records one use of a voucher and the transaction that credited it
+type Redemption struct {
+	ID            uint
+	VoucherID     uint
+	UserID        uint
+	TransactionID uint
+	CreatedAt     time.Time
+}
+
+type VoucherFilter struct {
+	Batch *string
+}
+
+// GenerateSpec describes a batch of codes to generate
+type GenerateSpec struct {
+	Count          int
+	Prefix         string // optional, letters and digits only
+	Batch          string // generated from the time when empty
+	Amount         float64
+	MaxRedemptions int
+	PerUserLimit   int
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	Actor          string
+}
+
+// NormalizeCode makes codes case-insensitive and tolerant of surrounding spaces
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// codeAlphabet leaves out characters that are easily confused, such as 0/O and 1/I
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// codeLength gives 32^12 possible codes, so random collisions are negligible
+const codeLength = 12
+
+// GenerateCode returns a random code such as PREFIX-ABCD-EFGH-JKLM
+func GenerateCode(prefix string) (string, error) {
+	buf := make([]byte, codeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if prefix = NormalizeCode(prefix); prefix != "" {
+		b.WriteString(prefix)
+		b.WriteByte('-')
+	}
+	for i, c := range buf {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte('-')
+		}
+		// 256 is a multiple of the alphabet size, so every character is equally likely
+		b.WriteByte(codeAlphabet[int(c)%len(codeAlphabet)])
+	}
+	return b.String(), nil
+}
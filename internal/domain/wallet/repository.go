@@ -5,5 +5,13 @@ import "context"
 type Repository interface {
 	Create(wallet Wallet) error
 	Update(ctx context.Context, wallet Wallet) error
-	FindById(id uint) (*Wallet, error)
+	FindById(ctx context.Context, id uint) (*Wallet, error)
+	// AddBalance atomically adds delta, which may be negative, and returns the updated wallet.
+	// It fails with ErrInsufficientBalance instead of taking the balance below zero.
+	AddBalance(ctx context.Context, id uint, delta float64) (*Wallet, error)
+	CreateAdjustment(ctx context.Context, adjustment Adjustment) (uint, error)
+	CreatePostings(ctx context.Context, postings []Posting) error
+	// FindDiscrepancies returns wallets whose balance differs from the net amount of their
+	// completed top-ups plus adjustments
+	FindDiscrepancies(ctx context.Context) ([]Discrepancy, error)
 }
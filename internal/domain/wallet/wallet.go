@@ -1,6 +1,10 @@
 package wallet
 
-import "github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/vo"
+)
 
 // Wallet represents the wallets table (1-to-1 with User)
 type Wallet struct {
@@ -19,3 +23,50 @@ func (w Wallet) ToNotEmptyValueMap() map[string]interface{} {
 type WalletFilter struct {
 	Balance *vo.Money
 }
+
+// Adjustment is a manual balance correction made by an operator
+type Adjustment struct {
+	ID       uint
+	WalletID uint
+	// Amount is signed; a negative amount debits the wallet
+	Amount    float64
+	Reason    string
+	Actor     string
+	CreatedAt time.Time
+}
+
+// PostingType names the part of a top-up a posting records
+type PostingType string
+
+const (
+	PostingTopup PostingType = "topup"
+	PostingFee   PostingType = "fee"
+	PostingBonus PostingType = "bonus"
+)
+
+func (p PostingType) String() string {
+	return string(p)
+}
+
+// Posting is one part of a top-up credited to or debited from a wallet
+type Posting struct {
+	ID            uint
+	WalletID      uint
+	TransactionID uint
+	Type          PostingType
+	// Amount is signed; fees are negative
+	Amount    float64
+	CreatedAt time.Time
+}
+
+// Discrepancy is a wallet whose balance does not match its ledger
+type Discrepancy struct {
+	WalletID uint    `json:"wallet_id"`
+	Balance  float64 `json:"balance"`
+	Expected float64 `json:"expected"` // net credited by completed top-ups plus adjustments
+}
+
+// Difference returns how much the balance exceeds the ledger
+func (d Discrepancy) Difference() float64 {
+	return d.Balance - d.Expected
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription Subscription) (uint, error)
+	FindById(id uint) (*Subscription, error)
+	FindAll(filter *SubscriptionFilter) ([]Subscription, error)
+	Delete(ctx context.Context, id uint) error
+	SetActive(ctx context.Context, id uint, active bool) error
+	// RecordFailure increments the failure streak and deactivates the subscription once it
+	// reaches disableAfter. It returns true when this call disabled the subscription.
+	RecordFailure(ctx context.Context, id uint, disableAfter int) (bool, error)
+	ResetFailures(ctx context.Context, id uint) error
+}
+
+type DeliveryRepository interface {
+	// CreateMany inserts deliveries, ignoring ones that already exist for the same subscription and event
+	CreateMany(ctx context.Context, deliveries []Delivery) error
+	// ClaimDue leases up to limit due deliveries of active subscriptions by pushing their
+	// next attempt lease into the future, so other workers skip them while they are sent.
+	ClaimDue(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]Delivery, error)
+	Update(ctx context.Context, delivery Delivery) error
+	FindById(id uint) (*Delivery, error)
+	FindAll(filter *DeliveryFilter) ([]Delivery, error)
+	CreateAttempt(ctx context.Context, attempt Attempt) error
+	FindAttempts(deliveryID uint) ([]Attempt, error)
+}
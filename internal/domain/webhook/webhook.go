@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// Subscription is a partner endpoint that receives selected events
+type Subscription struct {
+	ID                  uint
+	URL                 string
+	Secret              string
+	EventTypes          []event.Type
+	Active              bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+}
+
+// Subscribes reports whether the subscription wants events of type t
+func (s Subscription) Subscribes(t event.Type) bool {
+	for _, et := range s.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+type SubscriptionFilter struct {
+	Active    *bool
+	EventType *event.Type
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+func (s DeliveryStatus) String() string {
+	return string(s)
+}
+
+// Delivery tracks sending one event to one subscription
+type Delivery struct {
+	ID               uint
+	SubscriptionID   uint
+	EventID          uint
+	EventType        event.Type
+	Payload          []byte
+	Status           DeliveryStatus
+	Attempts         int
+	LastResponseCode int
+	LastError        string
+	NextAttemptAt    time.Time
+	DeliveredAt      *time.Time
+	CreatedAt        time.Time
+}
+
+type DeliveryFilter struct {
+	SubscriptionID *uint
+	Status         *DeliveryStatus
+}
+
+// Attempt is a single HTTP call recorded in the delivery log
+type Attempt struct {
+	ID           uint
+	DeliveryID   uint
+	Attempt      int
+	ResponseCode int
+	Error        string
+	Duration     time.Duration
+	CreatedAt    time.Time
+}
+
+// Request is a signed webhook call ready to be sent
+type Request struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Succeeded reports whether the receiver accepted the webhook
+func (r Response) Succeeded() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Sender performs the HTTP call for a webhook request
+type Sender interface {
+	Send(ctx context.Context, req Request) (Response, error)
+}
+
+// Sign returns the signature header value for body: "t=<unix>,v1=<hex hmac>".
+// The HMAC-SHA256 covers "<unix>.<body>" so a captured request cannot be replayed with a new timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeMAC(secret, ts, body))
+}
+
+// VerifySignature checks a signature header produced by Sign, rejecting timestamps outside tolerance
+func VerifySignature(secret, header string, body []byte, tolerance time.Duration, now time.Time) bool {
+	var ts, mac string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			mac = value
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || mac == "" {
+		return false
+	}
+	if diff := now.Sub(time.Unix(unix, 0)); diff > tolerance || diff < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(mac), []byte(computeMAC(secret, ts, body)))
+}
+
+func computeMAC(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
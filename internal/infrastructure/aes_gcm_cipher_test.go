@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T) *AESGCMCipher {
+	t.Helper()
+	c, err := NewAESGCMCipher(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	require.NoError(t, err)
+	return c
+}
+
+func TestAESGCMCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t)
+	plaintext := []byte(`{"token":"tok_123"}`)
+	ad := []byte("payment_instrument:user:1")
+
+	ciphertext, err := c.Encrypt(plaintext, ad)
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "tok_123")
+
+	again, err := c.Encrypt(plaintext, ad)
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "every ciphertext has its own nonce")
+
+	decrypted, err := c.Decrypt(ciphertext, ad)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestAESGCMCipherRejectsWrongAdditionalData(t *testing.T) {
+	c := newTestCipher(t)
+	ciphertext, err := c.Encrypt([]byte("secret"), []byte("payment_instrument:user:1"))
+	require.NoError(t, err)
+
+	_, err = c.Decrypt(ciphertext, []byte("payment_instrument:user:2"))
+	assert.Error(t, err)
+}
+
+func TestAESGCMCipherRejectsTamperedCiphertext(t *testing.T) {
+	c := newTestCipher(t)
+	ad := []byte("payment_instrument:user:1")
+	ciphertext, err := c.Encrypt([]byte("secret"), ad)
+	require.NoError(t, err)
+
+	for i := range ciphertext {
+		tampered := bytes.Clone(ciphertext)
+		tampered[i] ^= 0x01
+		_, err := c.Decrypt(tampered, ad)
+		assert.Error(t, err, "flipped byte %d", i)
+	}
+}
+
+func TestAESGCMCipherRejectsShortCiphertext(t *testing.T) {
+	c := newTestCipher(t)
+	ad := []byte("payment_instrument:user:1")
+	ciphertext, err := c.Encrypt([]byte("secret"), ad)
+	require.NoError(t, err)
+
+	for _, n := range []int{0, c.aead.NonceSize() - 1, c.aead.NonceSize(), len(ciphertext) - 1} {
+		_, err := c.Decrypt(ciphertext[:n], ad)
+		assert.Error(t, err, "%d bytes", n)
+	}
+}
+
+func TestNewAESGCMCipherRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"empty", ""},
+		{"16 bytes", base64.StdEncoding.EncodeToString(make([]byte, 16))},
+		{"31 bytes", base64.StdEncoding.EncodeToString(make([]byte, 31))},
+		{"33 bytes", base64.StdEncoding.EncodeToString(make([]byte, 33))},
+		{"not base64", "not base64!"},
+		{"url-safe base64", base64.URLEncoding.EncodeToString(bytes.Repeat([]byte{0xfb}, 32))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAESGCMCipher(tt.key)
+			assert.Error(t, err)
+		})
+	}
+}
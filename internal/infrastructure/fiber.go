@@ -1,13 +1,13 @@
 package infrastructure
 
 import (
+	"fmt"
 	"runtime/debug"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
-	fb_logger "github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
 )
 
 type ServerConfig struct {
@@ -17,7 +17,7 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration `yaml:"idleTimeout"`
 }
 
-func NewFiber(config ServerConfig) *fiber.App {
+func NewFiber(config ServerConfig, log logger.Logger) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
@@ -29,7 +29,12 @@ func NewFiber(config ServerConfig) *fiber.App {
 				code = e.Code
 			}
 
-			log.Error("Error occurred", "error", err, "code", code)
+			if code >= fiber.StatusInternalServerError {
+				log.WithContext(c.UserContext()).Error("Error occurred", map[string]interface{}{
+					"error": err.Error(),
+					"code":  code,
+				})
+			}
 			return c.Status(code).JSON(fiber.Map{
 				"error": err.Error(),
 			})
@@ -37,12 +42,15 @@ func NewFiber(config ServerConfig) *fiber.App {
 	})
 
 	// Add middlewares
-	app.Use(fb_logger.New())
+	app.Use(RequestIDMiddleware(log))
+	app.Use(AccessLogMiddleware(log))
 	app.Use(recover.New(recover.Config{
 		EnableStackTrace: true,
 		StackTraceHandler: func(c *fiber.Ctx, err interface{}) {
-			log.Error("Recovered from panic", "error", err, "stack", string(debug.Stack()))
-			c.Status(fiber.StatusInternalServerError).SendString("Internal Server Error")
+			log.WithContext(c.UserContext()).Error("Recovered from panic", map[string]interface{}{
+				"error": fmt.Sprint(err),
+				"stack": string(debug.Stack()),
+			})
 		},
 	}))
 
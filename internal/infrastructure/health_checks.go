@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/health"
+	"gorm.io/gorm"
+)
+
+// PostgresHealthCheck pings the database. The service cannot work without it, so the check is critical.
+func PostgresHealthCheck(db *gorm.DB) health.Check {
+	return health.Check{
+		Name:     "postgres",
+		Critical: true,
+		Probe: func(ctx context.Context) (health.Details, error) {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+			return nil, sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// DBPoolHealthCheck reports connection pool usage and degrades the service once
+// the share of connections in use reaches maxSaturation
+func DBPoolHealthCheck(db *gorm.DB, maxSaturation float64) health.Check {
+	return health.Check{
+		Name:     "postgres_pool",
+		Critical: false,
+		Probe: func(ctx context.Context) (health.Details, error) {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+			stats := sqlDB.Stats()
+			saturation := 0.0
+			if stats.MaxOpenConnections > 0 {
+				saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+			}
+			details := health.Details{
+				"open":           stats.OpenConnections,
+				"in_use":         stats.InUse,
+				"idle":           stats.Idle,
+				"max_open":       stats.MaxOpenConnections,
+				"wait_count":     stats.WaitCount,
+				"wait_duration":  stats.WaitDuration.String(),
+				"saturation":     saturation,
+				"max_saturation": maxSaturation,
+			}
+			if saturation >= maxSaturation {
+				return details, fmt.Errorf("connection pool %.0f%% in use", saturation*100)
+			}
+			return details, nil
+		},
+	}
+}
+
+// MigrationHealthCheck reports the applied schema version and fails while migrations are pending,
+// so a replica is not ready until the schema matches the code it runs
+func MigrationHealthCheck(migrator *Migrator) health.Check {
+	return health.Check{
+		Name:     "migrations",
+		Critical: true,
+		Probe: func(ctx context.Context) (health.Details, error) {
+			version, pending, err := migrator.Version(ctx)
+			if err != nil {
+				return nil, err
+			}
+			details := health.Details{"version": version, "latest": migrator.Latest(), "pending": pending}
+			if pending > 0 {
+				return details, fmt.Errorf("%d pending migrations", pending)
+			}
+			return details, nil
+		},
+	}
+}
+
+// CacheHealthCheck reports cache health. The use cases bypass an unhealthy cache, so it is not critical.
+func CacheHealthCheck(c cache.CacheService) health.Check {
+	return health.Check{
+		Name:     "cache",
+		Critical: false,
+		Probe: func(ctx context.Context) (health.Details, error) {
+			checker, ok := c.(cache.HealthChecker)
+			if !ok {
+				return nil, nil
+			}
+			err := checker.Health(ctx)
+			// Look through decorators such as the metrics wrapper for a circuit breaker
+			inner := c
+			for {
+				if reporter, ok := inner.(interface{ BreakerState() (string, error) }); ok {
+					state, _ := reporter.BreakerState()
+					return health.Details{"circuit": state}, err
+				}
+				wrapper, ok := inner.(interface{ Unwrap() cache.CacheService })
+				if !ok {
+					return nil, err
+				}
+				inner = wrapper.Unwrap()
+			}
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+)
+
+// LifecycleConfig holds shutdown settings
+type LifecycleConfig struct {
+	ShutdownTimeout time.Duration // hard deadline for the whole shutdown
+	DrainDelay      time.Duration // time between reporting not-ready and closing the listener
+}
+
+type lifecycleCloser struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// Lifecycle runs the server and background workers and shuts everything down in order on SIGINT or SIGTERM:
+// shutdown hooks, drain delay, server drain, worker stop, then closers in reverse registration order.
+type Lifecycle struct {
+	logger   logger.Logger
+	cfg      LifecycleConfig
+	ctx      context.Context // cancelled to stop workers
+	cancel   context.CancelFunc
+	workers  sync.WaitGroup
+	hooks    []func()
+	closers  []lifecycleCloser
+	signals  chan os.Signal
+	stopOnce sync.Once
+}
+
+// NewLifecycle creates a Lifecycle and starts listening for termination signals
+func NewLifecycle(logger logger.Logger, cfg LifecycleConfig) *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Lifecycle{
+		logger:  logger,
+		cfg:     cfg,
+		ctx:     ctx,
+		cancel:  cancel,
+		signals: make(chan os.Signal, 1),
+	}
+	signal.Notify(l.signals, syscall.SIGINT, syscall.SIGTERM)
+	return l
+}
+
+// Go starts a background worker. Its context is cancelled after the server has drained.
+func (l *Lifecycle) Go(name string, run func(ctx context.Context)) {
+	l.workers.Add(1)
+	go func() {
+		defer l.workers.Done()
+		run(l.ctx)
+		l.logger.Debug("Worker exited", map[string]interface{}{"worker": name})
+	}()
+}
+
+// OnShutdown registers a hook that runs as soon as shutdown starts, e.g. to fail readiness checks
+func (l *Lifecycle) OnShutdown(hook func()) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// AddCloser registers a resource to close once workers have stopped. Closers run in reverse order,
+// so register long-lived dependencies such as the logger first.
+func (l *Lifecycle) AddCloser(name string, close func(ctx context.Context) error) {
+	l.closers = append(l.closers, lifecycleCloser{name: name, close: close})
+}
+
+// Run calls serve and blocks until it fails or a termination signal arrives, then shuts down.
+// shutdown must stop serve, waiting for in-flight requests until ctx is done.
+func (l *Lifecycle) Run(serve func() error, shutdown func(ctx context.Context) error) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve()
+	}()
+
+	var runErr error
+	select {
+	case sig := <-l.signals:
+		l.logger.Info("Shutdown signal received", map[string]interface{}{"signal": sig.String()})
+	case err := <-serveErr:
+		if err != nil {
+			runErr = fmt.Errorf("server stopped: %w", err)
+		}
+		// Nothing to drain once the server is gone
+		serveErr = nil
+	}
+	return errors.Join(runErr, l.stop(shutdown, serveErr))
+}
+
+func (l *Lifecycle) stop(shutdown func(ctx context.Context) error, serveErr <-chan error) error {
+	var errs []error
+	l.stopOnce.Do(func() {
+		signal.Stop(l.signals)
+		ctx, cancel := context.WithTimeout(context.Background(), l.cfg.ShutdownTimeout)
+		defer cancel()
+		start := time.Now()
+
+		for _, hook := range l.hooks {
+			hook()
+		}
+
+		if serveErr != nil {
+			// Give load balancers time to see the failing readiness probe
+			select {
+			case <-time.After(l.cfg.DrainDelay):
+			case <-ctx.Done():
+			}
+			if err := shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("server shutdown: %w", err))
+			}
+			select {
+			case <-serveErr:
+			case <-ctx.Done():
+			}
+		}
+
+		l.cancel()
+		workersDone := make(chan struct{})
+		go func() {
+			l.workers.Wait()
+			close(workersDone)
+		}()
+		select {
+		case <-workersDone:
+		case <-ctx.Done():
+			errs = append(errs, errors.New("background workers did not stop before the shutdown deadline"))
+		}
+
+		// The logger may be among the closers, so this is the last log line
+		l.logger.Info("Workers stopped, closing resources", map[string]interface{}{
+			"elapsed_ms": time.Since(start).Milliseconds(),
+		})
+		for i := len(l.closers) - 1; i >= 0; i-- {
+			c := l.closers[i]
+			if err := c.close(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("close %s: %w", c.name, err))
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
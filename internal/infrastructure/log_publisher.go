@@ -0,0 +1,29 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+)
+
+// LogEventPublisher implements event.EventPublisher by writing events to the application log.
+// It is useful for local runs where no message broker is available.
+type LogEventPublisher struct {
+	logger logger.Logger
+}
+
+func NewLogEventPublisher(logger logger.Logger) *LogEventPublisher {
+	return &LogEventPublisher{logger: logger}
+}
+
+func (p *LogEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	p.logger.Info("Event published", map[string]interface{}{
+		"event_id":       e.ID,
+		"event_type":     e.Type.String(),
+		"aggregate_type": e.AggregateType,
+		"aggregate_id":   e.AggregateID,
+		"payload":        string(e.Payload),
+	})
+	return nil
+}
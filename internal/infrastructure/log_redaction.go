@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type maskFunc func(string) string
+
+// defaultRedactedFields maps normalized field names to how their values are masked
+var defaultRedactedFields = map[string]maskFunc{
+	"password":      maskAll,
+	"secret":        maskAll,
+	"token":         maskAll,
+	"authorization": maskAll,
+	"cvv":           maskAll,
+	"cardnumber":    maskKeepLast4,
+	"pan":           maskKeepLast4,
+	"phone":         maskKeepLast4,
+	"phonenumber":   maskKeepLast4,
+	"email":         maskEmail,
+}
+
+// redactingCore masks sensitive fields before they reach any encoder or file
+type redactingCore struct {
+	zapcore.Core
+	fields map[string]maskFunc
+}
+
+func newRedactingCore(core zapcore.Core, extra []string) zapcore.Core {
+	fields := make(map[string]maskFunc, len(defaultRedactedFields)+len(extra))
+	for k, v := range defaultRedactedFields {
+		fields[k] = v
+	}
+	for _, name := range extra {
+		if name = normalizeFieldName(name); name != "" {
+			fields[name] = maskAll
+		}
+	}
+	return &redactingCore{Core: core, fields: fields}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redact(fields)), fields: c.fields}
+}
+
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redact(fields))
+}
+
+func (c *redactingCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		redacted, changed := c.redactField(f)
+		if !changed {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			// Copy lazily so the common no-secrets case does not allocate
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, redacted)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func (c *redactingCore) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if mask, ok := c.fields[normalizeFieldName(f.Key)]; ok {
+		return zap.String(f.Key, mask(fieldString(f))), true
+	}
+	// Maps, structs and slices logged with zap.Any, e.g. request payloads
+	if f.Type == zapcore.ReflectType {
+		if redacted, changed := c.redactValue(f.Interface); changed {
+			return zap.Any(f.Key, redacted), true
+		}
+	}
+	return f, false
+}
+
+// redactValue masks sensitive keys at any depth of v. Structs, pointers and typed maps or slices
+// are converted to their JSON form first, so the keys checked are the ones the encoder writes.
+// When nothing is masked v is returned unchanged.
+func (c *redactingCore) redactValue(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return c.redactMap(v)
+	case []interface{}:
+		return c.redactSlice(v)
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Struct, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Array, reflect.Interface:
+	default:
+		return v, false
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v, false
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return v, false
+	}
+	if redacted, changed := c.redactValue(generic); changed {
+		return redacted, true
+	}
+	return v, false
+}
+
+func (c *redactingCore) redactMap(m map[string]interface{}) (map[string]interface{}, bool) {
+	var out map[string]interface{}
+	for k, v := range m {
+		var nv interface{}
+		if mask, ok := c.fields[normalizeFieldName(k)]; ok {
+			nv = mask(fmt.Sprint(v))
+		} else if redacted, changed := c.redactValue(v); changed {
+			nv = redacted
+		} else {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(m))
+			for k2, v2 := range m {
+				out[k2] = v2
+			}
+		}
+		out[k] = nv
+	}
+	if out == nil {
+		return m, false
+	}
+	return out, true
+}
+
+func (c *redactingCore) redactSlice(s []interface{}) ([]interface{}, bool) {
+	var out []interface{}
+	for i, v := range s {
+		redacted, changed := c.redactValue(v)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = slices.Clone(s)
+		}
+		out[i] = redacted
+	}
+	if out == nil {
+		return s, false
+	}
+	return out, true
+}
+
+// normalizeFieldName lets "card_number", "cardNumber" and "Card-Number" match the same rule
+func normalizeFieldName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "", "-", "", ".", "").Replace(name)
+}
+
+func fieldString(f zapcore.Field) string {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+		zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return fmt.Sprint(f.Integer)
+	default:
+		if f.Interface != nil {
+			return fmt.Sprint(f.Interface)
+		}
+		return ""
+	}
+}
+
+func maskAll(string) string {
+	return "[REDACTED]"
+}
+
+func maskKeepLast4(v string) string {
+	if len(v) <= 4 {
+		return strings.Repeat("*", len(v))
+	}
+	return strings.Repeat("*", len(v)-4) + v[len(v)-4:]
+}
+
+func maskEmail(v string) string {
+	at := strings.LastIndex(v, "@")
+	if at < 1 {
+		return maskAll(v)
+	}
+	return v[:1] + "***" + v[at:]
+}
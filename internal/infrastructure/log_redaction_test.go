@@ -0,0 +1,115 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// loggedFields logs fields through a redacting core and returns them as they reached the encoder
+func loggedFields(t *testing.T, extra []string, fields ...zap.Field) map[string]interface{} {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	zap.New(newRedactingCore(core, extra)).Info("test", fields...)
+	require.Equal(t, 1, logs.Len())
+	return logs.All()[0].ContextMap()
+}
+
+type loggedCard struct {
+	CardNumber string `json:"card_number"`
+	CVV        string `json:"cvv"`
+	Brand      string `json:"brand"`
+}
+
+type loggedRequest struct {
+	UserID   uint        `json:"user_id"`
+	Email    string      `json:"email"`
+	Card     *loggedCard `json:"card"`
+	Cards    []loggedCard
+	Metadata map[string]string `json:"metadata"`
+	internal string
+}
+
+func TestRedactTopLevelFields(t *testing.T) {
+	got := loggedFields(t, []string{"api_key"},
+		zap.String("password", "hunter2"),
+		zap.String("cardNumber", "4242424242424242"),
+		zap.String("email", "alice@example.com"),
+		zap.Int64("phone_number", 66812345678),
+		zap.String("API-Key", "abc"),
+		zap.String("status", "ok"),
+	)
+	assert.Equal(t, map[string]interface{}{
+		"password":     "[REDACTED]",
+		"cardNumber":   "************4242",
+		"email":        "a***@example.com",
+		"phone_number": "*******5678",
+		"API-Key":      "[REDACTED]",
+		"status":       "ok",
+	}, got)
+}
+
+func TestRedactNestedMaps(t *testing.T) {
+	got := loggedFields(t, nil, zap.Any("payload", map[string]interface{}{
+		"amount": 100,
+		"card":   map[string]interface{}{"pan": "4242424242424242", "brand": "visa"},
+	}))
+	assert.Equal(t, map[string]interface{}{
+		"amount": 100,
+		"card":   map[string]interface{}{"pan": "************4242", "brand": "visa"},
+	}, got["payload"])
+}
+
+func TestRedactStructs(t *testing.T) {
+	req := loggedRequest{
+		UserID:   7,
+		Email:    "alice@example.com",
+		Card:     &loggedCard{CardNumber: "4242424242424242", CVV: "123", Brand: "visa"},
+		Cards:    []loggedCard{{CardNumber: "5555555555554444", Brand: "mastercard"}},
+		Metadata: map[string]string{"token": "tok_123", "source": "app"},
+	}
+	want := map[string]interface{}{
+		"user_id": float64(7),
+		"email":   "a***@example.com",
+		"card":    map[string]interface{}{"card_number": "************4242", "cvv": "[REDACTED]", "brand": "visa"},
+		"Cards": []interface{}{
+			map[string]interface{}{"card_number": "************4444", "cvv": "[REDACTED]", "brand": "mastercard"},
+		},
+		"metadata": map[string]interface{}{"token": "[REDACTED]", "source": "app"},
+	}
+
+	assert.Equal(t, want, loggedFields(t, nil, zap.Any("request", req))["request"], "struct")
+	assert.Equal(t, want, loggedFields(t, nil, zap.Any("request", &req))["request"], "pointer to struct")
+	assert.Equal(t, map[string]interface{}{"request": want},
+		loggedFields(t, nil, zap.Any("payload", map[string]interface{}{"request": req}))["payload"], "struct inside a map")
+	assert.Equal(t, []interface{}{want},
+		loggedFields(t, nil, zap.Any("requests", []loggedRequest{req}))["requests"], "slice of structs")
+}
+
+func TestRedactLeavesValuesWithoutSecretsUntouched(t *testing.T) {
+	type brand struct {
+		Name string `json:"name"`
+	}
+	got := loggedFields(t, nil,
+		zap.Any("brand", brand{Name: "visa"}),
+		zap.Any("counts", map[string]int{"visa": 2}),
+		zap.Any("nothing", nil),
+	)
+	assert.Equal(t, brand{Name: "visa"}, got["brand"], "the original value is logged when nothing is masked")
+	assert.Equal(t, map[string]int{"visa": 2}, got["counts"])
+	assert.Nil(t, got["nothing"])
+}
+
+func TestRedactWithFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	zap.New(newRedactingCore(core, nil)).
+		With(zap.Any("card", loggedCard{CVV: "123"})).
+		Info("test")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{"card_number": "", "cvv": "[REDACTED]", "brand": ""},
+		logs.All()[0].ContextMap()["card"])
+}
@@ -1,92 +1,134 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
 	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// AppLogger defines the interface for application logging
-// NOTE: In a real Clean Architecture, this interface should be defined
-// in a higher-level package (like "app" or "shared"), not infrastructure.
-// It's defined here for simplicity of this single file example.
+// LogConfig holds logger configuration
+type LogConfig struct {
+	Level        string // debug, info, warn or error
+	Dir          string // directory for the JSON log file; empty disables file logging
+	FileName     string
+	MaxSizeMB    int      // rotate when the file reaches this size
+	MaxBackups   int      // rotated files kept; 0 keeps all
+	MaxAgeDays   int      // rotated files older than this are removed; 0 keeps all
+	Compress     bool     // gzip rotated files
+	RotateDaily  bool     // also rotate at local midnight
+	RedactFields []string // extra field names to mask, on top of the built-in list
+}
 
-// Logger implements the AppLogger interface using zap
+// Logger implements the logger.Logger interface using zap
 type Logger struct {
-	zap *zap.Logger
+	zap    *zap.Logger
+	level  zap.AtomicLevel
+	closer *logCloser // shared by child loggers
 }
 
-// NewLogger creates a new logger instance and returns it as the AppLogger interface
-func NewLogger(isProduction bool) (*Logger, error) { // Return the interface type
-	var config zap.Config
-	var err error
+// logCloser owns the log file and the daily rotation goroutine
+type logCloser struct {
+	file *lumberjack.Logger
+	stop chan struct{}
+	once sync.Once
+}
 
+// NewLogger creates a logger writing JSON to a rotated file and console output to stdout
+func NewLogger(cfg LogConfig, isProduction bool) (*Logger, error) {
+	var config zap.Config
 	if isProduction {
 		config = zap.NewProductionConfig()
 		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		// config.Level.SetLevel(zapcore.InfoLevel) // Adjust level if needed
 	} else {
 		config = zap.NewDevelopmentConfig()
 		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		// config.Level.SetLevel(zapcore.DebugLevel) // Keep Debug for development
 	}
 
-	// Create log directory if it doesn't exist
-	logDir := "logs"
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return nil, fmt.Errorf("can't create log directory: %w", err)
+	level := zap.NewAtomicLevel()
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
 	}
 
-	// Set up log file path (basic daily file for demonstration)
-	// For production, use a robust file rotation library like lumberjack
-	logFile := filepath.Join(logDir, fmt.Sprintf("app-%s.log", time.Now().Format("2006-01-02")))
-
-	// Create a file syncer, handling potential errors gracefully
-	fileSyncer := zapcore.AddSync(os.Stderr) // Default to stderr if file opening fails
-	file, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to open log file %s: %v. Logging only to stdout/stderr.\n", logFile, err)
-	} else {
-		fileSyncer = zapcore.AddSync(file)
-		// Note: Proper file handle closing on application exit is important
-		// but not fully implemented in this basic example's Close/Sync.
-	}
-
-	// Create cores for writing to file (JSON) and stdout (Console)
+	closer := &logCloser{stop: make(chan struct{})}
 	cores := []zapcore.Core{}
 
-	// Add file core if file was successfully opened
-	if file != nil && err == nil {
-		fileCore := zapcore.NewCore(
-			zapcore.NewJSONEncoder(config.EncoderConfig),
-			fileSyncer,
-			config.Level,
-		)
-		cores = append(cores, fileCore)
+	if cfg.Dir != "" {
+		if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+			return nil, fmt.Errorf("can't create log directory: %w", err)
+		}
+		closer.file = &lumberjack.Logger{
+			Filename:   filepath.Join(cfg.Dir, cfg.FileName),
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+			LocalTime:  true,
+		}
+		// The file always gets JSON without color codes
+		fileEncoderConfig := config.EncoderConfig
+		fileEncoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+		cores = append(cores, zapcore.NewCore(
+			zapcore.NewJSONEncoder(fileEncoderConfig),
+			zapcore.AddSync(closer.file),
+			level,
+		))
+		if cfg.RotateDaily {
+			go closer.rotateDaily()
+		}
 	}
 
 	// Always add stdout core
-	stdoutCore := zapcore.NewCore(
+	cores = append(cores, zapcore.NewCore(
 		zapcore.NewConsoleEncoder(config.EncoderConfig),
 		zapcore.AddSync(os.Stdout),
-		config.Level,
-	)
-	cores = append(cores, stdoutCore)
+		level,
+	))
 
-	// Combine cores
-	core := zapcore.NewTee(cores...)
+	core := newRedactingCore(zapcore.NewTee(cores...), cfg.RedactFields)
 
-	// Create logger
 	// Add SkipCaller 1 to skip the wrapper method itself in the log output
 	zapLogger := zap.New(core, zap.AddCallerSkip(1))
 
-	// Return the concrete struct, cast as the interface type
-	return &Logger{zapLogger}, nil
+	return &Logger{zap: zapLogger, level: level, closer: closer}, nil
+}
+
+// rotateDaily rotates the file at each local midnight until the logger is closed
+func (c *logCloser) rotateDaily() {
+	for {
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-c.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			if err := c.file.Rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to rotate log file: %v\n", err)
+			}
+		}
+	}
+}
+
+func (c *logCloser) close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.stop)
+		if c.file != nil {
+			err = c.file.Close()
+		}
+	})
+	return err
 }
 
 // mapToZapFields converts a map[string]interface{} into a slice of zapcore.Field
@@ -104,7 +146,7 @@ func mapToZapFields(fields map[string]interface{}) []zapcore.Field {
 	return zapFields
 }
 
-// Implement the AppLogger methods
+// Implement the logger.Logger methods
 
 func (l *Logger) Debug(msg string, fields map[string]interface{}) {
 	zapFields := mapToZapFields(fields)
@@ -132,22 +174,46 @@ func (l *Logger) Fatal(msg string, fields map[string]interface{}) {
 }
 
 func (l *Logger) With(fields map[string]interface{}) logger.Logger {
-	zapFields := mapToZapFields(fields)
-	// Return the new logger wrapped in our Logger struct, cast as the interface
-	return &Logger{
-		zap: l.zap.With(zapFields...),
+	return l.child(mapToZapFields(fields)...)
+}
+
+func (l *Logger) WithContext(ctx context.Context) logger.Logger {
+	base := l
+	if stored, ok := logger.FromContext(ctx); ok {
+		if zl, ok := stored.(*Logger); ok {
+			base = zl
+		}
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return base
+	}
+	return base.child(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+}
+
+func (l *Logger) child(fields ...zapcore.Field) *Logger {
+	return &Logger{zap: l.zap.With(fields...), level: l.level, closer: l.closer}
+}
+
+// Level returns the current minimum level
+func (l *Logger) Level() string {
+	return l.level.Level().String()
+}
+
+// SetLevel changes the minimum level for this logger and every logger derived from it
+func (l *Logger) SetLevel(level string) error {
+	if err := l.level.UnmarshalText([]byte(level)); err != nil {
+		return errs.ErrInvalidLogLevel
 	}
+	return nil
 }
 
 func (l *Logger) Sync() error {
-	// Sync attempts to flush buffered logs.
-	// If you were managing the file handle directly, add file.Close() here.
 	return l.zap.Sync()
 }
 
-// Optional Close method if you need explicit resource cleanup
+// Close flushes buffered entries and closes the log file
 func (l *Logger) Close() error {
-	syncErr := l.zap.Sync()
-	// Add file.Close() logic here if you stored the file handle
-	return syncErr
+	_ = l.zap.Sync() // stdout sync fails on some platforms; nothing to do about it
+	return l.closer.close()
 }
@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+)
+
+// MemoryCacheConfig holds in-process cache configuration
+type MemoryCacheConfig struct {
+	MaxEntries      int           // least recently used entries are evicted beyond this; 0 means unbounded
+	JanitorInterval time.Duration // how often expired entries are swept
+}
+
+type memoryEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time // zero means no expiry
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// MemoryCache implements cache.CacheService in process memory.
+// Values are stored JSON encoded so Get behaves the same as with Redis.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	items      map[string]*list.Element
+	lru        *list.List // front is most recently used
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewMemoryCache creates a MemoryCache and starts its janitor goroutine
+func NewMemoryCache(cfg MemoryCacheConfig) *MemoryCache {
+	c := &MemoryCache{
+		maxEntries: cfg.MaxEntries,
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+		stop:       make(chan struct{}),
+	}
+	if cfg.JanitorInterval > 0 {
+		go c.janitor(cfg.JanitorInterval)
+	}
+	return c
+}
+
+// Set stores a value with expiration; zero expiration keeps the value until evicted
+func (c *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = time.Now().Add(expiration)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.data = data
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(el)
+		return nil
+	}
+	c.items[key] = c.lru.PushFront(&memoryEntry{key: key, data: data, expiresAt: expiresAt})
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+	return nil
+}
+
+// Get retrieves a value by key
+func (c *MemoryCache) Get(ctx context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	el, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return cache.ErrCacheMiss
+	}
+	entry := el.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		c.removeElement(el)
+		c.mu.Unlock()
+		return cache.ErrCacheMiss
+	}
+	c.lru.MoveToFront(el)
+	data := entry.data
+	c.mu.Unlock()
+
+	return json.Unmarshal(data, dest)
+}
+
+// Delete removes a key
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including expired ones not yet swept
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Health always succeeds for the in-process cache
+func (c *MemoryCache) Health(ctx context.Context) error {
+	return nil
+}
+
+// Close stops the janitor goroutine
+func (c *MemoryCache) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return nil
+}
+
+func (c *MemoryCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.deleteExpired()
+		}
+	}
+}
+
+func (c *MemoryCache) deleteExpired() {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.lru.Back(); el != nil; {
+		prev := el.Prev()
+		if el.Value.(*memoryEntry).expired(now) {
+			c.removeElement(el)
+		}
+		el = prev
+	}
+}
+
+// removeElement must be called with mu held
+func (c *MemoryCache) removeElement(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.items, el.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(MemoryCacheConfig{MaxEntries: 2})
+	defer c.Close()
+
+	require.NoError(t, c.Set(ctx, "a", 1, 0))
+	require.NoError(t, c.Set(ctx, "b", 2, 0))
+	// Reading a makes b the least recently used entry
+	var got int
+	require.NoError(t, c.Get(ctx, "a", &got))
+	require.NoError(t, c.Set(ctx, "c", 3, 0))
+
+	assert.Equal(t, 2, c.Len())
+	assert.ErrorIs(t, c.Get(ctx, "b", &got), cache.ErrCacheMiss)
+	require.NoError(t, c.Get(ctx, "a", &got))
+	assert.Equal(t, 1, got)
+	require.NoError(t, c.Get(ctx, "c", &got))
+	assert.Equal(t, 3, got)
+
+	// Overwriting an entry neither grows the cache nor evicts anything
+	require.NoError(t, c.Set(ctx, "a", 10, 0))
+	assert.Equal(t, 2, c.Len())
+	require.NoError(t, c.Get(ctx, "a", &got))
+	assert.Equal(t, 10, got)
+}
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(MemoryCacheConfig{})
+	defer c.Close()
+
+	require.NoError(t, c.Set(ctx, "short", "v", 20*time.Millisecond))
+	require.NoError(t, c.Set(ctx, "forever", "v", 0))
+
+	var got string
+	require.NoError(t, c.Get(ctx, "short", &got))
+	time.Sleep(40 * time.Millisecond)
+
+	assert.ErrorIs(t, c.Get(ctx, "short", &got), cache.ErrCacheMiss)
+	assert.NoError(t, c.Get(ctx, "forever", &got))
+	// The expired entry was dropped when it was read
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestMemoryCacheJanitorSweepsExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(MemoryCacheConfig{JanitorInterval: 10 * time.Millisecond})
+	defer c.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, c.Set(ctx, key, key, 20*time.Millisecond))
+	}
+	require.NoError(t, c.Set(ctx, "kept", "v", time.Hour))
+
+	// Nothing reads the expired entries, so only the janitor can remove them
+	assert.Eventually(t, func() bool { return c.Len() == 1 }, time.Second, 10*time.Millisecond)
+	var got string
+	assert.NoError(t, c.Get(ctx, "kept", &got))
+}
+
+func TestMemoryCacheCloseStopsJanitor(t *testing.T) {
+	c := NewMemoryCache(MemoryCacheConfig{JanitorInterval: 10 * time.Millisecond})
+	require.NoError(t, c.Close())
+	// Closing twice is safe
+	require.NoError(t, c.Close())
+
+	require.NoError(t, c.Set(context.Background(), "a", 1, 10*time.Millisecond))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, c.Len(), "a stopped janitor must not sweep")
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/lock"
+)
+
+// MemoryLocker implements lock.Locker within a single process, for running without Redis
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]memoryLockEntry
+	cfg   LockerConfig
+}
+
+type memoryLockEntry struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewMemoryLocker creates a new MemoryLocker
+func NewMemoryLocker(cfg LockerConfig) *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]memoryLockEntry), cfg: cfg}
+}
+
+// Acquire retries with jittered exponential backoff until the lock is held or ctx is done
+func (l *MemoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (lock.Lock, error) {
+	return acquireWithBackoff(ctx, l.cfg, func() (lock.Lock, error) {
+		return l.TryAcquire(ctx, key, ttl)
+	})
+}
+
+// TryAcquire makes a single attempt to take the lock
+func (l *MemoryLocker) TryAcquire(ctx context.Context, key string, ttl time.Duration) (lock.Lock, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+	fullKey := l.cfg.Prefix + key
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if entry, ok := l.locks[fullKey]; ok && time.Now().Before(entry.expiresAt) {
+		return nil, errs.ErrLockNotAcquired
+	}
+	l.locks[fullKey] = memoryLockEntry{token: token, expiresAt: time.Now().Add(ttl)}
+	return &memoryLock{locker: l, key: fullKey, token: token}, nil
+}
+
+type memoryLock struct {
+	locker *MemoryLocker
+	key    string
+	token  string
+}
+
+func (l *memoryLock) Key() string {
+	return l.key
+}
+
+func (l *memoryLock) Extend(ctx context.Context, ttl time.Duration) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	entry, ok := l.locker.locks[l.key]
+	if !ok || entry.token != l.token || time.Now().After(entry.expiresAt) {
+		return errs.ErrLockLost
+	}
+	entry.expiresAt = time.Now().Add(ttl)
+	l.locker.locks[l.key] = entry
+	return nil
+}
+
+func (l *memoryLock) Release(ctx context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if entry, ok := l.locker.locks[l.key]; ok && entry.token == l.token {
+		delete(l.locker.locks, l.key)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+)
+
+// RequestIDHeader carries the correlation ID between clients, this service and its logs
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware accepts the caller's X-Request-ID, or generates one, echoes it on the response
+// and stores a child logger carrying it in the request's user context.
+func RequestIDMiddleware(log logger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Set(RequestIDHeader, requestID)
+		c.Locals("request_id", requestID)
+		c.SetUserContext(logger.NewContext(c.UserContext(), log.With(map[string]interface{}{
+			"request_id": requestID,
+		})))
+		return c.Next()
+	}
+}
+
+// validRequestID rejects empty, oversized or non-printable IDs so callers can't inject into the logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLogMiddleware writes one log line per request through the application logger.
+// Errors are passed to the app's error handler here so the logged status is the one sent.
+func AccessLogMiddleware(log logger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		status := c.Response().StatusCode()
+		fields := map[string]interface{}{
+			"method":     c.Method(),
+			"path":       c.Path(),
+			"route":      c.Route().Path,
+			"status":     status,
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"ip":         c.IP(),
+			"bytes_out":  len(c.Response().Body()),
+		}
+		reqLog := log.WithContext(c.UserContext())
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			reqLog.Error("HTTP request", fields)
+		case status >= fiber.StatusBadRequest:
+			reqLog.Warn("HTTP request", fields)
+		default:
+			reqLog.Info("HTTP request", fields)
+		}
+		return nil
+	}
+}
+
+// AdminAuthMiddleware lets a request through only when it carries apiKey as a bearer token.
+// The key is compared in constant time, and a missing or wrong key gets 401.
+func AdminAuthMiddleware(apiKey string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || apiKey == "" || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+			c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":  fiber.StatusUnauthorized,
+				"message": "Admin API key required",
+			})
+		}
+		return c.Next()
+	}
+}
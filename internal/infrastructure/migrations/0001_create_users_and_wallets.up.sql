@@ -0,0 +1,30 @@
+-- Baseline schema. IF NOT EXISTS lets databases created by the old AutoMigrate adopt it.
+CREATE TABLE IF NOT EXISTS users (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    first_name VARCHAR(50)  NOT NULL,
+    last_name  VARCHAR(50)  NOT NULL,
+    email      VARCHAR(100) NOT NULL,
+    password   VARCHAR(255) NOT NULL,
+    phone      VARCHAR(20)  NOT NULL,
+    kyc_status VARCHAR(20)  NOT NULL DEFAULT 'none',
+    kyc_tier   VARCHAR(20)  NOT NULL DEFAULT 'tier_0'
+);
+-- Tables created by AutoMigrate predate the KYC columns
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS kyc_status VARCHAR(20) NOT NULL DEFAULT 'none',
+    ADD COLUMN IF NOT EXISTS kyc_tier   VARCHAR(20) NOT NULL DEFAULT 'tier_0';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+-- Wallets share their primary key with the owning user
+CREATE TABLE IF NOT EXISTS wallets (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    balance    DECIMAL(18,2) NOT NULL DEFAULT 0.00
+);
+CREATE INDEX IF NOT EXISTS idx_wallets_deleted_at ON wallets (deleted_at);
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS transactions (
+    id             BIGSERIAL PRIMARY KEY,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ,
+    user_id        BIGINT        NOT NULL,
+    amount         DECIMAL(18,2) NOT NULL,
+    payment_method VARCHAR(50)   NOT NULL,
+    status         VARCHAR(20)   NOT NULL,
+    expires_at     TIMESTAMPTZ   NOT NULL,
+    risk_score     BIGINT        NOT NULL DEFAULT 0,
+    risk_decision  VARCHAR(20)   NOT NULL DEFAULT 'allow',
+    risk_reasons   TEXT,
+    CONSTRAINT chk_transactions_amount CHECK (amount > 0),
+    CONSTRAINT chk_transactions_payment_method CHECK (payment_method IN ('credit_card')),
+    CONSTRAINT chk_transactions_status CHECK (status IN ('verified','completed','failed','expired')),
+    CONSTRAINT chk_transactions_risk_decision CHECK (risk_decision IN ('allow','challenge','reject'))
+);
+-- Tables created by AutoMigrate predate the risk columns
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS risk_score    BIGINT      NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS risk_decision VARCHAR(20) NOT NULL DEFAULT 'allow',
+    ADD COLUMN IF NOT EXISTS risk_reasons  TEXT;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_transactions_risk_decision') THEN
+        ALTER TABLE transactions
+            ADD CONSTRAINT chk_transactions_risk_decision CHECK (risk_decision IN ('allow','challenge','reject'));
+    END IF;
+END
+$$;
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at);
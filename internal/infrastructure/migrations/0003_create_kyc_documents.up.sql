@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS kyc_documents (
+    id            BIGSERIAL PRIMARY KEY,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    deleted_at    TIMESTAMPTZ,
+    user_id       BIGINT       NOT NULL,
+    document_type VARCHAR(30)  NOT NULL,
+    storage_key   VARCHAR(255) NOT NULL,
+    status        VARCHAR(20)  NOT NULL,
+    review_note   VARCHAR(500),
+    reviewed_at   TIMESTAMPTZ,
+    CONSTRAINT chk_kyc_documents_document_type CHECK (document_type IN ('national_id','passport','driving_license')),
+    CONSTRAINT chk_kyc_documents_status CHECK (status IN ('pending','approved','rejected'))
+);
+CREATE INDEX IF NOT EXISTS idx_kyc_documents_user_id ON kyc_documents (user_id);
+CREATE INDEX IF NOT EXISTS idx_kyc_documents_deleted_at ON kyc_documents (deleted_at);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id              BIGSERIAL PRIMARY KEY,
+    aggregate_type  VARCHAR(50)  NOT NULL,
+    aggregate_id    VARCHAR(64)  NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    payload         JSONB        NOT NULL,
+    status          VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts        BIGINT       NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ  NOT NULL,
+    created_at      TIMESTAMPTZ,
+    published_at    TIMESTAMPTZ,
+    CONSTRAINT chk_outbox_messages_status CHECK (status IN ('pending','published','dead'))
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate ON outbox_messages (aggregate_type, aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_status ON outbox_messages (status);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_next_attempt_at ON outbox_messages (next_attempt_at);
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                   BIGSERIAL PRIMARY KEY,
+    created_at           TIMESTAMPTZ,
+    updated_at           TIMESTAMPTZ,
+    deleted_at           TIMESTAMPTZ,
+    url                  VARCHAR(500) NOT NULL,
+    secret               VARCHAR(100) NOT NULL,
+    event_types          VARCHAR(500) NOT NULL,
+    active               BOOLEAN      NOT NULL DEFAULT true,
+    consecutive_failures BIGINT       NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_deleted_at ON webhook_subscriptions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                 BIGSERIAL PRIMARY KEY,
+    subscription_id    BIGINT       NOT NULL,
+    event_id           BIGINT       NOT NULL,
+    event_type         VARCHAR(100) NOT NULL,
+    payload            JSONB        NOT NULL,
+    status             VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts           BIGINT       NOT NULL DEFAULT 0,
+    last_response_code BIGINT,
+    last_error         TEXT,
+    next_attempt_at    TIMESTAMPTZ  NOT NULL,
+    delivered_at       TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ,
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending','succeeded','failed'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_delivery_event ON webhook_deliveries (subscription_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id            BIGSERIAL PRIMARY KEY,
+    delivery_id   BIGINT NOT NULL,
+    attempt       BIGINT NOT NULL,
+    response_code BIGINT,
+    error         TEXT,
+    duration_ms   BIGINT NOT NULL,
+    created_at    TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts (delivery_id);
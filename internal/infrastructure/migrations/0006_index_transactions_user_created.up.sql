@@ -0,0 +1,3 @@
+-- migrate:no-transaction
+-- The risk engine and history lookups filter by user and creation time; build the index without blocking writes
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_transactions_user_created ON transactions (user_id, created_at);
@@ -0,0 +1,10 @@
+CREATE TABLE balance_adjustments (
+    id         BIGSERIAL PRIMARY KEY,
+    wallet_id  BIGINT        NOT NULL,
+    amount     DECIMAL(18,2) NOT NULL,
+    reason     VARCHAR(500)  NOT NULL,
+    actor      VARCHAR(100)  NOT NULL,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT chk_balance_adjustments_amount CHECK (amount <> 0)
+);
+CREATE INDEX idx_balance_adjustments_wallet_id ON balance_adjustments (wallet_id);
@@ -0,0 +1,17 @@
+CREATE TABLE settings (
+    key        VARCHAR(100) PRIMARY KEY,
+    value      TEXT         NOT NULL,
+    updated_by VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE setting_changes (
+    id         BIGSERIAL PRIMARY KEY,
+    key        VARCHAR(100) NOT NULL,
+    old_value  TEXT,
+    new_value  TEXT,
+    actor      VARCHAR(100) NOT NULL,
+    reason     VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX idx_setting_changes_key ON setting_changes (key);
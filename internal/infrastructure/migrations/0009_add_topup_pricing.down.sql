@@ -0,0 +1,17 @@
+-- Wallets were credited net of fees and bonuses, so dropping them would leave every such wallet
+-- out of line with its top-ups. Roll back only once those top-ups have been dealt with by hand.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM transactions WHERE status = 'completed' AND (fee <> 0 OR bonus <> 0)) THEN
+        RAISE EXCEPTION 'completed top-ups with fees or bonuses exist; remove them before rolling back 0009_add_topup_pricing';
+    END IF;
+END
+$$;
+
+DROP TABLE IF EXISTS wallet_postings;
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS chk_transactions_bonus,
+    DROP CONSTRAINT IF EXISTS chk_transactions_fee,
+    DROP COLUMN IF EXISTS campaign,
+    DROP COLUMN IF EXISTS bonus,
+    DROP COLUMN IF EXISTS fee;
@@ -0,0 +1,20 @@
+-- Fee and bonus are fixed at verification so a confirm credits exactly what was quoted
+ALTER TABLE transactions
+    ADD COLUMN fee      DECIMAL(18,2) NOT NULL DEFAULT 0,
+    ADD COLUMN bonus    DECIMAL(18,2) NOT NULL DEFAULT 0,
+    ADD COLUMN campaign VARCHAR(100)  NOT NULL DEFAULT '',
+    ADD CONSTRAINT chk_transactions_fee CHECK (fee >= 0),
+    ADD CONSTRAINT chk_transactions_bonus CHECK (bonus >= 0);
+
+CREATE TABLE wallet_postings (
+    id             BIGSERIAL PRIMARY KEY,
+    wallet_id      BIGINT        NOT NULL,
+    transaction_id BIGINT        NOT NULL,
+    type           VARCHAR(20)   NOT NULL,
+    amount         DECIMAL(18,2) NOT NULL,
+    created_at     TIMESTAMPTZ,
+    CONSTRAINT chk_wallet_postings_type CHECK (type IN ('topup','fee','bonus')),
+    CONSTRAINT chk_wallet_postings_amount CHECK (amount <> 0)
+);
+CREATE INDEX idx_wallet_postings_wallet_id ON wallet_postings (wallet_id);
+CREATE INDEX idx_wallet_postings_transaction_id ON wallet_postings (transaction_id);
@@ -0,0 +1,14 @@
+-- Voucher credits are real wallet money, so they are never deleted here. Roll back only once
+-- every voucher transaction, and the balance it credited, has been dealt with by hand.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM transactions WHERE payment_method = 'voucher') THEN
+        RAISE EXCEPTION 'voucher transactions exist; remove them before rolling back 0010_create_vouchers';
+    END IF;
+END
+$$;
+
+DROP TABLE IF EXISTS voucher_redemptions;
+DROP TABLE IF EXISTS vouchers;
+ALTER TABLE transactions DROP CONSTRAINT chk_transactions_payment_method;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_payment_method CHECK (payment_method IN ('credit_card'));
@@ -0,0 +1,32 @@
+ALTER TABLE transactions DROP CONSTRAINT chk_transactions_payment_method;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_payment_method CHECK (payment_method IN ('credit_card','voucher'));
+
+CREATE TABLE vouchers (
+    id              BIGSERIAL PRIMARY KEY,
+    code            VARCHAR(64)   NOT NULL,
+    batch           VARCHAR(100)  NOT NULL,
+    amount          DECIMAL(18,2) NOT NULL,
+    max_redemptions BIGINT        NOT NULL DEFAULT 1,
+    per_user_limit  BIGINT        NOT NULL DEFAULT 1,
+    redemptions     BIGINT        NOT NULL DEFAULT 0,
+    valid_from      TIMESTAMPTZ,
+    valid_until     TIMESTAMPTZ,
+    created_by      VARCHAR(100)  NOT NULL,
+    created_at      TIMESTAMPTZ,
+    CONSTRAINT chk_vouchers_amount CHECK (amount > 0),
+    CONSTRAINT chk_vouchers_limits CHECK (max_redemptions >= 0 AND per_user_limit >= 0),
+    -- Last line of defence against over-redemption; redemption also locks the voucher row
+    CONSTRAINT chk_vouchers_redemptions CHECK (redemptions >= 0 AND (max_redemptions = 0 OR redemptions <= max_redemptions))
+);
+CREATE UNIQUE INDEX idx_vouchers_code ON vouchers (code);
+CREATE INDEX idx_vouchers_batch ON vouchers (batch);
+
+CREATE TABLE voucher_redemptions (
+    id             BIGSERIAL PRIMARY KEY,
+    voucher_id     BIGINT NOT NULL,
+    user_id        BIGINT NOT NULL,
+    transaction_id BIGINT NOT NULL,
+    created_at     TIMESTAMPTZ
+);
+CREATE INDEX idx_voucher_redemptions_voucher_user ON voucher_redemptions (voucher_id, user_id);
+CREATE UNIQUE INDEX idx_voucher_redemptions_transaction_id ON voucher_redemptions (transaction_id);
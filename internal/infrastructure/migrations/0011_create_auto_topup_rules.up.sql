@@ -0,0 +1,22 @@
+CREATE TABLE auto_topup_rules (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT        NOT NULL,
+    "trigger"      VARCHAR(20)   NOT NULL,
+    threshold      DECIMAL(18,2) NOT NULL DEFAULT 0,
+    schedule       VARCHAR(100)  NOT NULL DEFAULT '',
+    amount         DECIMAL(18,2) NOT NULL,
+    payment_method VARCHAR(50)   NOT NULL,
+    payment_token  VARCHAR(255)  NOT NULL,
+    enabled        BOOLEAN       NOT NULL DEFAULT TRUE,
+    next_run_at    TIMESTAMPTZ,
+    attempts       BIGINT        NOT NULL DEFAULT 0,
+    last_run_at    TIMESTAMPTZ,
+    last_error     TEXT          NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    CONSTRAINT chk_auto_topup_rules_trigger CHECK ("trigger" IN ('threshold','schedule')),
+    CONSTRAINT chk_auto_topup_rules_amount CHECK (amount > 0)
+);
+CREATE INDEX idx_auto_topup_rules_user_id ON auto_topup_rules (user_id);
+-- The scheduler polls for enabled rules that are due
+CREATE INDEX idx_auto_topup_rules_next_run_at ON auto_topup_rules (next_run_at) WHERE enabled;
@@ -0,0 +1,23 @@
+-- Bank transfer top-ups are real wallet money, so they are never deleted or relabelled here.
+-- Roll back only once every bank transfer transaction has been dealt with by hand.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM transactions WHERE payment_method = 'bank_transfer') THEN
+        RAISE EXCEPTION 'bank_transfer transactions exist; remove them before rolling back 0012_create_payment_instruments';
+    END IF;
+END
+$$;
+
+-- Restored rules have no token and stay disabled
+ALTER TABLE auto_topup_rules DROP CONSTRAINT IF EXISTS chk_auto_topup_rules_instrument;
+UPDATE auto_topup_rules SET enabled = FALSE, next_run_at = NULL;
+ALTER TABLE auto_topup_rules
+    ADD COLUMN payment_method VARCHAR(50)  NOT NULL DEFAULT 'credit_card',
+    ADD COLUMN payment_token  VARCHAR(255) NOT NULL DEFAULT '',
+    DROP COLUMN IF EXISTS instrument_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS instrument_id;
+ALTER TABLE transactions DROP CONSTRAINT chk_transactions_payment_method;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_payment_method CHECK (payment_method IN ('credit_card','voucher'));
+
+DROP TABLE IF EXISTS payment_instruments;
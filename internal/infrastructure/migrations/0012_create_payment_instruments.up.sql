@@ -0,0 +1,36 @@
+CREATE TABLE payment_instruments (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT      NOT NULL,
+    type           VARCHAR(20) NOT NULL,
+    -- Provider token and card or bank account details, encrypted with AES-GCM
+    encrypted_data BYTEA       NOT NULL,
+    created_at     TIMESTAMPTZ,
+    CONSTRAINT chk_payment_instruments_type CHECK (type IN ('card','bank_account'))
+);
+CREATE INDEX idx_payment_instruments_user_id ON payment_instruments (user_id);
+
+ALTER TABLE transactions DROP CONSTRAINT chk_transactions_payment_method;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_payment_method CHECK (payment_method IN ('credit_card','bank_transfer','voucher'));
+ALTER TABLE transactions ADD COLUMN instrument_id BIGINT REFERENCES payment_instruments (id) ON DELETE SET NULL;
+
+-- Rules now charge a saved instrument. Existing rules only have a plaintext token, which is
+-- dropped, so they are disabled until their owner recreates them. Each disabled rule reports
+-- autotopup.failed, so webhook subscribers can tell its owner.
+ALTER TABLE auto_topup_rules ADD COLUMN instrument_id BIGINT REFERENCES payment_instruments (id);
+INSERT INTO outbox_messages (aggregate_type, aggregate_id, event_type, payload, status, next_attempt_at, created_at)
+SELECT 'auto_topup_rule', id::TEXT, 'autotopup.failed',
+       jsonb_build_object(
+           'rule_id', id,
+           'user_id', user_id,
+           'trigger', "trigger",
+           'amount', amount,
+           'attempts', 0,
+           'error', 'a saved payment instrument is required; recreate the rule'),
+       'pending', NOW(), NOW()
+FROM auto_topup_rules
+WHERE enabled;
+UPDATE auto_topup_rules SET enabled = FALSE, next_run_at = NULL, last_error = 'a saved payment instrument is required; recreate the rule';
+-- Blank the plaintext tokens before the column goes; VACUUM FULL then removes the old row versions
+UPDATE auto_topup_rules SET payment_token = '';
+ALTER TABLE auto_topup_rules DROP COLUMN payment_method, DROP COLUMN payment_token;
+ALTER TABLE auto_topup_rules ADD CONSTRAINT chk_auto_topup_rules_instrument CHECK (instrument_id IS NOT NULL OR NOT enabled);
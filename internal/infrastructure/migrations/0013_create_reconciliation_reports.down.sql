@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS reconciliation_items;
+DROP TABLE IF EXISTS reconciliation_reports;
+DROP INDEX IF EXISTS idx_transactions_provider_created_at;
+DROP INDEX IF EXISTS idx_transactions_provider_reference;
+ALTER TABLE transactions DROP COLUMN IF EXISTS provider_reference;
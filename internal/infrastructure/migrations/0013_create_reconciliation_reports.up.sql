@@ -0,0 +1,34 @@
+-- Top-ups charged through a payment provider keep the provider's reference for reconciliation
+ALTER TABLE transactions ADD COLUMN provider_reference VARCHAR(100);
+CREATE UNIQUE INDEX idx_transactions_provider_reference ON transactions (provider_reference);
+-- Reconciliation loads a day of provider-charged top-ups at a time
+CREATE INDEX idx_transactions_provider_created_at ON transactions (created_at) WHERE provider_reference IS NOT NULL;
+
+CREATE TABLE reconciliation_reports (
+    id                BIGSERIAL PRIMARY KEY,
+    provider          VARCHAR(50)  NOT NULL,
+    settlement_date   DATE         NOT NULL,
+    file_name         VARCHAR(255) NOT NULL DEFAULT '',
+    format            VARCHAR(10)  NOT NULL,
+    records           BIGINT       NOT NULL DEFAULT 0,
+    matched           BIGINT       NOT NULL DEFAULT 0,
+    missing_in_ours   BIGINT       NOT NULL DEFAULT 0,
+    missing_in_theirs BIGINT       NOT NULL DEFAULT 0,
+    amount_mismatch   BIGINT       NOT NULL DEFAULT 0,
+    created_by        VARCHAR(100) NOT NULL,
+    created_at        TIMESTAMPTZ,
+    CONSTRAINT chk_reconciliation_reports_format CHECK (format IN ('csv','json'))
+);
+CREATE INDEX idx_reconciliation_reports_provider_date ON reconciliation_reports (provider, settlement_date);
+
+CREATE TABLE reconciliation_items (
+    id                 BIGSERIAL PRIMARY KEY,
+    report_id          BIGINT        NOT NULL REFERENCES reconciliation_reports (id) ON DELETE CASCADE,
+    status             VARCHAR(20)   NOT NULL,
+    provider_reference VARCHAR(100)  NOT NULL,
+    transaction_id     BIGINT,
+    provider_amount    DECIMAL(18,2),
+    transaction_amount DECIMAL(18,2),
+    CONSTRAINT chk_reconciliation_items_status CHECK (status IN ('matched','missing_in_ours','missing_in_theirs','amount_mismatch'))
+);
+CREATE INDEX idx_reconciliation_items_report_status ON reconciliation_items (report_id, status);
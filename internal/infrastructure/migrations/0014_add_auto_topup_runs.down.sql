@@ -0,0 +1,3 @@
+ALTER TABLE auto_topup_rules
+    DROP COLUMN IF EXISTS run_transaction_id,
+    DROP COLUMN IF EXISTS run_key;
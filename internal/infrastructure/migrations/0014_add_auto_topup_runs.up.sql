@@ -0,0 +1,5 @@
+-- A run keeps its charge key and top-up until it finishes, so a retried or resumed run
+-- confirms the top-up it already paid for instead of charging the instrument again
+ALTER TABLE auto_topup_rules
+    ADD COLUMN run_key            VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN run_transaction_id BIGINT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_completed_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,13 @@
+-- Statements date a top-up by when it was credited, not when it was verified
+ALTER TABLE transactions ADD COLUMN completed_at TIMESTAMPTZ;
+
+-- Top-ups completed before this migration take the time of their wallet postings. Those completed
+-- before postings were recorded fall back to their last update, which a confirm sets.
+UPDATE transactions t
+SET completed_at = COALESCE(
+    (SELECT MIN(p.created_at) FROM wallet_postings p WHERE p.transaction_id = t.id),
+    t.updated_at,
+    t.created_at)
+WHERE t.status = 'completed';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_completed_at ON transactions (completed_at);
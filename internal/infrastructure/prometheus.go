@@ -0,0 +1,220 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const metricsNamespace = "wallet"
+
+// PrometheusMetrics owns the Prometheus registry and implements metrics.Recorder
+type PrometheusMetrics struct {
+	registry      *prometheus.Registry
+	httpDuration  *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+	cacheRequests *prometheus.CounterVec
+	topups        *prometheus.CounterVec
+	topupAmount   *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates and registers all collectors, including Go runtime and process metrics
+func NewPrometheusMetrics() *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "GORM query latency by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "cache_requests_total",
+			Help:      "Cache reads by result (hit, miss or error).",
+		}, []string{"result"}),
+		topups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "topups_total",
+			Help:      "Top-ups by lifecycle stage and payment method.",
+		}, []string{"stage", "payment_method"}),
+		topupAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "topup_amount_total",
+			Help:      "Sum of top-up amounts by lifecycle stage and payment method.",
+		}, []string{"stage", "payment_method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.queryDuration,
+		m.cacheRequests,
+		m.topups,
+		m.topupAmount,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *PrometheusMetrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware observes request latency. The route template is used as label to keep cardinality bounded.
+func (m *PrometheusMetrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		status := c.Response().StatusCode()
+		if err != nil {
+			// The error handler has not written the response yet
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && route == "/" {
+			route = "unmatched"
+		}
+		m.httpDuration.WithLabelValues(c.Method(), route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// RegisterDB adds GORM query timing callbacks and sql.DB pool statistics
+func (m *PrometheusMetrics) RegisterDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := m.registry.Register(collectors.NewDBStatsCollector(sqlDB, "wallet")); err != nil {
+		return err
+	}
+	return db.Use(&gormMetricsPlugin{duration: m.queryDuration})
+}
+
+// InstrumentCache wraps a cache so reads are counted as hits, misses or errors
+func (m *PrometheusMetrics) InstrumentCache(c cache.CacheService) cache.CacheService {
+	return &instrumentedCache{CacheService: c, requests: m.cacheRequests}
+}
+
+func (m *PrometheusMetrics) TopupVerified(paymentMethod string, amount float64) {
+	m.recordTopup("verified", paymentMethod, amount)
+}
+
+func (m *PrometheusMetrics) TopupCompleted(paymentMethod string, amount float64) {
+	m.recordTopup("completed", paymentMethod, amount)
+}
+
+func (m *PrometheusMetrics) TopupExpired(paymentMethod string, amount float64) {
+	m.recordTopup("expired", paymentMethod, amount)
+}
+
+func (m *PrometheusMetrics) TopupRejected(paymentMethod string, amount float64) {
+	m.recordTopup("rejected", paymentMethod, amount)
+}
+
+func (m *PrometheusMetrics) recordTopup(stage, paymentMethod string, amount float64) {
+	m.topups.WithLabelValues(stage, paymentMethod).Inc()
+	m.topupAmount.WithLabelValues(stage, paymentMethod).Add(amount)
+}
+
+type instrumentedCache struct {
+	cache.CacheService
+	requests *prometheus.CounterVec
+}
+
+func (c *instrumentedCache) Get(ctx context.Context, key string, dest interface{}) error {
+	err := c.CacheService.Get(ctx, key, dest)
+	switch {
+	case err == nil:
+		c.requests.WithLabelValues("hit").Inc()
+	case errors.Is(err, cache.ErrCacheMiss):
+		c.requests.WithLabelValues("miss").Inc()
+	default:
+		c.requests.WithLabelValues("error").Inc()
+	}
+	return err
+}
+
+// Unwrap returns the wrapped cache
+func (c *instrumentedCache) Unwrap() cache.CacheService {
+	return c.CacheService
+}
+
+// Health forwards to the wrapped cache so readiness checks still see it
+func (c *instrumentedCache) Health(ctx context.Context) error {
+	if checker, ok := c.CacheService.(cache.HealthChecker); ok {
+		return checker.Health(ctx)
+	}
+	return nil
+}
+
+const gormStartKey = "metrics:start"
+
+// gormMetricsPlugin times every statement between GORM's before and after callbacks
+type gormMetricsPlugin struct {
+	duration *prometheus.HistogramVec
+}
+
+func (p *gormMetricsPlugin) Name() string {
+	return "prometheus_metrics"
+}
+
+func (p *gormMetricsPlugin) Initialize(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(gormStartKey, time.Now())
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			v, ok := tx.InstanceGet(gormStartKey)
+			if !ok {
+				return
+			}
+			table := tx.Statement.Table
+			if table == "" {
+				table = "unknown"
+			}
+			p.duration.WithLabelValues(operation, table).Observe(time.Since(v.(time.Time)).Seconds())
+		}
+	}
+
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,38 +3,84 @@ package infrastructure
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
 )
 
 type RedisClient struct {
-	client *redis.Client
+	client  *redis.Client
+	breaker *CircuitBreaker
 }
 type CacheConfig struct {
-	Host     string
-	Port     int
-	Password string
-	Db       int
+	Host             string
+	Port             int
+	Password         string
+	Db               int
+	Timeout          int // in milliseconds, applied to dial, read and write
+	BreakerThreshold int // consecutive failures before Redis calls are skipped
+	BreakerCooldown  int // in seconds, before a probe call is let through
 }
 
-// NewRedisClient creates a new Redis client instance
-func NewRedisClient(cfg CacheConfig) *RedisClient {
+// NewRedisClient creates a new Redis client instance.
+// An unreachable Redis does not stop startup; calls fail fast with cache.ErrCacheUnavailable until it recovers.
+func NewRedisClient(cfg CacheConfig, logger logger.Logger) *RedisClient {
+	timeout := time.Duration(cfg.Timeout) * time.Millisecond
 	client := redis.NewClient(&redis.Options{
 		Addr:           fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 		Password:       cfg.Password,
 		DB:             cfg.Db,
 		MaxActiveConns: 0,
+		DialTimeout:    timeout,
+		ReadTimeout:    timeout,
+		WriteTimeout:   timeout,
 	})
-	fmt.Println("redis", fmt.Sprintf("%s:%d  %s", cfg.Host, cfg.Port, cfg.Password))
+	client.AddHook(redisTracingHook{tracer: otel.Tracer(tracerName)})
+	r := &RedisClient{
+		client:  client,
+		breaker: NewCircuitBreaker(cfg.BreakerThreshold, time.Duration(cfg.BreakerCooldown)*time.Second),
+	}
 	// Test connection
-	ctx := context.Background()
-	if err := client.Ping(ctx).Err(); err != nil {
-		panic(fmt.Errorf("failed to connect to Redis: %w", err))
+	if err := r.Health(context.Background()); err != nil {
+		logger.Warn("Redis unavailable, continuing without cache", map[string]interface{}{
+			"addr":  fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			"error": err.Error(),
+		})
 	}
 
-	return &RedisClient{client: client}
+	return r
+}
+
+// do runs fn through the circuit breaker. redis.Nil is a normal answer, not a failure.
+func (r *RedisClient) do(fn func() error) error {
+	if !r.breaker.Allow() {
+		return cache.ErrCacheUnavailable
+	}
+	err := fn()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.breaker.Failure(err)
+		return fmt.Errorf("%w: %v", cache.ErrCacheUnavailable, err)
+	}
+	r.breaker.Success()
+	return err
+}
+
+// Health pings Redis unless the circuit is open
+func (r *RedisClient) Health(ctx context.Context) error {
+	return r.do(func() error {
+		return r.client.Ping(ctx).Err()
+	})
+}
+
+// BreakerState returns the circuit breaker state and the last Redis error
+func (r *RedisClient) BreakerState() (string, error) {
+	return r.breaker.State()
 }
 
 // Set stores a value with expiration
@@ -44,17 +90,23 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	return r.client.Set(ctx, key, data, expiration).Err()
+	return r.do(func() error {
+		return r.client.Set(ctx, key, data, expiration).Err()
+	})
 }
 
 // Get retrieves a value by key
 func (r *RedisClient) Get(ctx context.Context, key string, dest interface{}) error {
-	data, err := r.client.Get(ctx, key).Bytes()
+	var data []byte
+	err := r.do(func() (err error) {
+		data, err = r.client.Get(ctx, key).Bytes()
+		return err
+	})
 	if err != nil {
-		if err == redis.Nil {
-			return fmt.Errorf("key does not exist: %s", key)
+		if errors.Is(err, redis.Nil) {
+			return cache.ErrCacheMiss
 		}
-		return fmt.Errorf("failed to get value: %w", err)
+		return err
 	}
 
 	return json.Unmarshal(data, dest)
@@ -62,7 +114,9 @@ func (r *RedisClient) Get(ctx context.Context, key string, dest interface{}) err
 
 // Delete removes a key
 func (r *RedisClient) Delete(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+	return r.do(func() error {
+		return r.client.Del(ctx, key).Err()
+	})
 }
 
 // HashSet stores a hash field
@@ -72,35 +126,51 @@ func (r *RedisClient) HashSet(ctx context.Context, key, field string, value inte
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	return r.client.HSet(ctx, key, field, data).Err()
+	return r.do(func() error {
+		return r.client.HSet(ctx, key, field, data).Err()
+	})
 }
 
 // HashGet retrieves a hash field
 func (r *RedisClient) HashGet(ctx context.Context, key, field string, dest interface{}) error {
-	data, err := r.client.HGet(ctx, key, field).Bytes()
+	var data []byte
+	err := r.do(func() (err error) {
+		data, err = r.client.HGet(ctx, key, field).Bytes()
+		return err
+	})
 	if err != nil {
-		if err == redis.Nil {
-			return nil
+		if errors.Is(err, redis.Nil) {
+			return cache.ErrCacheMiss
 		}
-		return fmt.Errorf("failed to get hash field: %w", err)
+		return err
 	}
 
 	return json.Unmarshal(data, dest)
 }
 
-// SetNX sets a value if the key doesn't exist (useful for distributed locks)
+// SetNX sets a value if the key doesn't exist. Use RedisLocker for distributed locks.
 func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return false, fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	return r.client.SetNX(ctx, key, data, expiration).Result()
+	var ok bool
+	err = r.do(func() (err error) {
+		ok, err = r.client.SetNX(ctx, key, data, expiration).Result()
+		return err
+	})
+	return ok, err
 }
 
 // Incr increments a key's value
 func (r *RedisClient) Incr(ctx context.Context, key string) (int64, error) {
-	return r.client.Incr(ctx, key).Result()
+	var n int64
+	err := r.do(func() (err error) {
+		n, err = r.client.Incr(ctx, key).Result()
+		return err
+	})
+	return n, err
 }
 
 // Close closes the Redis connection
@@ -0,0 +1,138 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	mrand "math/rand/v2"
+	"time"
+
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/lock"
+	"github.com/redis/go-redis/v9"
+)
+
+// LockerConfig holds lock acquisition retry settings
+type LockerConfig struct {
+	Prefix    string        // prepended to every lock key
+	RetryBase time.Duration // first retry delay
+	RetryMax  time.Duration // retry delay cap
+}
+
+// Only delete or extend the key if it still holds our token
+var (
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+	extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+)
+
+// RedisLocker implements lock.Locker with SET NX PX and a random owner token
+type RedisLocker struct {
+	redis *RedisClient
+	cfg   LockerConfig
+}
+
+// NewRedisLocker creates a new RedisLocker
+func NewRedisLocker(redis *RedisClient, cfg LockerConfig) *RedisLocker {
+	return &RedisLocker{redis: redis, cfg: cfg}
+}
+
+// Acquire retries with jittered exponential backoff until the lock is held or ctx is done
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (lock.Lock, error) {
+	return acquireWithBackoff(ctx, l.cfg, func() (lock.Lock, error) {
+		return l.TryAcquire(ctx, key, ttl)
+	})
+}
+
+// TryAcquire makes a single attempt to take the lock
+func (l *RedisLocker) TryAcquire(ctx context.Context, key string, ttl time.Duration) (lock.Lock, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+	fullKey := l.cfg.Prefix + key
+	var ok bool
+	err = l.redis.do(func() (err error) {
+		ok, err = l.redis.client.SetNX(ctx, fullKey, token, ttl).Result()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errs.ErrLockNotAcquired
+	}
+	return &redisLock{redis: l.redis, key: fullKey, token: token}, nil
+}
+
+type redisLock struct {
+	redis *RedisClient
+	key   string
+	token string
+}
+
+func (l *redisLock) Key() string {
+	return l.key
+}
+
+func (l *redisLock) Extend(ctx context.Context, ttl time.Duration) error {
+	var n int64
+	err := l.redis.do(func() (err error) {
+		n, err = extendScript.Run(ctx, l.redis.client, []string{l.key}, l.token, ttl.Milliseconds()).Int64()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.ErrLockLost
+	}
+	return nil
+}
+
+func (l *redisLock) Release(ctx context.Context) error {
+	return l.redis.do(func() error {
+		return releaseScript.Run(ctx, l.redis.client, []string{l.key}, l.token).Err()
+	})
+}
+
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// acquireWithBackoff calls try until it succeeds, fails with something other than
+// errs.ErrLockNotAcquired, or ctx is done
+func acquireWithBackoff(ctx context.Context, cfg LockerConfig, try func() (lock.Lock, error)) (lock.Lock, error) {
+	delay := cfg.RetryBase
+	for {
+		l, err := try()
+		if err != errs.ErrLockNotAcquired {
+			return l, err
+		}
+		// Jitter keeps competing replicas from retrying in lockstep
+		wait := delay
+		if wait > 0 {
+			wait = time.Duration(mrand.Int64N(int64(delay))) + delay/2
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errs.ErrLockNotAcquired
+		case <-time.After(wait):
+		}
+		delay *= 2
+		if delay > cfg.RetryMax {
+			delay = cfg.RetryMax
+		}
+	}
+}
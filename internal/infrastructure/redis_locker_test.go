@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisLocker(t *testing.T) (*RedisLocker, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	r := &RedisClient{client: client, breaker: NewCircuitBreaker(5, time.Second)}
+	return NewRedisLocker(r, LockerConfig{Prefix: "lock:", RetryBase: 5 * time.Millisecond, RetryMax: 20 * time.Millisecond}), mr
+}
+
+func TestRedisLockerTryAcquireHeldLock(t *testing.T) {
+	ctx := context.Background()
+	locker, mr := newTestRedisLocker(t)
+
+	held, err := locker.TryAcquire(ctx, "wallet:1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "lock:wallet:1", held.Key())
+	assert.True(t, mr.Exists("lock:wallet:1"))
+
+	_, err = locker.TryAcquire(ctx, "wallet:1", time.Minute)
+	assert.ErrorIs(t, err, errs.ErrLockNotAcquired)
+
+	other, err := locker.TryAcquire(ctx, "wallet:2", time.Minute)
+	require.NoError(t, err, "other keys are independent")
+	require.NoError(t, other.Release(ctx))
+
+	require.NoError(t, held.Release(ctx))
+	assert.False(t, mr.Exists("lock:wallet:1"))
+	again, err := locker.TryAcquire(ctx, "wallet:1", time.Minute)
+	require.NoError(t, err, "a released lock can be taken again")
+	require.NoError(t, again.Release(ctx))
+}
+
+func TestRedisLockerAcquireGivesUpWhenTheWaitEnds(t *testing.T) {
+	locker, _ := newTestRedisLocker(t)
+	held, err := locker.TryAcquire(context.Background(), "wallet:1", time.Minute)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = locker.Acquire(ctx, "wallet:1", time.Minute)
+	assert.ErrorIs(t, err, errs.ErrLockNotAcquired)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "Acquire retries until the wait ends")
+
+	require.NoError(t, held.Release(context.Background()))
+}
+
+func TestRedisLockerAcquireWaitsForRelease(t *testing.T) {
+	locker, _ := newTestRedisLocker(t)
+	held, err := locker.TryAcquire(context.Background(), "wallet:1", time.Minute)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = held.Release(context.Background())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	acquired, err := locker.Acquire(ctx, "wallet:1", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, acquired.Release(ctx))
+}
+
+func TestRedisLockReleaseOnlyDeletesItsOwnLock(t *testing.T) {
+	ctx := context.Background()
+	locker, mr := newTestRedisLocker(t)
+
+	expired, err := locker.TryAcquire(ctx, "wallet:1", time.Second)
+	require.NoError(t, err)
+	mr.FastForward(2 * time.Second)
+	assert.False(t, mr.Exists("lock:wallet:1"))
+
+	current, err := locker.TryAcquire(ctx, "wallet:1", time.Minute)
+	require.NoError(t, err)
+	owner, err := mr.Get("lock:wallet:1")
+	require.NoError(t, err)
+
+	require.NoError(t, expired.Release(ctx))
+	got, err := mr.Get("lock:wallet:1")
+	require.NoError(t, err, "releasing an expired lock leaves the new owner's lock in place")
+	assert.Equal(t, owner, got)
+	assert.ErrorIs(t, expired.Extend(ctx, time.Minute), errs.ErrLockLost)
+
+	require.NoError(t, current.Extend(ctx, 2*time.Minute))
+	assert.Equal(t, 2*time.Minute, mr.TTL("lock:wallet:1"))
+	require.NoError(t, current.Release(ctx))
+	assert.False(t, mr.Exists("lock:wallet:1"))
+}
@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamConfig holds Redis Streams publisher configuration
+type StreamConfig struct {
+	Key        string // stream all wallet events are appended to
+	MaxLen     int64  // trim the stream to roughly this many entries; 0 disables trimming
+	ApproxTrim bool   // use "MAXLEN ~" which is much cheaper than exact trimming
+}
+
+// RedisStreamPublisher implements event.EventPublisher with XADD.
+//
+// Every event is appended to a single stream so consumers see events for an aggregate in order.
+// Entries carry the event "id", "type" and "version" as separate fields plus the full JSON
+// envelope under "event". Subscribers are expected to use consumer groups, e.g.:
+//
+//	XGROUP CREATE wallet:events notifications $ MKSTREAM
+//	XREADGROUP GROUP notifications worker-1 COUNT 10 BLOCK 5000 STREAMS wallet:events >
+//	XACK wallet:events notifications <entry-id>
+//
+// Delivery is at-least-once, so consumers should deduplicate on the "id" field. XADD goes
+// through the client's circuit breaker, so while Redis is down events fail fast and stay in the
+// outbox to be retried.
+type RedisStreamPublisher struct {
+	redis *RedisClient
+	cfg   StreamConfig
+}
+
+// NewRedisStreamPublisher creates a publisher sharing the connection and circuit breaker of an
+// existing RedisClient
+func NewRedisStreamPublisher(r *RedisClient, cfg StreamConfig) *RedisStreamPublisher {
+	return &RedisStreamPublisher{redis: r, cfg: cfg}
+}
+
+func (p *RedisStreamPublisher) Publish(ctx context.Context, e event.Event) error {
+	envelope, err := json.Marshal(event.NewEnvelope(e))
+	if err != nil {
+		return fmt.Errorf("failed to marshal event envelope: %w", err)
+	}
+
+	args := &redis.XAddArgs{
+		Stream: p.cfg.Key,
+		Values: map[string]interface{}{
+			"id":      strconv.FormatUint(uint64(e.ID), 10),
+			"type":    e.Type.String(),
+			"version": event.SchemaVersion,
+			"event":   envelope,
+		},
+	}
+	if p.cfg.MaxLen > 0 {
+		args.MaxLen = p.cfg.MaxLen
+		args.Approx = p.cfg.ApproxTrim
+	}
+
+	err = p.redis.do(func() error {
+		return p.redis.client.XAdd(ctx, args).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish event to stream %s: %w", p.cfg.Key, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStreamPublisher(t *testing.T, breakerThreshold int) (*RedisStreamPublisher, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	r := &RedisClient{client: client, breaker: NewCircuitBreaker(breakerThreshold, time.Minute)}
+	return NewRedisStreamPublisher(r, StreamConfig{Key: "wallet:events"}), mr
+}
+
+func TestRedisStreamPublisherAppendsEvents(t *testing.T) {
+	publisher, mr := newTestStreamPublisher(t, 5)
+
+	require.NoError(t, publisher.Publish(context.Background(), event.Event{
+		ID:          42,
+		Type:        event.TypeTopupCompleted,
+		AggregateID: "7",
+		Payload:     []byte(`{"amount":100}`),
+		OccurredAt:  time.Now(),
+	}))
+
+	entries, err := mr.Stream("wallet:events")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	values := map[string]string{}
+	for i := 0; i+1 < len(entries[0].Values); i += 2 {
+		values[entries[0].Values[i]] = entries[0].Values[i+1]
+	}
+	assert.Equal(t, "42", values["id"])
+	assert.Equal(t, "topup.completed", values["type"])
+	assert.Contains(t, values["event"], `"amount":100`)
+}
+
+func TestRedisStreamPublisherFailsFastWhileTheBreakerIsOpen(t *testing.T) {
+	ctx := context.Background()
+	publisher, mr := newTestStreamPublisher(t, 1)
+	e := event.Event{ID: 1, Type: event.TypeTopupCompleted, OccurredAt: time.Now()}
+
+	mr.SetError("LOADING Redis is loading the dataset in memory")
+	err := publisher.Publish(ctx, e)
+	assert.ErrorIs(t, err, cache.ErrCacheUnavailable)
+	state, _ := publisher.redis.breaker.State()
+	assert.Equal(t, "open", state, "a failed XADD counts against the breaker")
+
+	mr.SetError("")
+	err = publisher.Publish(ctx, e)
+	assert.ErrorIs(t, err, cache.ErrCacheUnavailable, "XADD is not sent while the breaker is open")
+	entries, err := mr.Stream("wallet:events")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
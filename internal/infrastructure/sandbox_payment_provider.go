@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/payment"
+)
+
+// sandboxDeclinePrefix marks test tokens that the sandbox provider always declines
+const sandboxDeclinePrefix = "tok_decline"
+
+// SandboxPaymentProvider implements payment.Provider without moving money. It approves every
+// charge except those made with tokens starting with "tok_decline", which lets auto top-up
+// failures be exercised end to end.
+type SandboxPaymentProvider struct {
+	mu       sync.Mutex
+	receipts map[string]payment.Receipt // by charge reference, so a retried charge is not collected twice
+}
+
+func NewSandboxPaymentProvider() *SandboxPaymentProvider {
+	return &SandboxPaymentProvider{receipts: make(map[string]payment.Receipt)}
+}
+
+func (p *SandboxPaymentProvider) Charge(ctx context.Context, charge payment.Charge) (payment.Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return payment.Receipt{}, err
+	}
+	if strings.HasPrefix(charge.Token, sandboxDeclinePrefix) {
+		return payment.Receipt{}, fmt.Errorf("%w: sandbox token %s", payment.ErrDeclined, charge.Token)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if receipt, ok := p.receipts[charge.Reference]; ok {
+		return receipt, nil
+	}
+	receipt := payment.Receipt{ProviderReference: "sandbox_" + charge.Reference}
+	p.receipts[charge.Reference] = receipt
+	return receipt, nil
+}
@@ -0,0 +1,214 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/model"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// loadFixtureUsers is how many users the "load" fixture set creates
+const loadFixtureUsers = 1000
+
+var fixtureSets = map[string]func(db *gorm.DB) error{
+	"demo": seedDemo,
+	"load": seedLoad,
+}
+
+// FixtureSets returns the names of the fixture sets SeedFixtures can load
+func FixtureSets() []string {
+	names := make([]string, 0, len(fixtureSets))
+	for name := range fixtureSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SeedFixtures loads the named fixture set. Each set skips itself if its users already exist.
+func SeedFixtures(db *gorm.DB, set string) error {
+	seed, ok := fixtureSets[set]
+	if !ok {
+		return fmt.Errorf("unknown fixture set %q (available: %s)", set, strings.Join(FixtureSets(), ", "))
+	}
+	log.Printf("Seeding fixture set %q...", set)
+	if err := seed(db); err != nil {
+		return err
+	}
+	log.Println("Database seeding completed successfully")
+	return nil
+}
+
+// seedDemo creates a handful of demo users, some with completed and pending top-ups
+func seedDemo(db *gorm.DB) error {
+	if exists, err := userExists(db, "tanakarn@example.com"); err != nil || exists {
+		if exists {
+			log.Println("Demo users already exist, skipping")
+		}
+		return err
+	}
+
+	// Hash password - in production, use a stronger password
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+
+	// Create test users
+	users := []model.User{
+		{
+			FirstName: "ธนาคาร",
+			LastName:  "รุ่งเรือง",
+			Email:     "tanakarn@example.com",
+			Password:  string(hashedPassword),
+			Phone:     "0812345678",
+		},
+		{
+			FirstName: "สมหญิง",
+			LastName:  "ใจดี",
+			Email:     "somying@example.com",
+			Password:  string(hashedPassword),
+			Phone:     "0823456789",
+		},
+		{
+			FirstName: "มานะ",
+			LastName:  "มานี",
+			Email:     "mana@example.com",
+			Password:  string(hashedPassword),
+			Phone:     "0834567890",
+		},
+		{
+			FirstName: "ปิยะ",
+			LastName:  "แสงทอง",
+			Email:     "piya@example.com",
+			Password:  string(hashedPassword),
+			Phone:     "0845678901",
+		},
+		{
+			FirstName: "วิชัย",
+			LastName:  "เจริญ",
+			Email:     "wichai@example.com",
+			Password:  string(hashedPassword),
+			Phone:     "0856789012",
+		},
+	}
+
+	// Create users and their wallets in a transaction
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, user := range users {
+			if err := createUserWithWallet(tx, &user); err != nil {
+				return err
+			}
+
+			// Add a completed top-up for every second user
+			if i%2 == 1 {
+				if err := createCompletedTopup(tx, user.ID, 500.00); err != nil {
+					return err
+				}
+			}
+
+			// Add a pending transaction for the first user
+			if i == 0 {
+				pendingTx := model.Transaction{
+					UserID:        user.ID,
+					Amount:        1000.00,
+					PaymentMethod: "credit_card",
+					Status:        "verified", // Pending confirmation
+					ExpiresAt:     time.Now().Add(24 * time.Hour),
+				}
+				if err := tx.Create(&pendingTx).Error; err != nil {
+					log.Printf("Error creating pending transaction for user %s: %v", user.Email, err)
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// seedLoad creates many users, each with one completed top-up, for load and reconciliation tests
+func seedLoad(db *gorm.DB) error {
+	if exists, err := userExists(db, loadFixtureEmail(1)); err != nil || exists {
+		if exists {
+			log.Println("Load test users already exist, skipping")
+		}
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := 1; i <= loadFixtureUsers; i++ {
+			user := model.User{
+				FirstName: "Load",
+				LastName:  fmt.Sprintf("User %d", i),
+				Email:     loadFixtureEmail(i),
+				Password:  string(hashedPassword),
+				Phone:     fmt.Sprintf("09%08d", i),
+			}
+			if err := createUserWithWallet(tx, &user); err != nil {
+				return err
+			}
+			if err := createCompletedTopup(tx, user.ID, float64(100*(i%10+1))); err != nil {
+				return err
+			}
+		}
+		log.Printf("Created %d load test users", loadFixtureUsers)
+		return nil
+	})
+}
+
+func loadFixtureEmail(i int) string {
+	return fmt.Sprintf("loadtest-%04d@example.com", i)
+}
+
+func userExists(db *gorm.DB, email string) (bool, error) {
+	var count int64
+	if err := db.Model(&model.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// createUserWithWallet creates a user and a zero-balance wallet sharing its ID
+func createUserWithWallet(tx *gorm.DB, user *model.User) error {
+	if err := tx.Create(user).Error; err != nil {
+		log.Printf("Error seeding user: %v", err)
+		return err
+	}
+	wallet := model.Wallet{Model: gorm.Model{ID: user.ID}}
+	if err := tx.Create(&wallet).Error; err != nil {
+		log.Printf("Error creating wallet for user %s: %v", user.Email, err)
+		return err
+	}
+	return nil
+}
+
+// createCompletedTopup records a completed top-up and credits the wallet, keeping the ledger balanced
+func createCompletedTopup(tx *gorm.DB, userID uint, amount float64) error {
+	completedTx := model.Transaction{
+		UserID:        userID,
+		Amount:        amount,
+		PaymentMethod: "credit_card",
+		Status:        "completed",
+		ExpiresAt:     time.Now().Add(24 * time.Hour),
+	}
+	if err := tx.Create(&completedTx).Error; err != nil {
+		log.Printf("Error creating transaction for user %d: %v", userID, err)
+		return err
+	}
+	if err := tx.Model(&model.Wallet{}).Where("id = ?", userID).
+		Update("balance", gorm.Expr("balance + ?", amount)).Error; err != nil {
+		log.Printf("Error updating wallet balance for user %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+)
+
+// CSVStatementRenderer writes a statement as CSV: a header row, an opening_balance row, one row
+// per entry and a closing_balance row. An incomplete statement ends with an error row instead of
+// the closing_balance row. Rows are flushed as the buffer fills, so memory use does
+// not grow with the number of entries.
+type CSVStatementRenderer struct {
+	w *csv.Writer
+}
+
+// NewCSVStatementRenderer creates a renderer writing CSV to w
+func NewCSVStatementRenderer(w io.Writer) statement.Renderer {
+	return &CSVStatementRenderer{w: csv.NewWriter(w)}
+}
+
+func (r *CSVStatementRenderer) Begin(s statement.Statement) error {
+	_ = r.w.Write([]string{"date", "type", "reference_id", "description", "amount", "fee", "bonus", "net", "balance"})
+	_ = r.w.Write([]string{s.From.Format(time.RFC3339), "opening_balance", "", "", "", "", "", "", formatAmount(s.OpeningBalance)})
+	return r.w.Error()
+}
+
+func (r *CSVStatementRenderer) Entry(e statement.Entry) error {
+	_ = r.w.Write([]string{
+		e.PostedAt.UTC().Format(time.RFC3339),
+		e.Type.String(),
+		strconv.FormatUint(uint64(e.ReferenceID), 10),
+		e.Description,
+		formatAmount(e.Amount),
+		formatAmount(e.Fee),
+		formatAmount(e.Bonus),
+		formatAmount(e.Net),
+		formatAmount(e.Balance),
+	})
+	return r.w.Error()
+}
+
+func (r *CSVStatementRenderer) End(s statement.Statement) error {
+	_ = r.w.Write([]string{s.Until().Format(time.RFC3339), "closing_balance", "", "", "", "", "", "", formatAmount(s.ClosingBalance)})
+	r.w.Flush()
+	return r.w.Error()
+}
+
+func (r *CSVStatementRenderer) Abort(s statement.Statement) error {
+	_ = r.w.Write([]string{s.Until().Format(time.RFC3339), "error", "", "statement incomplete", "", "", "", "", ""})
+	r.w.Flush()
+	return r.w.Error()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
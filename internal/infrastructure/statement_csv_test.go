@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVStatementRenderer(t *testing.T) {
+	s := statement.Statement{
+		WalletID:       1,
+		From:           time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC),
+		Format:         statement.FormatCSV,
+		OpeningBalance: 100,
+	}
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	entries := []statement.Entry{
+		{Type: statement.EntryTopup, ReferenceID: 7, Description: "credit_card", Amount: 50, Fee: 1.5, Bonus: 5, Net: 53.5, Balance: 153.5,
+			PostedAt: time.Date(2026, 9, 2, 10, 0, 0, 0, bangkok)},
+		{Type: statement.EntryAdjustment, ReferenceID: 2, Description: `refund, "duplicate" charge`, Amount: -20, Net: -20, Balance: 133.5,
+			PostedAt: time.Date(2026, 9, 15, 8, 30, 0, 0, time.UTC)},
+	}
+
+	var buf bytes.Buffer
+	r := NewCSVStatementRenderer(&buf)
+	require.NoError(t, r.Begin(s))
+	for _, e := range entries {
+		require.NoError(t, r.Entry(e))
+	}
+	s.Entries = len(entries)
+	s.ClosingBalance = 133.5
+	require.NoError(t, r.End(s))
+
+	assert.Equal(t, "date,type,reference_id,description,amount,fee,bonus,net,balance\n"+
+		"2026-09-01T00:00:00Z,opening_balance,,,,,,,100.00\n"+
+		"2026-09-02T03:00:00Z,topup,7,credit_card,50.00,1.50,5.00,53.50,153.50\n"+
+		"2026-09-15T08:30:00Z,adjustment,2,\"refund, \"\"duplicate\"\" charge\",-20.00,0.00,0.00,-20.00,133.50\n"+
+		"2026-10-01T00:00:00Z,closing_balance,,,,,,,133.50\n",
+		buf.String())
+}
+
+func TestCSVStatementRendererAbort(t *testing.T) {
+	s := statement.Statement{
+		WalletID:       1,
+		From:           time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC),
+		Format:         statement.FormatCSV,
+		OpeningBalance: 100,
+	}
+
+	var buf bytes.Buffer
+	r := NewCSVStatementRenderer(&buf)
+	require.NoError(t, r.Begin(s))
+	require.NoError(t, r.Entry(statement.Entry{Type: statement.EntryTopup, ReferenceID: 7, Description: "credit_card", Amount: 50, Net: 50, Balance: 150,
+		PostedAt: time.Date(2026, 9, 2, 3, 0, 0, 0, time.UTC)}))
+	s.Entries = 1
+	require.NoError(t, r.Abort(s))
+
+	assert.Equal(t, "date,type,reference_id,description,amount,fee,bonus,net,balance\n"+
+		"2026-09-01T00:00:00Z,opening_balance,,,,,,,100.00\n"+
+		"2026-09-02T03:00:00Z,topup,7,credit_card,50.00,0.00,0.00,50.00,150.00\n"+
+		"2026-10-01T00:00:00Z,error,,statement incomplete,,,,,\n",
+		buf.String())
+}
@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"codeberg.org/go-pdf/fpdf"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+)
+
+// statementColumns are the table columns of a PDF statement, with widths in mm summing to the
+// printable width of landscape A4
+var statementColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date (UTC)", 34, "L"},
+	{"Type", 24, "L"},
+	{"Reference", 20, "R"},
+	{"Description", 79, "L"},
+	{"Amount", 24, "R"},
+	{"Fee", 22, "R"},
+	{"Bonus", 22, "R"},
+	{"Net", 24, "R"},
+	{"Balance", 28, "R"},
+}
+
+const statementRowHeight = 6
+
+// PDFStatementRenderer writes a statement as a paginated PDF table. The whole document is
+// assembled in memory and written to w by End, so the statement usecase limits the period a PDF
+// statement may cover.
+type PDFStatementRenderer struct {
+	w   io.Writer
+	pdf *fpdf.Fpdf
+	// tr converts UTF-8 text to the code page of the built-in fonts
+	tr func(string) string
+}
+
+// NewPDFStatementRenderer creates a renderer writing a PDF to w
+func NewPDFStatementRenderer(w io.Writer) statement.Renderer {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	return &PDFStatementRenderer{w: w, pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+}
+
+func (r *PDFStatementRenderer) Begin(s statement.Statement) error {
+	pdf := r.pdf
+	pdf.SetTitle(fmt.Sprintf("Wallet %d statement", s.WalletID), true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() == 1 {
+			pdf.SetFont("Helvetica", "B", 16)
+			pdf.CellFormat(0, 10, "Wallet Statement", "", 1, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 10)
+			pdf.CellFormat(0, 5, fmt.Sprintf("Wallet: %d", s.WalletID), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("Period: %s to %s", s.From.Format(time.DateOnly), s.To.Format(time.DateOnly)), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, "Generated: "+s.GeneratedAt.UTC().Format(time.RFC3339), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, "Opening balance: "+formatAmount(s.OpeningBalance), "", 1, "L", false, 0, "")
+			pdf.Ln(4)
+		}
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, c := range statementColumns {
+			pdf.CellFormat(c.width, statementRowHeight+1, c.title, "1", 0, c.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	return pdf.Error()
+}
+
+func (r *PDFStatementRenderer) Entry(e statement.Entry) error {
+	values := []string{
+		e.PostedAt.UTC().Format("2006-01-02 15:04:05"),
+		e.Type.String(),
+		fmt.Sprint(e.ReferenceID),
+		r.tr(e.Description),
+		formatAmount(e.Amount),
+		formatAmount(e.Fee),
+		formatAmount(e.Bonus),
+		formatAmount(e.Net),
+		formatAmount(e.Balance),
+	}
+	for i, c := range statementColumns {
+		r.pdf.CellFormat(c.width, statementRowHeight, r.fit(values[i], c.width), "1", 0, c.align, false, 0, "")
+	}
+	r.pdf.Ln(-1)
+	return r.pdf.Error()
+}
+
+func (r *PDFStatementRenderer) End(s statement.Statement) error {
+	pdf := r.pdf
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Entries: %d", s.Entries), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, "Closing balance: "+formatAmount(s.ClosingBalance), "", 1, "L", false, 0, "")
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(r.w)
+}
+
+// Abort writes the entries rendered so far under a notice that the statement is incomplete, in
+// place of the closing balance
+func (r *PDFStatementRenderer) Abort(s statement.Statement) error {
+	pdf := r.pdf
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetTextColor(200, 0, 0)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Statement incomplete: an error occurred after %d entries", s.Entries), "", 1, "L", false, 0, "")
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(r.w)
+}
+
+// fit shortens text with an ellipsis so it stays inside a cell of the given width
+func (r *PDFStatementRenderer) fit(text string, width float64) string {
+	const padding = 2
+	if r.pdf.GetStringWidth(text) <= width-padding {
+		return text
+	}
+	for len(text) > 0 && r.pdf.GetStringWidth(text+"...") > width-padding {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
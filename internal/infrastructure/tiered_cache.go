@@ -0,0 +1,156 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+)
+
+// TieredCacheConfig holds two-tier cache configuration
+type TieredCacheConfig struct {
+	LocalTTL            time.Duration // upper bound on how long a value lives in the local tier
+	InvalidationChannel string        // Redis pub/sub channel shared by all replicas
+}
+
+type invalidationMessage struct {
+	Key    string `json:"key"`
+	Origin string `json:"origin"`
+}
+
+// TieredCache implements cache.CacheService with an in-process tier in front of Redis.
+// Writes and deletes are broadcast over Redis pub/sub so other replicas drop their local copy.
+type TieredCache struct {
+	local      *MemoryCache
+	remote     *RedisClient
+	cfg        TieredCacheConfig
+	instanceID string
+	logger     logger.Logger
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewTieredCache creates a TieredCache and subscribes to invalidation messages
+func NewTieredCache(local *MemoryCache, remote *RedisClient, cfg TieredCacheConfig, logger logger.Logger) *TieredCache {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &TieredCache{
+		local:      local,
+		remote:     remote,
+		cfg:        cfg,
+		instanceID: hex.EncodeToString(id),
+		logger:     logger,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go c.listen(ctx)
+	return c
+}
+
+// Set writes through to Redis, then caches locally and tells other replicas to drop the key
+func (c *TieredCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := c.remote.Set(ctx, key, value, expiration); err != nil {
+		return err
+	}
+	_ = c.local.Set(ctx, key, value, c.localTTL(expiration))
+	c.publishInvalidation(ctx, key)
+	return nil
+}
+
+// Get reads the local tier first and falls back to Redis, filling the local tier on a hit
+func (c *TieredCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if err := c.local.Get(ctx, key, dest); err == nil {
+		return nil
+	}
+	var raw json.RawMessage
+	if err := c.remote.Get(ctx, key, &raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, dest); err != nil {
+		return err
+	}
+	// Never keep the local copy longer than Redis would
+	var ttl time.Duration
+	err := c.remote.do(func() (err error) {
+		ttl, err = c.remote.client.PTTL(ctx, key).Result()
+		return err
+	})
+	if err != nil || ttl < 0 {
+		ttl = 0
+	}
+	_ = c.local.Set(ctx, key, raw, c.localTTL(ttl))
+	return nil
+}
+
+// Delete removes the key from both tiers and every other replica's local tier
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	_ = c.local.Delete(ctx, key)
+	err := c.remote.Delete(ctx, key)
+	// Other replicas must drop their copy even when Redis still holds the key
+	c.publishInvalidation(ctx, key)
+	return err
+}
+
+// Health reports the health of the Redis tier
+func (c *TieredCache) Health(ctx context.Context) error {
+	return c.remote.Health(ctx)
+}
+
+// BreakerState reports the circuit breaker state of the Redis tier
+func (c *TieredCache) BreakerState() (string, error) {
+	return c.remote.BreakerState()
+}
+
+// Close stops listening for invalidations and closes the local tier
+func (c *TieredCache) Close() error {
+	c.cancel()
+	<-c.done
+	return c.local.Close()
+}
+
+func (c *TieredCache) localTTL(expiration time.Duration) time.Duration {
+	if expiration <= 0 || expiration > c.cfg.LocalTTL {
+		return c.cfg.LocalTTL
+	}
+	return expiration
+}
+
+func (c *TieredCache) publishInvalidation(ctx context.Context, key string) {
+	msg, _ := json.Marshal(invalidationMessage{Key: key, Origin: c.instanceID})
+	err := c.remote.do(func() error {
+		return c.remote.client.Publish(ctx, c.cfg.InvalidationChannel, msg).Err()
+	})
+	if err != nil {
+		c.logger.Warn("Failed to publish cache invalidation", map[string]interface{}{"key": key, "error": err.Error()})
+	}
+}
+
+func (c *TieredCache) listen(ctx context.Context) {
+	defer close(c.done)
+	pubsub := c.remote.client.Subscribe(ctx, c.cfg.InvalidationChannel)
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			var msg invalidationMessage
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				c.logger.Warn("Ignoring malformed cache invalidation", map[string]interface{}{"payload": m.Payload})
+				continue
+			}
+			if msg.Origin != c.instanceID {
+				_ = c.local.Delete(ctx, msg.Key)
+			}
+		}
+	}
+}
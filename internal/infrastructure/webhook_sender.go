@@ -0,0 +1,53 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+)
+
+// maxWebhookResponseBody limits how much of a receiver's response is kept in the delivery log
+const maxWebhookResponseBody = 4 << 10
+
+// HTTPWebhookSender implements webhook.Sender with net/http
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender(timeout time.Duration) *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Receivers must answer directly; following redirects could leak signed payloads
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, req webhook.Request) (webhook.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return webhook.Response{}, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "topup-wallet-webhooks/1.0")
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return webhook.Response{}, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	return webhook.Response{StatusCode: resp.StatusCode, Body: string(body)}, nil
+}
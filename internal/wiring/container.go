@@ -0,0 +1,332 @@
+// Package wiring assembles the application graph from configuration. Commands build a Base for
+// configuration, logging and the database, and a Container on top of it when they need use cases
+// or workers. Overrides swap parts of the graph for fakes in tests.
+package wiring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/repository/postgresql/repository"
+	usecase "github.com/hydr0g3nz/wallet_topup_system/internal/application"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/health"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"gorm.io/gorm"
+)
+
+// Base holds what every command needs: configuration, logging and the database
+type Base struct {
+	Config *config.Config
+	Logger Logger
+	DB     *gorm.DB // nil when every repository is overridden
+
+	overrides Overrides
+	closers   []namedCloser
+}
+
+type namedCloser struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// NewBase creates the logger and connects to the database, unless overrides provide them
+func NewBase(cfg *config.Config, overrides Overrides) (*Base, error) {
+	b := &Base{Config: cfg, Logger: overrides.Logger, DB: overrides.DB, overrides: overrides}
+	if b.Logger == nil {
+		log, err := infrastructure.NewLogger(cfg.Log, cfg.IsProduction())
+		if err != nil {
+			return nil, fmt.Errorf("initialize logger: %w", err)
+		}
+		b.Logger = log
+		// Closers run in reverse, so the logger is flushed and closed last
+		b.AddCloser("logger", func(context.Context) error { return log.Close() })
+	}
+	b.Logger.Info("Configuration loaded", map[string]interface{}{
+		"environment": cfg.Server.Environment,
+		"config":      cfg.Redacted(),
+	})
+
+	if b.DB == nil && !overrides.Repositories.complete() {
+		db, err := infrastructure.ConnectDB(&cfg.Database)
+		if err != nil {
+			_ = b.Close(context.Background())
+			return nil, fmt.Errorf("connect to database: %w", err)
+		}
+		b.DB = db
+		b.AddCloser("database", func(context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		})
+	}
+	return b, nil
+}
+
+// AddCloser registers a resource to release when the command finishes
+func (b *Base) AddCloser(name string, close func(ctx context.Context) error) {
+	b.closers = append(b.closers, namedCloser{name: name, close: close})
+}
+
+// Close releases resources in reverse registration order. Long-running commands hand
+// their closers to a Lifecycle instead.
+func (b *Base) Close(ctx context.Context) error {
+	var errs []error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if err := b.closers[i].close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", b.closers[i].name, err))
+		}
+	}
+	b.closers = nil
+	return errors.Join(errs...)
+}
+
+// NewLifecycle creates a Lifecycle that closes the base resources once workers have stopped
+func (b *Base) NewLifecycle(drainDelay time.Duration) *infrastructure.Lifecycle {
+	lifecycle := infrastructure.NewLifecycle(b.Logger, infrastructure.LifecycleConfig{
+		ShutdownTimeout: time.Duration(b.Config.Server.ShutdownTimeout) * time.Second,
+		DrainDelay:      drainDelay,
+	})
+	for _, c := range b.closers {
+		lifecycle.AddCloser(c.name, c.close)
+	}
+	b.closers = nil
+	return lifecycle
+}
+
+// Usecases holds the application use cases
+type Usecases struct {
+	Wallet         usecase.WalletUsecase
+	KYC            usecase.KYCUsecase
+	RiskReview     usecase.RiskReviewUsecase
+	Outbox         usecase.OutboxUsecase
+	Webhook        usecase.WebhookUsecase
+	Admin          usecase.AdminUsecase
+	Settings       usecase.SettingsUsecase
+	Voucher        usecase.VoucherUsecase
+	AutoTopup      usecase.AutoTopupUsecase
+	Instrument     usecase.InstrumentUsecase
+	Reconciliation usecase.ReconciliationUsecase
+	Statement      usecase.StatementUsecase
+	Health         usecase.HealthUsecase
+}
+
+// Workers holds the background workers; they only run when a command starts them
+type Workers struct {
+	OutboxRelay       *usecase.OutboxRelay
+	WebhookDispatcher *usecase.WebhookDispatcher
+	ExpirySweeper     *usecase.ExpirySweeper
+	AutoTopup         *usecase.AutoTopupScheduler
+}
+
+// Container is the full application graph
+type Container struct {
+	*Base
+	Migrator     *infrastructure.Migrator // nil without a database
+	Metrics      *infrastructure.PrometheusMetrics
+	Cache        cache.CacheService
+	Repositories Repositories
+	Usecases     Usecases
+	Workers      Workers
+}
+
+// New builds the full dependency graph. On error the base resources are left for the caller to close.
+func New(b *Base) (*Container, error) {
+	cfg := b.Config
+	o := b.overrides
+	tracerProvider, err := infrastructure.NewTracerProvider(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("initialize tracing: %w", err)
+	}
+	b.AddCloser("tracer", tracerProvider.Shutdown)
+
+	c := &Container{Base: b, Metrics: infrastructure.NewPrometheusMetrics()}
+	if b.DB != nil {
+		// Schema changes are applied separately with `migrate up`; readiness fails while any are pending
+		if c.Migrator, err = infrastructure.NewMigrator(b.DB, b.Logger); err != nil {
+			return nil, fmt.Errorf("load database migrations: %w", err)
+		}
+		if err := infrastructure.TraceDB(b.DB); err != nil {
+			return nil, fmt.Errorf("register database tracing: %w", err)
+		}
+		if err := c.Metrics.RegisterDB(b.DB); err != nil {
+			return nil, fmt.Errorf("register database metrics: %w", err)
+		}
+	}
+
+	// Redis is only required when a component below is configured to use it
+	var redisClient *infrastructure.RedisClient
+	if (o.Cache == nil && cfg.CacheBackend.Backend != "memory") || (o.EventPublisher == nil && cfg.Events.Publisher == "redis") {
+		redisClient = infrastructure.NewRedisClient(cfg.Cache, b.Logger)
+		b.AddCloser("redis", func(context.Context) error { return redisClient.Close() })
+	}
+	cacheService := o.Cache
+	if cacheService == nil {
+		var closeCache func() error
+		cacheService, closeCache = newCacheService(cfg.CacheBackend, redisClient, b.Logger)
+		b.AddCloser("cache", func(context.Context) error { return closeCache() })
+	}
+	c.Cache = c.Metrics.InstrumentCache(cacheService)
+	locker := o.Locker
+	if locker == nil {
+		locker = newLocker(cfg.Lock, redisClient)
+	}
+	blobStorage := o.Storage
+	if blobStorage == nil {
+		if blobStorage, err = infrastructure.NewLocalStorage(cfg.Storage); err != nil {
+			return nil, fmt.Errorf("initialize storage: %w", err)
+		}
+	}
+
+	repos, err := newRepositories(b.DB, cfg.Payment, o.Repositories)
+	if err != nil {
+		return nil, err
+	}
+	c.Repositories = repos
+
+	riskEngine := usecase.NewRiskEngine(cfg.Risk, repos.Transaction)
+	settingsUsecase := usecase.NewSettingsUsecase(repos.Setting, repos.TxManager, b.Logger, usecase.DefaultSettings(cfg.App, cfg.KYC, cfg.Pricing), cfg.Settings)
+	healthChecks := []health.Check{infrastructure.CacheHealthCheck(c.Cache)}
+	if b.DB != nil {
+		healthChecks = append([]health.Check{
+			infrastructure.PostgresHealthCheck(b.DB),
+			infrastructure.DBPoolHealthCheck(b.DB, cfg.Health.MaxPoolSaturation),
+			infrastructure.MigrationHealthCheck(c.Migrator),
+		}, healthChecks...)
+	}
+	c.Usecases = Usecases{
+		Wallet:         usecase.NewWalletUsecase(repos.User, repos.Transaction, repos.Wallet, repos.Instrument, c.Cache, repos.TxManager, b.Logger, cfg.Lock, settingsUsecase, riskEngine, repos.Outbox, locker, c.Metrics),
+		KYC:            usecase.NewKYCUsecase(repos.User, repos.KYC, blobStorage, repos.TxManager, b.Logger),
+		RiskReview:     usecase.NewRiskReviewUsecase(repos.Transaction, c.Cache, b.Logger),
+		Outbox:         usecase.NewOutboxUsecase(repos.Outbox, b.Logger),
+		Webhook:        usecase.NewWebhookUsecase(repos.WebhookSubscription, repos.WebhookDelivery, b.Logger),
+		Admin:          usecase.NewAdminUsecase(repos.Wallet, repos.Transaction, repos.Outbox, c.Cache, repos.TxManager, b.Logger, c.Metrics),
+		Settings:       settingsUsecase,
+		Voucher:        usecase.NewVoucherUsecase(repos.Voucher, repos.User, repos.Transaction, repos.Wallet, repos.Outbox, repos.TxManager, b.Logger, c.Metrics),
+		AutoTopup:      usecase.NewAutoTopupUsecase(repos.AutoTopup, repos.User, repos.Wallet, repos.Instrument, b.Logger),
+		Instrument:     usecase.NewInstrumentUsecase(repos.Instrument, repos.User, repos.AutoTopup, b.Logger),
+		Reconciliation: usecase.NewReconciliationUsecase(repos.Reconciliation, repos.Transaction, repos.TxManager, b.Logger, cfg.Payment.Provider),
+		Statement: usecase.NewStatementUsecase(repos.Statement, repos.User, map[statement.Format]statement.RendererFactory{
+			statement.FormatCSV: infrastructure.NewCSVStatementRenderer,
+			statement.FormatPDF: infrastructure.NewPDFStatementRenderer,
+		}, b.Logger),
+		Health: usecase.NewHealthUsecase(time.Duration(cfg.Health.CheckTimeout)*time.Millisecond, healthChecks...),
+	}
+
+	eventPublisher := o.EventPublisher
+	if eventPublisher == nil {
+		eventPublisher = infrastructure.NewLogEventPublisher(b.Logger)
+		if cfg.Events.Publisher == "redis" {
+			eventPublisher = infrastructure.NewRedisStreamPublisher(redisClient, cfg.Events.Stream)
+		}
+	}
+	webhookSender := o.WebhookSender
+	if webhookSender == nil {
+		webhookSender = infrastructure.NewHTTPWebhookSender(time.Duration(cfg.Webhook.Timeout) * time.Second)
+	}
+	webhookPublisher := usecase.NewWebhookEventPublisher(repos.WebhookSubscription, repos.WebhookDelivery)
+	autoTopupTrigger := usecase.NewAutoTopupTrigger(repos.AutoTopup, repos.Wallet, b.Logger, cfg.AutoTopup)
+	paymentProvider := o.PaymentProvider
+	if paymentProvider == nil {
+		paymentProvider = infrastructure.NewSandboxPaymentProvider()
+	}
+	c.Workers = Workers{
+		OutboxRelay:       usecase.NewOutboxRelay(repos.Outbox, event.MultiPublisher{eventPublisher, webhookPublisher, autoTopupTrigger}, repos.TxManager, b.Logger, cfg.Outbox),
+		WebhookDispatcher: usecase.NewWebhookDispatcher(repos.WebhookSubscription, repos.WebhookDelivery, webhookSender, b.Logger, cfg.Webhook),
+		ExpirySweeper:     usecase.NewExpirySweeper(repos.Transaction, repos.Outbox, c.Cache, repos.TxManager, b.Logger, c.Metrics, cfg.Expiry),
+		AutoTopup:         usecase.NewAutoTopupScheduler(repos.AutoTopup, repos.Instrument, repos.Transaction, repos.Outbox, c.Usecases.Wallet, paymentProvider, repos.TxManager, b.Logger, cfg.AutoTopup),
+	}
+	return c, nil
+}
+
+// newRepositories fills every repository that is not overridden with its Postgres implementation
+func newRepositories(db *gorm.DB, paymentCfg config.PaymentConfig, overrides Repositories) (Repositories, error) {
+	repos := overrides
+	if repos.User == nil {
+		repos.User = repository.NewUserRepository(db)
+	}
+	if repos.Transaction == nil {
+		repos.Transaction = repository.NewTransactionRepository(db)
+	}
+	if repos.Wallet == nil {
+		repos.Wallet = repository.NewWalletRepository(db)
+	}
+	if repos.KYC == nil {
+		repos.KYC = repository.NewKYCRepository(db)
+	}
+	if repos.Outbox == nil {
+		repos.Outbox = repository.NewOutboxRepository(db)
+	}
+	if repos.WebhookSubscription == nil {
+		repos.WebhookSubscription = repository.NewWebhookSubscriptionRepository(db)
+	}
+	if repos.WebhookDelivery == nil {
+		repos.WebhookDelivery = repository.NewWebhookDeliveryRepository(db)
+	}
+	if repos.Setting == nil {
+		repos.Setting = repository.NewSettingRepository(db)
+	}
+	if repos.Voucher == nil {
+		repos.Voucher = repository.NewVoucherRepository(db)
+	}
+	if repos.AutoTopup == nil {
+		repos.AutoTopup = repository.NewAutoTopupRepository(db)
+	}
+	if repos.Instrument == nil {
+		cipher, err := infrastructure.NewAESGCMCipher(paymentCfg.InstrumentKey)
+		if err != nil {
+			return Repositories{}, fmt.Errorf("initialize payment instrument encryption: %w", err)
+		}
+		repos.Instrument = repository.NewPaymentInstrumentRepository(db, cipher)
+	}
+	if repos.Reconciliation == nil {
+		repos.Reconciliation = repository.NewReconciliationRepository(db)
+	}
+	if repos.Statement == nil {
+		repos.Statement = repository.NewStatementRepository(db)
+	}
+	if repos.TxManager == nil {
+		repos.TxManager = repository.NewTxManagerGorm(db)
+	}
+	return repos, nil
+}
+
+// WorkerNames lists the background workers accepted by StartWorkers
+var WorkerNames = []string{"outbox", "webhooks", "expiry", "autotopup"}
+
+// ParseWorkers splits a comma-separated worker list and rejects unknown names
+func ParseWorkers(list string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if !slices.Contains(WorkerNames, name) {
+			return nil, fmt.Errorf("unknown worker %q (available: %s)", name, strings.Join(WorkerNames, ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// StartWorkers runs the named workers under the lifecycle
+func (c *Container) StartWorkers(lifecycle *infrastructure.Lifecycle, names []string) {
+	workers := map[string]func(ctx context.Context){
+		"outbox":    c.Workers.OutboxRelay.Run,
+		"webhooks":  c.Workers.WebhookDispatcher.Run,
+		"expiry":    c.Workers.ExpirySweeper.Run,
+		"autotopup": c.Workers.AutoTopup.Run,
+	}
+	for _, name := range names {
+		lifecycle.Go(name, workers[name])
+	}
+}
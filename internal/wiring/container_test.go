@@ -0,0 +1,155 @@
+package wiring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	errs "github.com/hydr0g3nz/wallet_topup_system/internal/domain/error"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, map[string]interface{})        {}
+func (nopLogger) Info(string, map[string]interface{})         {}
+func (nopLogger) Warn(string, map[string]interface{})         {}
+func (nopLogger) Error(string, map[string]interface{})        {}
+func (nopLogger) Fatal(string, map[string]interface{})        {}
+func (l nopLogger) With(map[string]interface{}) logger.Logger { return l }
+func (l nopLogger) WithContext(context.Context) logger.Logger { return l }
+func (nopLogger) Sync() error                                 { return nil }
+func (nopLogger) Level() string                               { return "info" }
+func (nopLogger) SetLevel(string) error                       { return nil }
+
+// Embedding the interfaces satisfies them; a test fails loudly if it reaches a method it did not fake
+type (
+	fakeUserRepo           struct{ user.Repository }
+	fakeTransactionRepo    struct{ transaction.Repository }
+	fakeWalletRepo         struct{ wallet.Repository }
+	fakeKYCRepo            struct{ kyc.Repository }
+	fakeOutboxRepo         struct{ outbox.Repository }
+	fakeSubscriptionRepo   struct{ webhook.SubscriptionRepository }
+	fakeDeliveryRepo       struct{ webhook.DeliveryRepository }
+	fakeVoucherRepo        struct{ voucher.Repository }
+	fakeAutoTopupRepo      struct{ autotopup.Repository }
+	fakeInstrumentRepo     struct{ instrument.Repository }
+	fakeReconciliationRepo struct{ reconciliation.Repository }
+	fakeStatementRepo      struct{ statement.Repository }
+	fakeTxManager          struct{ domain.TxManager }
+)
+
+type fakeSettingRepo struct {
+	setting.Repository
+	stored []setting.Setting
+}
+
+func (r *fakeSettingRepo) FindAll(ctx context.Context) ([]setting.Setting, error) {
+	return r.stored, nil
+}
+
+func newTestContainer(t *testing.T, settings *fakeSettingRepo) *Container {
+	cfg := config.Default()
+	cfg.Storage.BaseDir = t.TempDir()
+	memoryCache := infrastructure.NewMemoryCache(infrastructure.MemoryCacheConfig{MaxEntries: 100, JanitorInterval: time.Minute})
+	t.Cleanup(func() { _ = memoryCache.Close() })
+	b, err := NewBase(&cfg, Overrides{
+		Logger: nopLogger{},
+		Repositories: Repositories{
+			User:                fakeUserRepo{},
+			Transaction:         fakeTransactionRepo{},
+			Wallet:              fakeWalletRepo{},
+			KYC:                 fakeKYCRepo{},
+			Outbox:              fakeOutboxRepo{},
+			WebhookSubscription: fakeSubscriptionRepo{},
+			WebhookDelivery:     fakeDeliveryRepo{},
+			Setting:             settings,
+			Voucher:             fakeVoucherRepo{},
+			AutoTopup:           fakeAutoTopupRepo{},
+			Instrument:          fakeInstrumentRepo{},
+			Reconciliation:      fakeReconciliationRepo{},
+			Statement:           fakeStatementRepo{},
+			TxManager:           fakeTxManager{},
+		},
+		Cache: memoryCache,
+	})
+	require.NoError(t, err)
+	c, err := New(b)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = b.Close(context.Background()) })
+	return c
+}
+
+func TestContainerBuildsWithoutDatabaseWhenRepositoriesAreOverridden(t *testing.T) {
+	c := newTestContainer(t, &fakeSettingRepo{})
+
+	assert.Nil(t, c.DB)
+	assert.Nil(t, c.Migrator)
+	assert.NotNil(t, c.Usecases.Wallet)
+	assert.NotNil(t, c.Workers.OutboxRelay)
+	assert.IsType(t, fakeUserRepo{}, c.Repositories.User)
+}
+
+func TestContainerUsesOverriddenSettingsRepository(t *testing.T) {
+	settings := &fakeSettingRepo{stored: []setting.Setting{{Key: setting.KeyMaxAcceptedAmount, Value: "100"}}}
+	c := newTestContainer(t, settings)
+
+	values, err := c.Usecases.Settings.Current(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, values.MaxAcceptedAmount)
+
+	// The stored limit is enforced before any other repository is used
+	_, err = c.Usecases.Wallet.VerifyTopup(context.Background(), 1, 500, 1)
+	assert.ErrorIs(t, err, errs.ErrAmountExceedsLimit)
+}
+
+func TestAdminRoutesRequireAPIKey(t *testing.T) {
+	c := newTestContainer(t, &fakeSettingRepo{})
+	app := fiber.New()
+	c.RegisterAPIRoutes(app)
+
+	request := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/admin/vouchers"},
+		{http.MethodGet, "/api/v1/admin/vouchers/export"},
+		{http.MethodGet, "/api/v1/admin/settings"},
+		{http.MethodPut, "/api/v1/admin/log-level"},
+		{http.MethodGet, "/api/v1/admin/outbox/dead"},
+		{http.MethodGet, "/api/v1/admin/risk/transactions"},
+		{http.MethodPost, "/api/v1/admin/reconciliations"},
+		{http.MethodGet, "/api/v1/admin/webhooks/subscriptions"},
+	} {
+		assert.Equal(t, http.StatusUnauthorized, request(route.method, route.path, ""), "%s %s without a key", route.method, route.path)
+		assert.Equal(t, http.StatusUnauthorized, request(route.method, route.path, "wrong-key"), "%s %s with a wrong key", route.method, route.path)
+	}
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/api/v1/admin/log-level", config.DevelopmentAdminAPIKey))
+}
@@ -0,0 +1,48 @@
+package wiring
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/wallet_topup_system/config"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/lock"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+)
+
+// newCacheService builds the cache backend selected in config and a function that releases it.
+// The Redis connection itself is closed separately.
+func newCacheService(cfg config.CacheBackendConfig, redisClient *infrastructure.RedisClient, logger logger.Logger) (cache.CacheService, func() error) {
+	memory := func() *infrastructure.MemoryCache {
+		return infrastructure.NewMemoryCache(infrastructure.MemoryCacheConfig{
+			MaxEntries:      cfg.MaxEntries,
+			JanitorInterval: time.Duration(cfg.JanitorInterval) * time.Second,
+		})
+	}
+	switch cfg.Backend {
+	case "memory":
+		c := memory()
+		return c, c.Close
+	case "tiered":
+		c := infrastructure.NewTieredCache(memory(), redisClient, infrastructure.TieredCacheConfig{
+			LocalTTL:            time.Duration(cfg.LocalTTL) * time.Second,
+			InvalidationChannel: cfg.InvalidationChannel,
+		}, logger)
+		return c, c.Close
+	default:
+		return redisClient, func() error { return nil }
+	}
+}
+
+// newLocker uses Redis locks when Redis is configured and process-local locks otherwise
+func newLocker(cfg config.LockConfig, redisClient *infrastructure.RedisClient) lock.Locker {
+	lockerCfg := infrastructure.LockerConfig{
+		Prefix:    cfg.Prefix,
+		RetryBase: time.Duration(cfg.RetryBase) * time.Millisecond,
+		RetryMax:  time.Duration(cfg.RetryMax) * time.Millisecond,
+	}
+	if redisClient == nil {
+		return infrastructure.NewMemoryLocker(lockerCfg)
+	}
+	return infrastructure.NewRedisLocker(redisClient, lockerCfg)
+}
@@ -0,0 +1,71 @@
+package wiring
+
+import (
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/autotopup"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/cache"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/event"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/instrument"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/kyc"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/lock"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/logger"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/outbox"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/payment"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/reconciliation"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/setting"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/statement"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/storage"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/transaction"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/user"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/voucher"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/wallet"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/domain/webhook"
+	"gorm.io/gorm"
+)
+
+// Logger is a logger whose level can be changed at runtime through the admin API
+type Logger interface {
+	logger.Logger
+	logger.LevelController
+}
+
+// Repositories groups the persistence ports used by the use cases
+type Repositories struct {
+	User                user.Repository
+	Transaction         transaction.Repository
+	Wallet              wallet.Repository
+	KYC                 kyc.Repository
+	Outbox              outbox.Repository
+	WebhookSubscription webhook.SubscriptionRepository
+	WebhookDelivery     webhook.DeliveryRepository
+	Setting             setting.Repository
+	Voucher             voucher.Repository
+	AutoTopup           autotopup.Repository
+	Instrument          instrument.Repository
+	Reconciliation      reconciliation.Repository
+	Statement           statement.Repository
+	TxManager           domain.TxManager
+}
+
+// complete reports whether every repository is set, in which case no database is needed
+func (r Repositories) complete() bool {
+	return r.User != nil && r.Transaction != nil && r.Wallet != nil && r.KYC != nil && r.Outbox != nil &&
+		r.WebhookSubscription != nil && r.WebhookDelivery != nil && r.Setting != nil && r.Voucher != nil && r.AutoTopup != nil &&
+		r.Instrument != nil && r.Reconciliation != nil && r.Statement != nil && r.TxManager != nil
+}
+
+// Overrides replaces parts of the graph, typically with fakes in tests. Nil fields are built from config.
+type Overrides struct {
+	Logger Logger
+	// DB is used instead of connecting with the configured settings. When every repository is
+	// overridden and DB is nil, no database is used at all.
+	DB             *gorm.DB
+	Repositories   Repositories
+	Cache          cache.CacheService
+	Locker         lock.Locker
+	Storage        storage.BlobStorage
+	EventPublisher event.EventPublisher
+	WebhookSender  webhook.Sender
+	// PaymentProvider charges saved payment instruments for auto top-ups
+	PaymentProvider payment.Provider
+}
@@ -0,0 +1,34 @@
+package wiring
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/adapter/controller"
+	"github.com/hydr0g3nz/wallet_topup_system/internal/infrastructure"
+)
+
+// RegisterHealthRoutes registers the liveness and readiness probes
+func (c *Container) RegisterHealthRoutes(router fiber.Router) {
+	controller.NewHealthController(c.Usecases.Health).RegisterRoutes(router)
+}
+
+// RegisterAPIRoutes registers all API routes under /api/v1. Routes under /api/v1/admin require
+// the admin API key.
+func (c *Container) RegisterAPIRoutes(router fiber.Router) {
+	api := router.Group("/api/v1")
+	// Registered ahead of the controllers so it runs before any admin handler
+	api.Use("/admin", infrastructure.AdminAuthMiddleware(c.Config.Admin.APIKey))
+	controller.NewWalletController(c.Usecases.Wallet).RegisterRoutes(api)
+	controller.NewKYCController(c.Usecases.KYC).RegisterRoutes(api)
+	controller.NewRiskController(c.Usecases.RiskReview).RegisterRoutes(api)
+	controller.NewOutboxController(c.Usecases.Outbox).RegisterRoutes(api)
+	controller.NewWebhookController(c.Usecases.Webhook).RegisterRoutes(api)
+	controller.NewSettingController(c.Usecases.Settings).RegisterRoutes(api)
+	controller.NewVoucherController(c.Usecases.Voucher).RegisterRoutes(api)
+	controller.NewAutoTopupController(c.Usecases.AutoTopup).RegisterRoutes(api)
+	controller.NewInstrumentController(c.Usecases.Instrument).RegisterRoutes(api)
+	controller.NewReconciliationController(c.Usecases.Reconciliation).RegisterRoutes(api)
+	controller.NewStatementController(c.Usecases.Statement, time.Duration(c.Config.Server.WriteTimeout)*time.Second).RegisterRoutes(api)
+	controller.NewLoggingController(c.Logger).RegisterRoutes(api)
+}